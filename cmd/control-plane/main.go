@@ -11,6 +11,7 @@ import (
 	internalflags "github.com/solo-io/gloo/internal/control-plane/bootstrap/flags"
 	"github.com/solo-io/gloo/internal/control-plane/eventloop"
 	"github.com/solo-io/gloo/pkg/bootstrap/flags"
+	"github.com/solo-io/gloo/pkg/metrics"
 	"github.com/solo-io/gloo/pkg/signals"
 
 	//register plugins
@@ -34,6 +35,11 @@ var rootCmd = &cobra.Command{
 	Short: "runs the gloo control plane to manage Envoy as a Function Gateway",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		stop := signals.SetupSignalHandler()
+		if opts.MetricsOptions.BindAddress != "" {
+			if err := metrics.Serve(opts.MetricsOptions.BindAddress, stop); err != nil {
+				return errors.Wrap(err, "starting metrics server")
+			}
+		}
 		eventLoop, err := eventloop.Setup(opts, xdsPort, stop)
 		if err != nil {
 			return errors.Wrap(err, "setting up event loop")
@@ -56,12 +62,30 @@ func init() {
 	flags.AddFileFlags(rootCmd, baseOpts)
 	flags.AddKubernetesFlags(rootCmd, baseOpts)
 	flags.AddConsulFlags(rootCmd, baseOpts)
+	flags.AddEtcdFlags(rootCmd, baseOpts)
+	flags.AddGitFlags(rootCmd, baseOpts)
+	flags.AddCompositeFlags(rootCmd, baseOpts)
 	flags.AddCoPilotFlags(rootCmd, baseOpts)
 	flags.AddVaultFlags(rootCmd, baseOpts)
 
+	// prometheus metrics
+	flags.AddMetricsFlags(rootCmd, baseOpts)
+
 	// xds port
 	rootCmd.PersistentFlags().IntVar(&xdsPort, "xds.port", 8081, "port to serve envoy xDS services. this port should be specified in your envoy's static config")
 
 	// Ingress flags
 	internalflags.AddIngressFlags(rootCmd, &opts)
+
+	// coalescing of watch events
+	internalflags.AddSyncFlags(rootCmd, &opts)
+
+	// admin api
+	internalflags.AddAdminFlags(rootCmd, &opts)
+
+	// validating admission webhook for crds
+	internalflags.AddAdmissionFlags(rootCmd, &opts)
+
+	// translator plugins that run outside of the control plane
+	internalflags.AddPluginFlags(rootCmd, &opts)
 }
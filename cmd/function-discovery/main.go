@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/solo-io/gloo/internal/function-discovery/eventloop"
@@ -11,6 +12,7 @@ import (
 	"github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/bootstrap/flags"
 	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/metrics"
 	"github.com/solo-io/gloo/pkg/signals"
 )
 
@@ -34,6 +36,12 @@ var rootCmd = &cobra.Command{
 		stop := signals.SetupSignalHandler()
 		errs := make(chan error)
 
+		if opts.MetricsOptions.BindAddress != "" {
+			if err := metrics.Serve(opts.MetricsOptions.BindAddress, stop); err != nil {
+				return errors.Wrap(err, "starting metrics server")
+			}
+		}
+
 		finished := make(chan error)
 		go func() { finished <- eventloop.Run(opts, discoveryOpts, stop, errs) }()
 		go func() {
@@ -58,8 +66,17 @@ func init() {
 	flags.AddFileFlags(rootCmd, &opts)
 	flags.AddKubernetesFlags(rootCmd, &opts)
 	flags.AddConsulFlags(rootCmd, &opts)
+	flags.AddEtcdFlags(rootCmd, &opts)
+	flags.AddGitFlags(rootCmd, &opts)
+	flags.AddCompositeFlags(rootCmd, &opts)
 	flags.AddVaultFlags(rootCmd, &opts)
 
+	// prometheus metrics
+	flags.AddMetricsFlags(rootCmd, &opts)
+
+	// leader election between replicas
+	flags.AddLeaderElectionFlags(rootCmd, &opts)
+
 	// function discovery: upstream service type detection
 	rootCmd.PersistentFlags().BoolVar(&discoveryOpts.AutoDiscoverSwagger, "detect-swagger-upstreams", true, "enable automatic discovery of upstreams that implement Swagger by querying for common Swagger Doc endpoints.")
 	rootCmd.PersistentFlags().BoolVar(&discoveryOpts.AutoDiscoverNATS, "detect-nats-upstreams", true, "enable automatic discovery of upstreams that are running NATS by connecting to the default cluster id.")
@@ -13,6 +13,8 @@ import (
 	"github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
 	"github.com/solo-io/gloo/pkg/bootstrap/flags"
+	leaderelectionsetup "github.com/solo-io/gloo/pkg/bootstrap/leaderelection"
+	"github.com/solo-io/gloo/pkg/leaderelection"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/gloo/pkg/signals"
 	"github.com/solo-io/gloo/pkg/storage"
@@ -44,7 +46,17 @@ var rootCmd = &cobra.Command{
 		}
 		stop := signals.SetupSignalHandler()
 
-		go runIngressController(cfg, store, stop)
+		elector, err := leaderelectionsetup.Bootstrap(opts, "gloo-kube-ingress-controller")
+		if err != nil {
+			return errors.Wrap(err, "failed to set up leader election")
+		}
+		go func() {
+			if err := elector.Run(stop); err != nil {
+				log.Warnf("leader election stopped: %v", err)
+			}
+		}()
+
+		go runIngressController(cfg, leaderelection.Storage(store, elector), elector, stop)
 
 		<-stop
 		log.Printf("shutting down")
@@ -60,20 +72,26 @@ func init() {
 	flags.AddFileFlags(rootCmd, &opts)
 	flags.AddKubernetesFlags(rootCmd, &opts)
 	flags.AddConsulFlags(rootCmd, &opts)
+	flags.AddEtcdFlags(rootCmd, &opts)
+	flags.AddGitFlags(rootCmd, &opts)
+	flags.AddCompositeFlags(rootCmd, &opts)
+
+	// leader election between replicas
+	flags.AddLeaderElectionFlags(rootCmd, &opts)
 
 	// ingress-specific
 	rootCmd.PersistentFlags().BoolVar(&globalIngress, "global", true, "use gloo as the cluster-wide kubernetes ingress")
 	rootCmd.PersistentFlags().StringVar(&ingressServiceName, "service", "", "The name of the proxy service (envoy) if running in-cluster. If --service is set, the ingress controller will update ingress objects with the load balancer endpoints")
 }
 
-func runIngressController(cfg *rest.Config, store storage.Interface, stop <-chan struct{}) error {
+func runIngressController(cfg *rest.Config, store storage.Interface, elector leaderelection.Elector, stop <-chan struct{}) error {
 	ingressCtl, err := ingress.NewIngressController(cfg, store, opts.ConfigStorageOptions.SyncFrequency, globalIngress)
 	if err != nil {
 		return errors.Wrap(err, "failed to create ingress controller")
 	}
 
 	if ingressServiceName != "" {
-		ingressSync, err := ingress.NewIngressSyncer(cfg, opts.ConfigStorageOptions.SyncFrequency, stop, globalIngress, ingressServiceName)
+		ingressSync, err := ingress.NewIngressSyncer(cfg, opts.ConfigStorageOptions.SyncFrequency, stop, globalIngress, ingressServiceName, elector)
 		if err != nil {
 			return errors.Wrap(err, "failed to start load balancer status syncer")
 		}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	internalflags "github.com/solo-io/gloo/internal/control-plane/bootstrap/flags"
+	"github.com/solo-io/gloo/internal/control-plane/offline"
+	"github.com/solo-io/gloo/pkg/bootstrap/flags"
+	"github.com/solo-io/gloo/pkg/plugins"
+
+	//register plugins
+	_ "github.com/solo-io/gloo/internal/control-plane/install"
+)
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+var (
+	opts   bootstrap.Options
+	output string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "gloo-translate [dir]",
+	Short: "translates gloo config stored in local directories into envoy config, without running the control plane",
+	Long: "reads upstreams, virtual hosts, secrets and files in the layout used by file storage, and prints the " +
+		"listeners, clusters, routes and endpoints gloo would send to envoy along with a report for every config " +
+		"object. exits non-zero if any config object is rejected. relative storage directories are resolved " +
+		"against [dir], which defaults to the current directory.",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		out, err := offline.Translate(offline.Options{
+			ConfigDir:          inDir(root, opts.FileOptions.ConfigDir),
+			SecretDir:          inDir(root, opts.FileOptions.SecretDir),
+			FilesDir:           inDir(root, opts.FileOptions.FilesDir),
+			IngressBindAddress: opts.IngressOptions.BindAddress,
+		}, plugins.RegisteredPlugins())
+		if err != nil {
+			return err
+		}
+		if err := offline.Print(os.Stdout, out, output); err != nil {
+			return err
+		}
+		if rejected := out.Rejected(); rejected > 0 {
+			return errors.Errorf("%v config objects were rejected", rejected)
+		}
+		return nil
+	},
+}
+
+func inDir(root, dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(root, dir)
+}
+
+func init() {
+	// file storage layout
+	flags.AddFileFlags(rootCmd, &opts.Options)
+
+	// Ingress flags
+	internalflags.AddIngressFlags(rootCmd, &opts)
+
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", offline.FormatYAML, "output format, one of json or yaml")
+}
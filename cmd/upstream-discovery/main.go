@@ -12,7 +12,10 @@ import (
 	internalflags "github.com/solo-io/gloo/internal/upstream-discovery/bootstrap/flags"
 	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
 	"github.com/solo-io/gloo/pkg/bootstrap/flags"
+	leaderelectionsetup "github.com/solo-io/gloo/pkg/bootstrap/leaderelection"
+	"github.com/solo-io/gloo/pkg/leaderelection"
 	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/metrics"
 	"github.com/solo-io/gloo/pkg/signals"
 )
 
@@ -35,12 +38,29 @@ var rootCmd = &cobra.Command{
 		}
 		stop := signals.SetupSignalHandler()
 
+		if opts.MetricsOptions.BindAddress != "" {
+			if err := metrics.Serve(opts.MetricsOptions.BindAddress, stop); err != nil {
+				return errors.Wrap(err, "starting metrics server")
+			}
+		}
+
 		// enable kubernetes service discovery by default if no discovery option has been enabled
 		if !opts.UpstreamDiscoveryOptions.DiscoveryEnabled() {
 			opts.UpstreamDiscoveryOptions.EnableDiscoveryForKubernetes = true
 		}
 
-		if err := upstreamdiscovery.Start(opts, store, stop); err != nil {
+		elector, err := leaderelectionsetup.Bootstrap(opts.Options, "gloo-upstream-discovery")
+		if err != nil {
+			return errors.Wrap(err, "failed to set up leader election")
+		}
+		go func() {
+			if err := elector.Run(stop); err != nil {
+				log.Warnf("leader election stopped: %v", err)
+			}
+		}()
+
+		// every replica discovers upstreams, only the leader writes them
+		if err := upstreamdiscovery.Start(opts, leaderelection.Storage(store, elector), stop); err != nil {
 			return errors.Wrap(err, "initializing upstream discovery failed")
 		}
 
@@ -59,6 +79,9 @@ func init() {
 	// storage backends
 	flags.AddFileFlags(rootCmd, baseOpts)
 	flags.AddConsulFlags(rootCmd, baseOpts)
+	flags.AddEtcdFlags(rootCmd, baseOpts)
+	flags.AddGitFlags(rootCmd, baseOpts)
+	flags.AddCompositeFlags(rootCmd, baseOpts)
 
 	// kubernetes flags
 	// used for both storage and ud
@@ -70,4 +93,10 @@ func init() {
 
 	// upstream discovery options
 	internalflags.AddUpstreamDiscoveryFlags(rootCmd, &opts)
+
+	// prometheus metrics
+	flags.AddMetricsFlags(rootCmd, baseOpts)
+
+	// leader election between replicas
+	flags.AddLeaderElectionFlags(rootCmd, baseOpts)
 }
@@ -4,25 +4,38 @@ import (
 	"bytes"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"html/template"
 	"io/ioutil"
 	"log"
+	"sort"
 
 	"os"
 
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/ilackarms/protoc-gen-doc"
+
+	_ "github.com/solo-io/gloo/internal/control-plane/install"
+	_ "github.com/solo-io/gloo/pkg/coreplugins/route-extensions"
+	_ "github.com/solo-io/gloo/pkg/coreplugins/service"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
 func main() {
 	f := flag.String("f", os.Getenv("GOPATH")+"/src/github.com/solo-io/gloo/docs/"+"api.json", "input json file")
 	tmplFile := flag.String("t", os.Getenv("GOPATH")+"/src/github.com/solo-io/gloo/docs/markdown.tmpl", "template to build from")
 	outDir := flag.String("o", os.Getenv("GOPATH")+"/src/github.com/solo-io/gloo/docs/v1/", "output dir")
+	specsFile := flag.String("specs", os.Getenv("GOPATH")+"/src/github.com/solo-io/gloo/docs/v1/plugin_specs.md", "output file for the plugin spec schemas")
 	flag.Parse()
 	if err := run(*f, *tmplFile, *outDir); err != nil {
 		log.Fatal(err)
 	}
+	if err := writeSpecs(*specsFile); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func run(file, tmplFile, outDir string) error {
@@ -187,3 +200,66 @@ func isObjectType(longType string) bool {
 	}
 	return true
 }
+
+// writeSpecs documents the spec schemas registered by the plugins
+func writeSpecs(file string) error {
+	var buf bytes.Buffer
+	buf.WriteString("<a name=\"top\"></a>\n\n# Plugin Specs\n\n")
+	buf.WriteString("The `spec` of upstreams and functions, the `properties` of service info and the `extensions` of routes " +
+		"are decoded by plugins. Writes to config storage are rejected if they do not match the schemas below.\n\n")
+	for _, s := range schemas.Registered() {
+		fmt.Fprintf(&buf, "<a name=\"%v.%v\"></a>\n\n## %v (%v)\n\n", s.Kind, s.Type, s.Type, s.Kind)
+		fmt.Fprintf(&buf, "Version: `%v`\n\n", s.Version)
+		if s.Description != "" {
+			fmt.Fprintf(&buf, "%v\n\n", s.Description)
+		}
+		buf.WriteString("| Field | Type | Description |\n| ----- | ---- | ----------- |\n")
+		writeFields(&buf, "", s.Properties())
+		if s.Example != nil {
+			jsn, err := protoutil.Marshal(s.Example)
+			if err != nil {
+				return err
+			}
+			yam, err := yaml.JSONToYAML(jsn)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&buf, "\nExample:\n\n```yaml\n%v```\n", yam)
+		}
+		buf.WriteString("\n")
+	}
+	return ioutil.WriteFile(file, buf.Bytes(), 0644)
+}
+
+// writeFields adds a row for every field of the object, naming nested fields by their path
+func writeFields(buf *bytes.Buffer, prefix string, p *schemas.Property) {
+	var names []string
+	for name := range p.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := p.Properties[name]
+		fmt.Fprintf(buf, "| `%v%v` | %v | %v |\n", prefix, name, specType(field), field.Description)
+		switch {
+		case len(field.Properties) > 0:
+			writeFields(buf, prefix+name+".", field)
+		case field.Items != nil && len(field.Items.Properties) > 0:
+			writeFields(buf, prefix+name+"[].", field.Items)
+		}
+	}
+}
+
+func specType(p *schemas.Property) string {
+	switch {
+	case p.Items != nil:
+		return "[" + specType(p.Items) + "]"
+	case p.AdditionalProperties != nil:
+		return "map<string," + specType(p.AdditionalProperties) + ">"
+	case p.Type == "":
+		return "any"
+	case p.Format != "":
+		return p.Type + " (" + p.Format + ")"
+	}
+	return p.Type
+}
@@ -0,0 +1,206 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/internal/control-plane/dump"
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+const (
+	PathConfig    = "/config"
+	PathSnapshot  = "/snapshot"
+	PathReports   = "/reports"
+	PathRefs      = "/refs"
+	PathEndpoints = "/endpoints"
+	PathReady     = "/ready"
+
+	PathRefusedSnapshot = "/snapshot/refused"
+	PathAcceptSnapshot  = "/snapshot/accept"
+)
+
+// State is the view of the control plane served by the admin API
+type State struct {
+	// the latest config read from storage
+	Config *v1.Config
+	// the last snapshot sent to envoy
+	Snapshot *envoycache.Snapshot
+	// a snapshot that was not sent to envoy because it removes too many routes, and why
+	RefusedSnapshot *envoycache.Snapshot
+	RefusedReason   string
+	// the reports from the last translation
+	Reports []reporter.ConfigObjectReport
+	// refs of the secrets and files the secret and file watchers are tracking
+	SecretRefs []string
+	FileRefs   []string
+	// endpoint groups by the name of the discovery that found them
+	Endpoints map[string]endpointdiscovery.EndpointGroups
+	// whether each watcher has delivered its first update
+	Ready map[string]bool
+}
+
+// StateFunc returns a copy of the current state. it is called from the admin server's goroutines
+type StateFunc func() State
+
+// Actions are the changes to the control plane that can be made through the admin API.
+// they are called from the admin server's goroutines
+type Actions struct {
+	// AcceptSnapshot sends the refused snapshot to envoy
+	AcceptSnapshot func() error
+}
+
+// NewHandler serves the admin API. if token is not empty, every request must carry it as a bearer token
+func NewHandler(token string, state StateFunc, actions Actions) http.Handler {
+	m := http.NewServeMux()
+	m.HandleFunc(PathConfig, func(w http.ResponseWriter, r *http.Request) {
+		cfg := state().Config
+		if cfg == nil {
+			http.Error(w, "no config has been read yet", http.StatusServiceUnavailable)
+			return
+		}
+		data, err := protoutil.Marshal(cfg)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "marshalling config").Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+	m.HandleFunc(PathSnapshot, func(w http.ResponseWriter, r *http.Request) {
+		snap := state().Snapshot
+		if snap == nil {
+			http.Error(w, "no snapshot has been translated yet", http.StatusServiceUnavailable)
+			return
+		}
+		out, err := dump.Snapshot(snap)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+	m.HandleFunc(PathRefusedSnapshot, func(w http.ResponseWriter, r *http.Request) {
+		s := state()
+		if s.RefusedSnapshot == nil {
+			http.Error(w, "no snapshot has been refused", http.StatusNotFound)
+			return
+		}
+		out, err := dump.Snapshot(s.RefusedSnapshot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, refusedSnapshot{Reason: s.RefusedReason, Snapshot: out})
+	})
+	m.HandleFunc(PathAcceptSnapshot, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if actions.AcceptSnapshot == nil {
+			http.Error(w, "accepting snapshots is not supported", http.StatusNotImplemented)
+			return
+		}
+		if err := actions.AcceptSnapshot(); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	m.HandleFunc(PathReports, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, dump.Reports(state().Reports))
+	})
+	m.HandleFunc(PathRefs, func(w http.ResponseWriter, r *http.Request) {
+		s := state()
+		writeJSON(w, http.StatusOK, refs{Secrets: sorted(s.SecretRefs), Files: sorted(s.FileRefs)})
+	})
+	m.HandleFunc(PathEndpoints, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, state().Endpoints)
+	})
+	m.HandleFunc(PathReady, func(w http.ResponseWriter, r *http.Request) {
+		ready := state().Ready
+		status := http.StatusOK
+		for _, ok := range ready {
+			if !ok {
+				status = http.StatusServiceUnavailable
+			}
+		}
+		writeJSON(w, status, ready)
+	})
+	if token == "" {
+		return m
+	}
+	return requireToken(token, m)
+}
+
+// Run serves the admin API on addr until stop is closed
+func Run(addr, token string, state StateFunc, actions Actions, stop <-chan struct{}) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %v", addr)
+	}
+	server := &http.Server{Handler: NewHandler(token, state, actions)}
+	go func() {
+		log.Printf("admin server listening on %v", lis.Addr())
+		if err := server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.Warnf("admin server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-stop
+		server.Shutdown(context.Background())
+	}()
+	return nil
+}
+
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type refusedSnapshot struct {
+	Reason   string                    `json:"reason"`
+	Snapshot map[string]dump.Resources `json:"snapshot"`
+}
+
+type refs struct {
+	Secrets []string `json:"secrets"`
+	Files   []string `json:"files"`
+}
+
+func sorted(in []string) []string {
+	out := append([]string{}, in...)
+	sort.Strings(out)
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,173 @@
+package admin_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	. "github.com/solo-io/gloo/internal/control-plane/admin"
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+var _ = Describe("Admin", func() {
+	var (
+		state    State
+		srv      *httptest.Server
+		accepted int
+		actions  = Actions{AcceptSnapshot: func() error {
+			if state.RefusedSnapshot == nil {
+				return errors.New("no snapshot has been refused")
+			}
+			accepted++
+			return nil
+		}}
+	)
+	get := func(path string, token string) (int, []byte) {
+		req, err := http.NewRequest("GET", srv.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return res.StatusCode, body
+	}
+	BeforeEach(func() {
+		upstream := &v1.Upstream{Name: "my-upstream", Type: "service"}
+		vhost := &v1.VirtualHost{Name: "my-vhost"}
+		state = State{
+			Config: &v1.Config{
+				Upstreams:    []*v1.Upstream{upstream},
+				VirtualHosts: []*v1.VirtualHost{vhost},
+			},
+			Snapshot: &envoycache.Snapshot{
+				Clusters: envoycache.NewResources("1", []envoycache.Resource{
+					&envoyapi.Cluster{Name: "my-upstream"},
+				}),
+			},
+			Reports: []reporter.ConfigObjectReport{
+				{CfgObject: upstream},
+				{CfgObject: vhost, Err: errors.New("bad vhost")},
+			},
+			SecretRefs: []string{"b-secret", "a-secret"},
+			FileRefs:   []string{"a-file"},
+			Endpoints: map[string]endpointdiscovery.EndpointGroups{
+				"kube": {"my-upstream": {{Address: "1.2.3.4", Port: 80}}},
+			},
+			Ready: map[string]bool{"config": true, "secrets": true},
+		}
+	})
+	AfterEach(func() {
+		srv.Close()
+	})
+	Context("without a token", func() {
+		BeforeEach(func() {
+			srv = httptest.NewServer(NewHandler("", func() State { return state }, actions))
+		})
+		It("serves the current config", func() {
+			status, body := get(PathConfig, "")
+			Expect(status).To(Equal(http.StatusOK))
+			var cfg v1.Config
+			Expect(protoutil.Unmarshal(body, &cfg)).To(Succeed())
+			Expect(&cfg).To(Equal(state.Config))
+		})
+		It("serves the last snapshot as envoy json", func() {
+			status, body := get(PathSnapshot, "")
+			Expect(status).To(Equal(http.StatusOK))
+			var snap map[string]struct {
+				Version string                     `json:"version"`
+				Items   map[string]json.RawMessage `json:"items"`
+			}
+			Expect(json.Unmarshal(body, &snap)).To(Succeed())
+			Expect(snap["clusters"].Version).To(Equal("1"))
+			var cluster envoyapi.Cluster
+			Expect(protoutil.Unmarshal(snap["clusters"].Items["my-upstream"], &cluster)).To(Succeed())
+			Expect(cluster.Name).To(Equal("my-upstream"))
+			Expect(snap["endpoints"].Items).To(BeEmpty())
+		})
+		It("serves the reports", func() {
+			status, body := get(PathReports, "")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(MatchJSON(`[
+				{"kind": "upstream", "name": "my-upstream"},
+				{"kind": "virtual_host", "name": "my-vhost", "error": "bad vhost"}
+			]`))
+		})
+		It("serves the tracked refs", func() {
+			status, body := get(PathRefs, "")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(MatchJSON(`{"secrets": ["a-secret", "b-secret"], "files": ["a-file"]}`))
+		})
+		It("serves the endpoints by discovery", func() {
+			status, body := get(PathEndpoints, "")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(MatchJSON(`{"kube": {"my-upstream": [{"Address": "1.2.3.4", "Port": 80}]}}`))
+		})
+		It("reports readiness of the watchers", func() {
+			status, _ := get(PathReady, "")
+			Expect(status).To(Equal(http.StatusOK))
+			state.Ready["files"] = false
+			status, body := get(PathReady, "")
+			Expect(status).To(Equal(http.StatusServiceUnavailable))
+			Expect(body).To(MatchJSON(`{"config": true, "secrets": true, "files": false}`))
+		})
+		It("returns unavailable before the first snapshot", func() {
+			state.Snapshot = nil
+			status, _ := get(PathSnapshot, "")
+			Expect(status).To(Equal(http.StatusServiceUnavailable))
+		})
+		It("serves and accepts the refused snapshot", func() {
+			status, _ := get(PathRefusedSnapshot, "")
+			Expect(status).To(Equal(http.StatusNotFound))
+			res, err := http.Post(srv.URL+PathAcceptSnapshot, "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.StatusCode).To(Equal(http.StatusConflict))
+
+			state.RefusedSnapshot = state.Snapshot
+			state.RefusedReason = "removes too many routes"
+			status, body := get(PathRefusedSnapshot, "")
+			Expect(status).To(Equal(http.StatusOK))
+			var refused struct {
+				Reason string `json:"reason"`
+			}
+			Expect(json.Unmarshal(body, &refused)).To(Succeed())
+			Expect(refused.Reason).To(Equal("removes too many routes"))
+
+			status, _ = get(PathAcceptSnapshot, "")
+			Expect(status).To(Equal(http.StatusMethodNotAllowed))
+			accepted = 0
+			res, err = http.Post(srv.URL+PathAcceptSnapshot, "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(accepted).To(Equal(1))
+		})
+	})
+	Context("with a token", func() {
+		BeforeEach(func() {
+			srv = httptest.NewServer(NewHandler("secret-token", func() State { return state }, actions))
+		})
+		It("rejects requests without the token", func() {
+			status, _ := get(PathConfig, "")
+			Expect(status).To(Equal(http.StatusUnauthorized))
+			status, _ = get(PathConfig, "wrong-token")
+			Expect(status).To(Equal(http.StatusUnauthorized))
+		})
+		It("accepts requests with the token", func() {
+			status, _ := get(PathConfig, "secret-token")
+			Expect(status).To(Equal(http.StatusOK))
+		})
+	})
+})
@@ -0,0 +1,166 @@
+package admission_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gogo/protobuf/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/solo-io/gloo/internal/control-plane/admission"
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/internal/control-plane/translator"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/coreplugins/service"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/crd"
+	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
+	memorydeps "github.com/solo-io/gloo/pkg/storage/dependencies/memory"
+	"github.com/solo-io/gloo/pkg/storage/memory"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+func serviceUpstream(name string, hosts ...service.Host) *v1.Upstream {
+	return &v1.Upstream{
+		Name: name,
+		Type: service.UpstreamTypeService,
+		Spec: service.EncodeUpstreamSpec(service.UpstreamSpec{Hosts: hosts}),
+	}
+}
+
+func virtualHostTo(name, upstream string) *v1.VirtualHost {
+	return &v1.VirtualHost{
+		Name: name,
+		Routes: []*v1.Route{{
+			Matcher: &v1.Route_RequestMatcher{
+				RequestMatcher: &v1.RequestMatcher{Path: &v1.RequestMatcher_PathPrefix{PathPrefix: "/"}},
+			},
+			SingleDestination: &v1.Destination{
+				DestinationType: &v1.Destination_Upstream{Upstream: &v1.UpstreamDestination{Name: upstream}},
+			},
+		}},
+	}
+}
+
+var _ = Describe("admission", func() {
+	var (
+		store     storage.Interface
+		translate TranslateFunc
+		validator *Validator
+	)
+	plugs := []plugins.TranslatorPlugin{&service.Plugin{}}
+	getDependencies := func(cfg *v1.Config) []*plugins.Dependencies { return nil }
+	BeforeEach(func() {
+		store = memory.NewStorage()
+		translate = func(inputs translator.Inputs) ([]reporter.ConfigObjectReport, error) {
+			_, reports, err := translator.NewTranslator(translator.TranslatorConfig{IngressBindAddress: "::"}, plugs).Translate(inputs)
+			return reports, err
+		}
+		validator = NewValidator(store, memorydeps.NewSecretStorage(), memorydeps.NewFileStorage(), nil, getDependencies,
+			func(inputs translator.Inputs) ([]reporter.ConfigObjectReport, error) { return translate(inputs) })
+		_, err := store.V1().Upstreams().Create(serviceUpstream("petstore", service.Host{Addr: "localhost", Port: 8080}))
+		Must(err)
+		_, err = store.V1().VirtualHosts().Create(virtualHostTo("default", "petstore"))
+		Must(err)
+	})
+
+	Describe("Validator", func() {
+		It("accepts changes the control plane would accept", func() {
+			Expect(validator.ValidateVirtualHost(virtualHostTo("other", "petstore"))).To(Succeed())
+			Expect(validator.ValidateUpstream(serviceUpstream("petstore", service.Host{Addr: "localhost", Port: 9090}))).To(Succeed())
+		})
+		It("rejects an object the control plane would reject", func() {
+			err := validator.ValidateVirtualHost(virtualHostTo("other", "missing"))
+			Expect(err).To(HaveOccurred())
+			Expect(IsRejected(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("upstream missing was not found"))
+		})
+		It("rejects a spec its plugin cannot decode", func() {
+			us := serviceUpstream("broken")
+			us.Spec = &types.Struct{Fields: map[string]*types.Value{
+				"hosts": {Kind: &types.Value_StringValue{StringValue: "not a list"}},
+			}}
+			err := validator.ValidateUpstream(us)
+			Expect(IsRejected(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("invalid service upstream spec"))
+		})
+		It("rejects a change that gets accepted objects rejected", func() {
+			err := validator.ValidateUpstream(serviceUpstream("petstore"))
+			Expect(IsRejected(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("virtual host default would be rejected"))
+		})
+		It("ignores objects that are already rejected", func() {
+			_, err := store.V1().VirtualHosts().Create(virtualHostTo("stale", "missing"))
+			Must(err)
+			Expect(validator.ValidateUpstream(serviceUpstream("other", service.Host{Addr: "localhost", Port: 8080}))).To(Succeed())
+		})
+		It("fails without rejecting if the config cannot be translated", func() {
+			translate = func(inputs translator.Inputs) ([]reporter.ConfigObjectReport, error) {
+				return nil, errors.New("flux capacitor offline")
+			}
+			err := validator.ValidateVirtualHost(virtualHostTo("other", "petstore"))
+			Expect(err).To(HaveOccurred())
+			Expect(IsRejected(err)).To(BeFalse())
+		})
+	})
+
+	Describe("NewHandler", func() {
+		review := func(handler http.Handler, operation admissionv1beta1.Operation, vh *v1.VirtualHost) *admissionv1beta1.AdmissionResponse {
+			vhCrd, err := crd.VirtualHostToCrd("gloo-system", vh)
+			Must(err)
+			raw, err := json.Marshal(vhCrd)
+			Must(err)
+			body, err := json.Marshal(admissionv1beta1.AdmissionReview{
+				Request: &admissionv1beta1.AdmissionRequest{
+					UID:       "1234",
+					Kind:      metav1.GroupVersionKind{Group: crdv1.GroupName, Version: "v1", Kind: crdv1.VirtualHostCRD.Kind},
+					Name:      vh.Name,
+					Namespace: "gloo-system",
+					Operation: operation,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			Must(err)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var out admissionv1beta1.AdmissionReview
+			Must(json.Unmarshal(rec.Body.Bytes(), &out))
+			Expect(out.Response).NotTo(BeNil())
+			Expect(string(out.Response.UID)).To(Equal("1234"))
+			return out.Response
+		}
+		It("allows valid objects", func() {
+			resp := review(NewHandler(validator, false), admissionv1beta1.Create, virtualHostTo("other", "petstore"))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+		It("denies invalid objects with the translator's errors", func() {
+			resp := review(NewHandler(validator, true), admissionv1beta1.Update, virtualHostTo("other", "missing"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("upstream missing was not found"))
+		})
+		It("does not validate deletes", func() {
+			resp := review(NewHandler(validator, false), admissionv1beta1.Delete, virtualHostTo("other", "missing"))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+		It("applies the failure policy to requests that cannot be validated", func() {
+			translate = func(inputs translator.Inputs) ([]reporter.ConfigObjectReport, error) {
+				return nil, errors.New("flux capacitor offline")
+			}
+			resp := review(NewHandler(validator, true), admissionv1beta1.Create, virtualHostTo("other", "petstore"))
+			Expect(resp.Allowed).To(BeTrue())
+			resp = review(NewHandler(validator, false), admissionv1beta1.Create, virtualHostTo("other", "petstore"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("flux capacitor offline"))
+		})
+	})
+})
@@ -0,0 +1,230 @@
+package admission
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/internal/control-plane/filewatcher"
+	"github.com/solo-io/gloo/internal/control-plane/namespaces"
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/internal/control-plane/translator"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+// TranslateFunc translates the inputs the way the control plane does and returns the report for every config object.
+// it may be called concurrently with the control plane's own translations
+type TranslateFunc func(inputs translator.Inputs) ([]reporter.ConfigObjectReport, error)
+
+// Validator decides whether a change to an upstream or virtual host would be rejected by the control plane,
+// by translating the stored config with the change applied
+type Validator struct {
+	store           storage.Interface
+	secrets         dependencies.SecretStorage
+	files           dependencies.FileStorage
+	resolver        *namespaces.Resolver
+	getDependencies func(cfg *v1.Config) []*plugins.Dependencies
+	translate       TranslateFunc
+}
+
+// NewValidator creates a validator that reads the current config, and the secrets and files it needs, from storage.
+// resolver qualifies names and refs with their namespace, and is nil if the config storage has no namespaces
+func NewValidator(store storage.Interface, secrets dependencies.SecretStorage, files dependencies.FileStorage,
+	resolver *namespaces.Resolver, getDependencies func(cfg *v1.Config) []*plugins.Dependencies, translate TranslateFunc) *Validator {
+	return &Validator{
+		store:           store,
+		secrets:         secrets,
+		files:           files,
+		resolver:        resolver,
+		getDependencies: getDependencies,
+		translate:       translate,
+	}
+}
+
+type rejectedErr struct {
+	err error
+}
+
+func (e *rejectedErr) Error() string {
+	return e.err.Error()
+}
+
+// IsRejected returns true if the error is the reason a change was rejected,
+// rather than a failure to validate it
+func IsRejected(err error) bool {
+	_, ok := err.(*rejectedErr)
+	return ok
+}
+
+// ValidateUpstream returns an error for which IsRejected is true if creating or updating the upstream
+// would get it, or any config object that is accepted now, rejected by the control plane
+func (v *Validator) ValidateUpstream(proposed *v1.Upstream) error {
+	if err := schemas.ValidateUpstream(proposed); err != nil {
+		return &rejectedErr{err: err}
+	}
+	current, err := v.currentConfig()
+	if err != nil {
+		return err
+	}
+	next := &v1.Config{VirtualHosts: current.VirtualHosts}
+	replaced := false
+	for _, us := range current.Upstreams {
+		if v.sameObject(us, proposed) {
+			us = proposed
+			replaced = true
+		}
+		next.Upstreams = append(next.Upstreams, us)
+	}
+	if !replaced {
+		next.Upstreams = append(next.Upstreams, proposed)
+	}
+	return v.validate(current, next, proposed)
+}
+
+// ValidateVirtualHost returns an error for which IsRejected is true if creating or updating the virtual host
+// would get it, or any config object that is accepted now, rejected by the control plane
+func (v *Validator) ValidateVirtualHost(proposed *v1.VirtualHost) error {
+	if err := schemas.ValidateVirtualHost(proposed); err != nil {
+		return &rejectedErr{err: err}
+	}
+	current, err := v.currentConfig()
+	if err != nil {
+		return err
+	}
+	next := &v1.Config{Upstreams: current.Upstreams}
+	replaced := false
+	for _, vh := range current.VirtualHosts {
+		if v.sameObject(vh, proposed) {
+			vh = proposed
+			replaced = true
+		}
+		next.VirtualHosts = append(next.VirtualHosts, vh)
+	}
+	if !replaced {
+		next.VirtualHosts = append(next.VirtualHosts, proposed)
+	}
+	return v.validate(current, next, proposed)
+}
+
+func (v *Validator) currentConfig() (*v1.Config, error) {
+	upstreams, err := v.store.V1().Upstreams().List(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing upstreams")
+	}
+	virtualHosts, err := v.store.V1().VirtualHosts().List(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing virtual hosts")
+	}
+	return &v1.Config{Upstreams: upstreams, VirtualHosts: virtualHosts}, nil
+}
+
+// name returns the name of the config object as the control plane spells it
+func (v *Validator) name(obj v1.ConfigObject) string {
+	if v.resolver == nil {
+		return obj.GetName()
+	}
+	return v.resolver.Ref(obj.GetName(), obj.GetMetadata().GetNamespace())
+}
+
+func (v *Validator) sameObject(a, b v1.ConfigObject) bool {
+	return v.name(a) == v.name(b)
+}
+
+func kindOf(obj v1.ConfigObject) string {
+	switch obj.(type) {
+	case *v1.Upstream:
+		return "upstream"
+	case *v1.VirtualHost:
+		return "virtual host"
+	}
+	return fmt.Sprintf("%T", obj)
+}
+
+// validate rejects the change if the proposed object is rejected in the next config,
+// or if it gets other config objects rejected that are accepted in the current config
+func (v *Validator) validate(current, next *v1.Config, proposed v1.ConfigObject) error {
+	before, err := v.translateConfig(current)
+	if err != nil {
+		return errors.Wrap(err, "translating the current config")
+	}
+	after, err := v.translateConfig(next)
+	if err != nil {
+		return errors.Wrap(err, "translating the config with the change applied")
+	}
+	// reports are for the resolved config, so their names are already spelled as the control plane spells them
+	key := func(obj v1.ConfigObject) string {
+		return kindOf(obj) + " " + obj.GetName()
+	}
+	rejectedBefore := make(map[string]bool)
+	for _, report := range before {
+		if report.Err != nil {
+			rejectedBefore[key(report.CfgObject)] = true
+		}
+	}
+	proposedKey := kindOf(proposed) + " " + v.name(proposed)
+	var errs error
+	for _, report := range after {
+		if report.Err == nil {
+			continue
+		}
+		k := key(report.CfgObject)
+		switch {
+		case k == proposedKey:
+			errs = multierror.Append(errs, report.Err)
+		case !rejectedBefore[k]:
+			errs = multierror.Append(errs, errors.Wrapf(report.Err, "%v would be rejected", k))
+		}
+	}
+	if errs != nil {
+		return &rejectedErr{err: errs}
+	}
+	return nil
+}
+
+// translateConfig translates the config with the secrets and files it needs read from storage.
+// secrets and files that cannot be read are left out, so the translation reports them the way the control plane would
+func (v *Validator) translateConfig(cfg *v1.Config) ([]reporter.ConfigObjectReport, error) {
+	if v.resolver != nil {
+		cfg = v.resolver.Config(cfg)
+	}
+	var secretRefs, fileRefs []string
+	for _, dep := range v.getDependencies(cfg) {
+		secretRefs = append(secretRefs, dep.SecretRefs...)
+		fileRefs = append(fileRefs, dep.FileRefs...)
+	}
+	for _, vhost := range cfg.VirtualHosts {
+		if vhost.SslConfig != nil && vhost.SslConfig.SecretRef != "" {
+			secretRefs = append(secretRefs, vhost.SslConfig.SecretRef)
+		}
+	}
+	trackedSecretRefs := secretRefs
+	if v.resolver != nil {
+		trackedSecretRefs = v.resolver.SecretRefs(secretRefs)
+	}
+	secrets := make(secretwatcher.SecretMap)
+	for _, ref := range trackedSecretRefs {
+		if secret, err := v.secrets.Get(ref); err == nil {
+			secrets[ref] = secret
+		}
+	}
+	if v.resolver != nil {
+		secrets = v.resolver.Secrets(secrets, secretRefs)
+	}
+	files := make(filewatcher.Files)
+	for _, ref := range fileRefs {
+		if file, err := v.files.Get(ref); err == nil {
+			files[ref] = file
+		}
+	}
+	return v.translate(translator.Inputs{
+		Cfg:     cfg,
+		Secrets: secrets,
+		Files:   files,
+	})
+}
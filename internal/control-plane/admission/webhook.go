@@ -0,0 +1,146 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage/crd"
+	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
+)
+
+// NewHandler serves kubernetes validating admission webhook requests for upstream and virtual host CRDs.
+// creates and updates the control plane would reject are denied with the translator's errors.
+// if failOpen is set, requests that cannot be validated are allowed, otherwise they are denied
+func NewHandler(v *Validator, failOpen bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "reading request").Error(), http.StatusBadRequest)
+			return
+		}
+		var review admissionv1beta1.AdmissionReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			http.Error(w, errors.Wrap(err, "parsing admission review").Error(), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "admission review has no request", http.StatusBadRequest)
+			return
+		}
+		review.Response = respond(v, review.Request, failOpen)
+		review.Request = nil
+		data, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "marshalling admission review").Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+// respond validates the object in the request. only creates and updates are validated
+func respond(v *Validator, req *admissionv1beta1.AdmissionRequest, failOpen bool) *admissionv1beta1.AdmissionResponse {
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return allowed(req)
+	}
+	err := validate(v, req)
+	switch {
+	case err == nil:
+		return allowed(req)
+	case IsRejected(err):
+		return denied(req, err.Error())
+	case failOpen:
+		log.Warnf("allowing %v %v that could not be validated: %v", req.Kind.Kind, req.Name, err)
+		return allowed(req)
+	}
+	return denied(req, errors.Wrap(err, "could not be validated").Error())
+}
+
+func validate(v *Validator, req *admissionv1beta1.AdmissionRequest) error {
+	switch req.Kind.Kind {
+	case crdv1.UpstreamCRD.Kind:
+		var usCrd crdv1.Upstream
+		if err := json.Unmarshal(req.Object.Raw, &usCrd); err != nil {
+			return &rejectedErr{err: errors.Wrap(err, "parsing upstream")}
+		}
+		if usCrd.Namespace == "" {
+			usCrd.Namespace = req.Namespace
+		}
+		us, err := crd.UpstreamFromCrd(&usCrd)
+		if err != nil {
+			return &rejectedErr{err: err}
+		}
+		return v.ValidateUpstream(us)
+	case crdv1.VirtualHostCRD.Kind:
+		var vhCrd crdv1.VirtualHost
+		if err := json.Unmarshal(req.Object.Raw, &vhCrd); err != nil {
+			return &rejectedErr{err: errors.Wrap(err, "parsing virtual host")}
+		}
+		if vhCrd.Namespace == "" {
+			vhCrd.Namespace = req.Namespace
+		}
+		vh, err := crd.VirtualHostFromCrd(&vhCrd)
+		if err != nil {
+			return &rejectedErr{err: err}
+		}
+		return v.ValidateVirtualHost(vh)
+	}
+	return errors.Errorf("unsupported kind %v", req.Kind.Kind)
+}
+
+func allowed(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{UID: req.UID, Allowed: true}
+}
+
+func denied(req *admissionv1beta1.AdmissionRequest, message string) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
+
+// Run serves the webhook on addr until stop is closed. kubernetes only calls webhooks over https,
+// so certFile and keyFile should be given unless tls is terminated in front of the control plane
+func Run(addr, certFile, keyFile string, handler http.Handler, stop <-chan struct{}) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %v", addr)
+	}
+	server := &http.Server{Handler: handler}
+	go func() {
+		log.Printf("admission webhook listening on %v", lis.Addr())
+		var err error
+		if certFile != "" || keyFile != "" {
+			err = server.ServeTLS(lis, certFile, keyFile)
+		} else {
+			err = server.Serve(lis)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			log.Warnf("admission webhook stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-stop
+		server.Shutdown(context.Background())
+	}()
+	return nil
+}
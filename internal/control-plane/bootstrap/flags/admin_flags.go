@@ -0,0 +1,11 @@
+package flags
+
+import (
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddAdminFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringVar(&opts.AdminOptions.BindAddress, "admin.address", "127.0.0.1:9091", "address to serve the admin/debug api on. set to empty to disable the admin api")
+	cmd.PersistentFlags().StringVar(&opts.AdminOptions.Token, "admin.token", "", "if set, requests to the admin api must include the header 'Authorization: Bearer <token>'")
+}
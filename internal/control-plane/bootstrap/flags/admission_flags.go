@@ -0,0 +1,13 @@
+package flags
+
+import (
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddAdmissionFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringVar(&opts.AdmissionOptions.BindAddress, "admission.address", "", "address to serve the validating admission webhook for upstream and virtual host crds on. empty disables the webhook")
+	cmd.PersistentFlags().StringVar(&opts.AdmissionOptions.CertFile, "admission.cert-file", "", "tls certificate to serve the admission webhook with")
+	cmd.PersistentFlags().StringVar(&opts.AdmissionOptions.KeyFile, "admission.key-file", "", "tls private key to serve the admission webhook with")
+	cmd.PersistentFlags().StringVar(&opts.AdmissionOptions.FailurePolicy, "admission.failure-policy", bootstrap.AdmissionFailOpen, "what the admission webhook does with a request it cannot validate, e.g. because storage cannot be read: "+bootstrap.AdmissionFailOpen+" allows it, "+bootstrap.AdmissionFailClosed+" denies it")
+}
@@ -0,0 +1,18 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddPluginFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringArrayVar(&opts.PluginOptions.Connect, "plugins.connect", nil, "translator plugin to call over grpc, as name=address where address is host:port or unix:///path/to/socket. may be repeated")
+	cmd.PersistentFlags().StringArrayVar(&opts.PluginOptions.Launch, "plugins.launch", nil, "translator plugin to run and call over grpc, as name=command. the command is split on spaces, and is given the address to serve on in $GLOO_PLUGIN_ADDRESS. may be repeated")
+	cmd.PersistentFlags().DurationVar(&opts.PluginOptions.StartTimeout, "plugins.start-timeout", 30*time.Second, "how long a plugin has to start serving before the control plane fails to start")
+	cmd.PersistentFlags().DurationVar(&opts.PluginOptions.CallTimeout, "plugins.call-timeout", 5*time.Second, "how long a call to a plugin may take. a call that takes longer is an error on the upstream or route being translated")
+	cmd.PersistentFlags().StringVar(&opts.PluginOptions.CaFile, "plugins.ca-file", "", "ca to verify the tls certificates of plugins with. plugins connected to at an address other than a unix socket or loopback address are only called over tls")
+	cmd.PersistentFlags().StringVar(&opts.PluginOptions.CertFile, "plugins.cert-file", "", "tls certificate to present to plugins that verify their clients")
+	cmd.PersistentFlags().StringVar(&opts.PluginOptions.KeyFile, "plugins.key-file", "", "tls private key to present to plugins that verify their clients")
+}
@@ -0,0 +1,16 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddSyncFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().DurationVar(&opts.SyncOptions.MinInterval, "sync.min-interval", 100*time.Millisecond, "minimum time between translations. events that arrive within this interval of each other are coalesced into a single translation")
+	cmd.PersistentFlags().DurationVar(&opts.SyncOptions.MaxDelay, "sync.max-delay", time.Second, "maximum time an event can be delayed by coalescing before a translation is run")
+	cmd.PersistentFlags().DurationVar(&opts.SyncOptions.EndpointsTimeout, "sync.endpoints-timeout", 10*time.Second, "how long the first snapshot waits for endpoint discoveries that have not reported endpoints yet")
+	cmd.PersistentFlags().Float64Var(&opts.SyncOptions.MaxRouteRemoval, "sync.max-route-removal", 0.5, "largest fraction of the routes served to envoy that a new snapshot may remove. larger removals are refused until accepted with a POST to /snapshot/accept on the admin api")
+	cmd.PersistentFlags().BoolVar(&opts.SyncOptions.AllowRouteRemoval, "sync.allow-route-removal", false, "push snapshots regardless of how many routes they remove")
+}
@@ -1,14 +1,79 @@
 package bootstrap
 
 import (
+	"time"
+
 	"github.com/solo-io/gloo/pkg/bootstrap"
 )
 
 type Options struct {
 	bootstrap.Options
-	IngressOptions IngressOptions
+	IngressOptions   IngressOptions
+	SyncOptions      SyncOptions
+	AdminOptions     AdminOptions
+	AdmissionOptions AdmissionOptions
+	PluginOptions    PluginOptions
 }
 
 type IngressOptions struct {
 	BindAddress string
 }
+
+// SyncOptions control how watch events are coalesced into translations
+type SyncOptions struct {
+	// a translation runs once no new events have arrived for this long,
+	// so consecutive translations are always at least this far apart
+	MinInterval time.Duration
+	// the longest an event may wait for a translation while new events keep arriving
+	MaxDelay time.Duration
+	// how long the first translation waits for endpoint discoveries that have not reported yet.
+	// a discovery that has no upstreams to track never reports
+	EndpointsTimeout time.Duration
+	// the largest fraction of the routes in the current snapshot a new snapshot may remove.
+	// a snapshot that removes more is refused until it is accepted through the admin api
+	MaxRouteRemoval float64
+	// disables the route removal check
+	AllowRouteRemoval bool
+}
+
+type AdminOptions struct {
+	// address for the admin api. empty disables it
+	BindAddress string
+	// if set, requests to the admin api must present this bearer token
+	Token string
+}
+
+// what the admission webhook does with a request it cannot validate
+const (
+	AdmissionFailOpen   = "fail-open"
+	AdmissionFailClosed = "fail-closed"
+)
+
+// AdmissionOptions configure the validating admission webhook for upstream and virtual host CRDs
+type AdmissionOptions struct {
+	// address for the webhook. empty disables it
+	BindAddress string
+	// tls certificate and key the webhook is served with
+	CertFile string
+	KeyFile  string
+	// AdmissionFailOpen allows requests that cannot be validated, AdmissionFailClosed denies them
+	FailurePolicy string
+}
+
+// PluginOptions configure translator plugins that run outside of the control plane and are called over grpc
+type PluginOptions struct {
+	// plugins that are already serving, as name=address
+	Connect []string
+	// plugins the control plane runs, as name=command
+	Launch []string
+	// how long a plugin has to start serving before the control plane gives up on it
+	StartTimeout time.Duration
+	// how long a call to a plugin may take before it fails
+	CallTimeout time.Duration
+	// ca the certificates of plugins connected to over tcp are verified against. required for plugins
+	// that are not on a unix socket or loopback address
+	CaFile string
+	// tls certificate and key the control plane presents to plugins that verify their clients
+	CertFile string
+	KeyFile  string
+}
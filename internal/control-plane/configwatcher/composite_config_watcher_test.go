@@ -0,0 +1,40 @@
+package configwatcher
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage/composite"
+	"github.com/solo-io/gloo/pkg/storage/memory"
+)
+
+var _ = Describe("CompositeConfigWatcher", func() {
+	It("sends the config merged from every backend", func() {
+		kube := memory.NewStorage()
+		file := memory.NewStorage()
+		storageClient, err := composite.NewStorage([]composite.Backend{
+			{Name: "kube", Storage: kube},
+			{Name: "file", Storage: file},
+		}, "", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		fromKube, err := kube.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "kube"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "file"})
+		Expect(err).NotTo(HaveOccurred())
+		vhost, err := file.V1().VirtualHosts().Create(&v1.VirtualHost{Name: "myvirtualhost", Domains: []string{"foo"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		watcher, err := NewConfigWatcher(storageClient)
+		Expect(err).NotTo(HaveOccurred())
+		stop := make(chan struct{})
+		defer close(stop)
+		go watcher.Run(stop)
+
+		var cfg *v1.Config
+		Eventually(watcher.Config()).Should(Receive(&cfg))
+		Expect(cfg.Upstreams).To(Equal([]*v1.Upstream{fromKube}))
+		Expect(cfg.VirtualHosts).To(Equal([]*v1.VirtualHost{vhost}))
+	})
+})
@@ -1,16 +1,19 @@
 package configwatcher
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
 
 	"github.com/d4l3k/messagediff"
+	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/solo-io/gloo/pkg/api/types/v1"
-	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/backoff"
 	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage"
 )
 
 type configWatcher struct {
@@ -24,42 +27,64 @@ func NewConfigWatcher(storageClient storage.Interface) (*configWatcher, error) {
 		return nil, fmt.Errorf("failed to register to storage backend: %v", err)
 	}
 
-	initialUpstreams, err := storageClient.V1().Upstreams().List()
-	if err != nil {
-		log.Warnf("Startup: failed to read upstreams from storage: %v", err)
-		initialUpstreams = []*v1.Upstream{}
-	}
-	initialVirtualHosts, err := storageClient.V1().VirtualHosts().List()
-	if err != nil {
-		log.Warnf("Startup: failed to read virtual hosts from storage: %v", err)
-		initialVirtualHosts = []*v1.VirtualHost{}
-	}
 	configs := make(chan *v1.Config)
-	// do a first time read
-	cache := &v1.Config{
-		Upstreams:    initialUpstreams,
-		VirtualHosts: initialVirtualHosts,
+	// a config is only sent once both upstreams and virtual hosts have been read successfully,
+	// so a backend that is briefly unavailable at startup never produces an empty config
+	var (
+		lock                          sync.Mutex
+		cache                         = &v1.Config{}
+		upstreamsSynced, vhostsSynced bool
+	)
+	send := func() {
+		if !upstreamsSynced || !vhostsSynced {
+			return
+		}
+		configs <- proto.Clone(cache).(*v1.Config)
 	}
-	// throw it down the channel to get things going
-	go func() {
-		configs <- cache
-	}()
 
-	syncUpstreams := func(updatedList []*v1.Upstream, _ *v1.Upstream) {
-		sort.SliceStable(updatedList, func(i, j int) bool {
-			return updatedList[i].GetName() < updatedList[j].GetName()
-		})
+	// do a first time read, retrying until storage can be read
+	go backoff.UntilSuccess(func() error {
+		initialUpstreams, err := storageClient.V1().Upstreams().List(nil)
+		if err != nil {
+			log.Warnf("Startup: failed to read upstreams from storage: %v", err)
+			return err
+		}
+		initialVirtualHosts, err := storageClient.V1().VirtualHosts().List(nil)
+		if err != nil {
+			log.Warnf("Startup: failed to read virtual hosts from storage: %v", err)
+			return err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		// the watchers may have delivered a newer list in the meantime
+		if !upstreamsSynced {
+			cache.Upstreams = sortedUpstreams(initialUpstreams)
+			upstreamsSynced = true
+		}
+		if !vhostsSynced {
+			cache.VirtualHosts = sortedVirtualHosts(initialVirtualHosts)
+			vhostsSynced = true
+		}
+		// throw it down the channel to get things going
+		send()
+		return nil
+	}, context.Background())
 
+	syncUpstreams := func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+		updatedList = sortedUpstreams(updatedList)
+		lock.Lock()
+		defer lock.Unlock()
 		diff, equal := messagediff.PrettyDiff(cache.Upstreams, updatedList)
-		if equal {
+		if equal && upstreamsSynced {
 			return
 		}
 		log.GreyPrintf("change detected in upstream: %v", diff)
 
 		cache.Upstreams = updatedList
-		configs <- cache
+		upstreamsSynced = true
+		send()
 	}
-	upstreamWatcher, err := storageClient.V1().Upstreams().Watch(&storage.UpstreamEventHandlerFuncs{
+	upstreamWatcher, err := storageClient.V1().Upstreams().Watch(nil, &storage.UpstreamEventHandlerFuncs{
 		AddFunc:    syncUpstreams,
 		UpdateFunc: syncUpstreams,
 		DeleteFunc: syncUpstreams,
@@ -68,20 +93,20 @@ func NewConfigWatcher(storageClient storage.Interface) (*configWatcher, error) {
 		return nil, errors.Wrap(err, "failed to create watcher for upstreams")
 	}
 	syncVhosts := func(updatedList []*v1.VirtualHost, _ *v1.VirtualHost) {
-		sort.SliceStable(updatedList, func(i, j int) bool {
-			return updatedList[i].GetName() < updatedList[j].GetName()
-		})
-
+		updatedList = sortedVirtualHosts(updatedList)
+		lock.Lock()
+		defer lock.Unlock()
 		diff, equal := messagediff.PrettyDiff(cache.VirtualHosts, updatedList)
-		if equal {
+		if equal && vhostsSynced {
 			return
 		}
 		log.GreyPrintf("change detected in virtualhosts: %v", diff)
 
 		cache.VirtualHosts = updatedList
-		configs <- cache
+		vhostsSynced = true
+		send()
 	}
-	vhostWatcher, err := storageClient.V1().VirtualHosts().Watch(&storage.VirtualHostEventHandlerFuncs{
+	vhostWatcher, err := storageClient.V1().VirtualHosts().Watch(nil, &storage.VirtualHostEventHandlerFuncs{
 		AddFunc:    syncVhosts,
 		UpdateFunc: syncVhosts,
 		DeleteFunc: syncVhosts,
@@ -116,3 +141,21 @@ func (w *configWatcher) Config() <-chan *v1.Config {
 func (w *configWatcher) Error() <-chan error {
 	return w.errs
 }
+
+// the lists are sorted as copies: storage clients may give the same list to several handlers,
+// e.g. the merged list of composite storage
+func sortedUpstreams(upstreams []*v1.Upstream) []*v1.Upstream {
+	sorted := append([]*v1.Upstream(nil), upstreams...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetName() < sorted[j].GetName()
+	})
+	return sorted
+}
+
+func sortedVirtualHosts(virtualHosts []*v1.VirtualHost) []*v1.VirtualHost {
+	sorted := append([]*v1.VirtualHost(nil), virtualHosts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetName() < sorted[j].GetName()
+	})
+	return sorted
+}
@@ -0,0 +1,75 @@
+// Package dump renders translation output as json for humans and tools
+package dump
+
+import (
+	"encoding/json"
+
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+// Resources are the envoy resources of one xDS type, rendered as envoy json
+type Resources struct {
+	Version string                     `json:"version"`
+	Items   map[string]json.RawMessage `json:"items"`
+}
+
+// Snapshot renders each resource type in the snapshot, keyed by "endpoints", "clusters", "routes" and "listeners"
+func Snapshot(snap *envoycache.Snapshot) (map[string]Resources, error) {
+	out := make(map[string]Resources)
+	for name, res := range map[string]envoycache.Resources{
+		"endpoints": snap.Endpoints,
+		"clusters":  snap.Clusters,
+		"routes":    snap.Routes,
+		"listeners": snap.Listeners,
+	} {
+		items := make(map[string]json.RawMessage)
+		for itemName, item := range res.Items {
+			data, err := protoutil.Marshal(item)
+			if err != nil {
+				return nil, errors.Wrapf(err, "marshalling %v %v", name, itemName)
+			}
+			items[itemName] = data
+		}
+		out[name] = Resources{Version: res.Version, Items: items}
+	}
+	return out, nil
+}
+
+type Report struct {
+	Kind     string   `json:"kind"`
+	Name     string   `json:"name"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// route errors by the index of the route
+	RouteErrors map[int]string `json:"route_errors,omitempty"`
+}
+
+func Reports(reports []reporter.ConfigObjectReport) []Report {
+	out := []Report{}
+	for _, r := range reports {
+		var kind string
+		switch r.CfgObject.(type) {
+		case *v1.Upstream:
+			kind = "upstream"
+		case *v1.VirtualHost:
+			kind = "virtual_host"
+		}
+		rep := Report{Kind: kind, Name: r.CfgObject.GetName(), Warnings: r.Warnings}
+		if r.Err != nil {
+			rep.Error = r.Err.Error()
+		}
+		for index, err := range r.RouteErrors {
+			if rep.RouteErrors == nil {
+				rep.RouteErrors = make(map[int]string)
+			}
+			rep.RouteErrors[index] = err.Error()
+		}
+		out = append(out, rep)
+	}
+	return out
+}
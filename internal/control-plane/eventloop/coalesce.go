@@ -0,0 +1,118 @@
+package eventloop
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+)
+
+// debouncer coalesces bursts of events into a single sync.
+// a sync is due once no event has arrived for minInterval,
+// or once maxDelay has passed since the first event that has not been synced yet
+type debouncer struct {
+	minInterval time.Duration
+	maxDelay    time.Duration
+
+	timer        *time.Timer
+	pending      uint64
+	firstPending time.Time
+}
+
+func newDebouncer(opts bootstrap.SyncOptions) *debouncer {
+	maxDelay := opts.MaxDelay
+	if maxDelay < opts.MinInterval {
+		// the quiet period would never be reached
+		maxDelay = opts.MinInterval
+	}
+	return &debouncer{
+		minInterval: opts.MinInterval,
+		maxDelay:    maxDelay,
+	}
+}
+
+// event records an event and (re)schedules the next sync
+func (d *debouncer) event() {
+	now := time.Now()
+	if d.pending == 0 {
+		d.firstPending = now
+	}
+	d.pending++
+
+	delay := d.minInterval
+	if deadline := d.firstPending.Add(d.maxDelay); now.Add(delay).After(deadline) {
+		delay = deadline.Sub(now)
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.NewTimer(delay)
+}
+
+// ready receives when a sync is due. it blocks forever if no events are pending
+func (d *debouncer) ready() <-chan time.Time {
+	if d.timer == nil {
+		return nil
+	}
+	return d.timer.C
+}
+
+// flush returns the number of events covered by the sync that is about to run
+func (d *debouncer) flush() uint64 {
+	events := d.pending
+	d.pending = 0
+	d.timer = nil
+	return events
+}
+
+// SyncStats count the events received by the event loop and the syncs they caused
+type SyncStats struct {
+	// events received from the config, secret, file and endpoint watchers
+	Events uint64
+	// syncs run after coalescing
+	Syncs uint64
+	// events that did not cause a sync of their own because they were coalesced with another event
+	CoalescedEvents uint64
+	// syncs that were skipped because none of the inputs had changed
+	UnchangedSyncs uint64
+	// times the inputs could not be hashed, forcing a translation
+	HashErrors uint64
+}
+
+// syncStats is updated by the event loop and may be read concurrently.
+// the counts are also exported as prometheus metrics
+type syncStats struct {
+	stats SyncStats
+}
+
+func (s *syncStats) event(source string) {
+	atomic.AddUint64(&s.stats.Events, 1)
+	watchEvents.WithLabelValues(source).Inc()
+}
+
+func (s *syncStats) sync(events uint64) {
+	atomic.AddUint64(&s.stats.Syncs, 1)
+	syncs.Inc()
+	if events > 1 {
+		atomic.AddUint64(&s.stats.CoalescedEvents, events-1)
+		coalescedEvents.Add(float64(events - 1))
+	}
+}
+
+func (s *syncStats) unchanged() {
+	atomic.AddUint64(&s.stats.UnchangedSyncs, 1)
+}
+
+func (s *syncStats) hashError() {
+	atomic.AddUint64(&s.stats.HashErrors, 1)
+}
+
+func (s *syncStats) get() SyncStats {
+	return SyncStats{
+		Events:          atomic.LoadUint64(&s.stats.Events),
+		Syncs:           atomic.LoadUint64(&s.stats.Syncs),
+		CoalescedEvents: atomic.LoadUint64(&s.stats.CoalescedEvents),
+		UnchangedSyncs:  atomic.LoadUint64(&s.stats.UnchangedSyncs),
+		HashErrors:      atomic.LoadUint64(&s.stats.HashErrors),
+	}
+}
@@ -0,0 +1,114 @@
+package eventloop
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	"github.com/solo-io/gloo/internal/control-plane/filewatcher"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+)
+
+var _ = Describe("Debouncer", func() {
+	It("does not schedule a sync until an event arrives", func() {
+		d := newDebouncer(bootstrap.SyncOptions{})
+		Expect(d.ready()).To(BeNil())
+	})
+	It("syncs immediately when no min interval is set", func() {
+		d := newDebouncer(bootstrap.SyncOptions{})
+		d.event()
+		Eventually(d.ready(), 100*time.Millisecond).Should(Receive())
+		Expect(d.flush()).To(Equal(uint64(1)))
+		Expect(d.ready()).To(BeNil())
+	})
+	It("coalesces events that arrive within the min interval", func() {
+		d := newDebouncer(bootstrap.SyncOptions{MinInterval: 50 * time.Millisecond, MaxDelay: time.Second})
+		for i := 0; i < 5; i++ {
+			d.event()
+		}
+		Consistently(d.ready(), 30*time.Millisecond).ShouldNot(Receive())
+		Eventually(d.ready(), 100*time.Millisecond).Should(Receive())
+		Expect(d.flush()).To(Equal(uint64(5)))
+	})
+	It("syncs after the max delay while events keep arriving", func() {
+		d := newDebouncer(bootstrap.SyncOptions{MinInterval: 50 * time.Millisecond, MaxDelay: 100 * time.Millisecond})
+		start := time.Now()
+		var fired bool
+		for !fired && time.Since(start) < time.Second {
+			d.event()
+			select {
+			case <-d.ready():
+				fired = true
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+		Expect(fired).To(BeTrue())
+		Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+		Expect(d.flush()).To(BeNumerically(">", 1))
+	})
+})
+
+var _ = Describe("SyncStats", func() {
+	It("counts the events that were coalesced", func() {
+		s := &syncStats{}
+		for i := 0; i < 3; i++ {
+			s.event(eventSourceConfig)
+		}
+		s.sync(3)
+		s.event(eventSourceEndpoints)
+		s.sync(1)
+		s.unchanged()
+		Expect(s.get()).To(Equal(SyncStats{Events: 4, Syncs: 2, CoalescedEvents: 2, UnchangedSyncs: 1}))
+	})
+})
+
+var _ = Describe("cache", func() {
+	It("hashes endpoints independently of the discovery that found them", func() {
+		endpoints := endpointdiscovery.EndpointGroups{"a": {{Address: "1.2.3.4", Port: 80}}}
+		c1 := newCache()
+		c1.cfg = &v1.Config{}
+		c1.endpoints[&fakeDiscovery{}] = endpoints
+		c2 := newCache()
+		c2.cfg = &v1.Config{}
+		c2.endpoints[&fakeDiscovery{}] = endpoints
+		h1, err := c1.hash()
+		Expect(err).NotTo(HaveOccurred())
+		h2, err := c2.hash()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(h1).To(Equal(h2))
+	})
+	It("is ready once every watcher has synced", func() {
+		discovery := &fakeDiscovery{}
+		discoveries := []endpointdiscovery.Interface{discovery}
+		c := newCache()
+		c.cfg = &v1.Config{}
+		Expect(c.ready(discoveries)).To(BeFalse())
+		c.secrets = secretwatcher.SecretMap{}
+		c.files = filewatcher.Files{}
+		Expect(c.ready(discoveries)).To(BeFalse())
+		c.endpoints[discovery] = endpointdiscovery.EndpointGroups{}
+		Expect(c.ready(discoveries)).To(BeTrue())
+	})
+	It("stops waiting for endpoint discoveries after the timeout", func() {
+		c := newCache()
+		c.cfg = &v1.Config{}
+		c.secrets = secretwatcher.SecretMap{}
+		c.files = filewatcher.Files{}
+		c.endpointsTimedOut = true
+		Expect(c.ready([]endpointdiscovery.Interface{&fakeDiscovery{}})).To(BeTrue())
+	})
+})
+
+type fakeDiscovery struct {
+	endpoints chan endpointdiscovery.EndpointGroups
+	errors    chan error
+}
+
+func (d *fakeDiscovery) Run(stop <-chan struct{})                           {}
+func (d *fakeDiscovery) TrackUpstreams(upstreams []*v1.Upstream)            {}
+func (d *fakeDiscovery) Endpoints() <-chan endpointdiscovery.EndpointGroups { return d.endpoints }
+func (d *fakeDiscovery) Error() <-chan error                                { return d.errors }
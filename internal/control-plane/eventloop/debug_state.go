@@ -0,0 +1,59 @@
+package eventloop
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/solo-io/gloo/internal/control-plane/admin"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+)
+
+const (
+	configWatcherName = "config"
+	secretWatcherName = "secrets"
+	fileWatcherName   = "files"
+)
+
+// debugState records what the event loop has seen, for the admin api
+type debugState struct {
+	lock  sync.RWMutex
+	state admin.State
+}
+
+func newDebugState() *debugState {
+	return &debugState{
+		state: admin.State{
+			Endpoints: make(map[string]endpointdiscovery.EndpointGroups),
+			Ready:     make(map[string]bool),
+		},
+	}
+}
+
+func (d *debugState) update(fn func(state *admin.State)) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	fn(&d.state)
+}
+
+func (d *debugState) get() admin.State {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	state := d.state
+	state.Reports = append(state.Reports[:0:0], d.state.Reports...)
+	state.SecretRefs = append(state.SecretRefs[:0:0], d.state.SecretRefs...)
+	state.FileRefs = append(state.FileRefs[:0:0], d.state.FileRefs...)
+	state.Endpoints = make(map[string]endpointdiscovery.EndpointGroups)
+	for name, endpoints := range d.state.Endpoints {
+		state.Endpoints[name] = endpoints
+	}
+	state.Ready = make(map[string]bool)
+	for name, ready := range d.state.Ready {
+		state.Ready[name] = ready
+	}
+	return state
+}
+
+// endpoint discoveries are identified by their type
+func discoveryName(discovery endpointdiscovery.Interface) string {
+	return fmt.Sprintf("%T", discovery)
+}
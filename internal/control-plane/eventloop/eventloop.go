@@ -1,24 +1,34 @@
 package eventloop
 
 import (
+	"net/http"
+	"time"
+
 	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
 	"github.com/mitchellh/hashstructure"
 	"github.com/pkg/errors"
 
+	"github.com/solo-io/gloo/internal/control-plane/admin"
+	"github.com/solo-io/gloo/internal/control-plane/admission"
 	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
 	"github.com/solo-io/gloo/internal/control-plane/configwatcher"
 	"github.com/solo-io/gloo/internal/control-plane/filewatcher"
+	"github.com/solo-io/gloo/internal/control-plane/namespaces"
 	"github.com/solo-io/gloo/internal/control-plane/reporter"
 	"github.com/solo-io/gloo/internal/control-plane/translator"
 	"github.com/solo-io/gloo/internal/control-plane/xds"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	bootstrapopts "github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/bootstrap/artifactstorage"
 	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
+	"github.com/solo-io/gloo/pkg/bootstrap/secretstorage"
 	secretwatchersetup "github.com/solo-io/gloo/pkg/bootstrap/secretwatcher"
 	"github.com/solo-io/gloo/pkg/endpointdiscovery"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/gloo/pkg/plugins"
 	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/crd"
 )
 
 type eventLoop struct {
@@ -30,6 +40,20 @@ type eventLoop struct {
 	translator          *translator.Translator
 	xdsConfig           envoycache.SnapshotCache
 	getDependencies     func(cfg *v1.Config) []*plugins.Dependencies
+	syncOptions         bootstrap.SyncOptions
+	stats               *syncStats
+	adminOptions        bootstrap.AdminOptions
+	debug               *debugState
+	guard               *snapshotGuard
+	// qualifies names and refs with their namespace. nil if the config storage has no namespaces
+	resolver *namespaces.Resolver
+	// requests from the admin api to send the refused snapshot
+	acceptRequests   chan chan error
+	admissionOptions bootstrap.AdmissionOptions
+	// serves the admission webhook. nil if it is disabled
+	admission http.Handler
+	// translates for the admission webhook, with a translation cache of its own
+	dryRunTranslator *translator.Translator
 
 	startFuncs []func() error
 }
@@ -66,18 +90,37 @@ func Setup(opts bootstrap.Options, xdsPort int, stop <-chan struct{}) (*eventLoo
 		return nil, errors.Wrap(err, "failed to start xds server")
 	}
 
-	plugs := plugins.RegisteredPlugins()
+	remotePlugins, err := startRemotePlugins(opts.PluginOptions, stop)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start remote plugins")
+	}
+	plugs := append(append([]plugins.TranslatorPlugin{}, plugins.RegisteredPlugins()...), remotePlugins...)
 
 	trans := translator.NewTranslator(translatorConfig(opts), plugs)
 
 	e := &eventLoop{
-		configWatcher:   cfgWatcher,
-		secretWatcher:   secretWatcher,
-		fileWatcher:     fileWatcher,
-		translator:      trans,
-		xdsConfig:       xdsConfig,
-		getDependencies: getDependenciesFor(plugs),
-		reporter:        reporter.NewReporter(store),
+		configWatcher:    cfgWatcher,
+		secretWatcher:    secretWatcher,
+		fileWatcher:      fileWatcher,
+		translator:       trans,
+		xdsConfig:        xdsConfig,
+		getDependencies:  getDependenciesFor(plugs),
+		reporter:         reporter.NewReporter(store),
+		syncOptions:      opts.SyncOptions,
+		stats:            &syncStats{},
+		adminOptions:     opts.AdminOptions,
+		debug:            newDebugState(),
+		guard:            newSnapshotGuard(opts.SyncOptions),
+		acceptRequests:   make(chan chan error),
+		resolver:         resolverFor(opts),
+		admissionOptions: opts.AdmissionOptions,
+	}
+
+	if opts.AdmissionOptions.BindAddress != "" {
+		e.admission, err = e.admissionHandler(opts, store, plugs)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up admission webhook")
+		}
 	}
 
 	for _, endpointDiscoveryInitializer := range plugins.EndpointDiscoveryInitializers() {
@@ -98,6 +141,60 @@ func Setup(opts bootstrap.Options, xdsPort int, stop <-chan struct{}) (*eventLoo
 	return e, nil
 }
 
+// config objects only have namespaces in kubernetes. refs without a namespace
+// refer to objects in the namespace gloo reads and writes by default
+func resolverFor(opts bootstrap.Options) *namespaces.Resolver {
+	if !readsKube(opts.Options) {
+		return nil
+	}
+	defaultNamespace := opts.KubeOptions.Namespace
+	if defaultNamespace == "" {
+		defaultNamespace = crd.GlooDefaultNamespace
+	}
+	return namespaces.NewResolver(defaultNamespace)
+}
+
+// readsKube returns true if config objects are read from kube, alone or as a backend of composite storage
+func readsKube(opts bootstrapopts.Options) bool {
+	switch opts.ConfigStorageOptions.Type {
+	case bootstrapopts.WatcherTypeKube:
+		return true
+	case bootstrapopts.WatcherTypeComposite:
+		for _, backend := range opts.CompositeOptions.Backends {
+			if backend == bootstrapopts.WatcherTypeKube {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// admissionHandler returns the admission webhook. it reads the secrets and files of dry runs from storage,
+// since the watchers only track those of the stored config
+func (e *eventLoop) admissionHandler(opts bootstrap.Options, store storage.Interface, plugs []plugins.TranslatorPlugin) (http.Handler, error) {
+	var failOpen bool
+	switch opts.AdmissionOptions.FailurePolicy {
+	case bootstrap.AdmissionFailOpen:
+		failOpen = true
+	case bootstrap.AdmissionFailClosed:
+		failOpen = false
+	default:
+		return nil, errors.Errorf("unknown failure policy %v. must be %v or %v", opts.AdmissionOptions.FailurePolicy,
+			bootstrap.AdmissionFailOpen, bootstrap.AdmissionFailClosed)
+	}
+	secrets, err := secretstorage.Bootstrap(opts.Options)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating secret storage client")
+	}
+	files, err := artifactstorage.Bootstrap(opts.Options)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating file storage client")
+	}
+	e.dryRunTranslator = translator.NewTranslator(translatorConfig(opts), plugs)
+	validator := admission.NewValidator(store, secrets, files, e.resolver, e.getDependencies, e.dryRun)
+	return admission.NewHandler(validator, failOpen), nil
+}
+
 func getDependenciesFor(translatorPlugins []plugins.TranslatorPlugin) func(cfg *v1.Config) []*plugins.Dependencies {
 	return func(cfg *v1.Config) []*plugins.Dependencies {
 		var dependencies []*plugins.Dependencies
@@ -130,6 +227,27 @@ func (e *eventLoop) Run(stop <-chan struct{}) error {
 		go eds.Run(stop)
 	}
 
+	e.debug.update(func(state *admin.State) {
+		for _, name := range []string{configWatcherName, secretWatcherName, fileWatcherName} {
+			state.Ready[name] = false
+		}
+		for _, eds := range e.endpointDiscoveries {
+			state.Ready[discoveryName(eds)] = false
+		}
+	})
+	if e.adminOptions.BindAddress != "" {
+		actions := admin.Actions{AcceptSnapshot: e.acceptSnapshot}
+		if err := admin.Run(e.adminOptions.BindAddress, e.adminOptions.Token, e.debug.get, actions, stop); err != nil {
+			return errors.Wrap(err, "starting admin server")
+		}
+	}
+
+	if e.admission != nil {
+		if err := admission.Run(e.admissionOptions.BindAddress, e.admissionOptions.CertFile, e.admissionOptions.KeyFile, e.admission, stop); err != nil {
+			return errors.Wrap(err, "starting admission webhook")
+		}
+	}
+
 	go e.configWatcher.Run(stop)
 	go e.fileWatcher.Run(stop)
 	go e.secretWatcher.Run(stop)
@@ -141,17 +259,51 @@ func (e *eventLoop) Run(stop <-chan struct{}) error {
 	var hash uint64
 	current := newCache()
 	sync := func(current *cache) {
-		newHash := current.hash()
-		if hash == newHash {
+		if !current.ready(e.endpointDiscoveries) {
+			log.Debugf("cache is not fully constructed to produce a first snapshot yet")
+			return
+		}
+		newHash, err := current.hash()
+		if err != nil {
+			// without a hash we can't tell whether anything changed, so translate anyway
+			log.Warnf("failed to hash the latest inputs: %v", err)
+			e.stats.hashError()
+		} else if hash == newHash {
+			e.stats.unchanged()
 			return
 		}
-		hash = newHash
-		e.updateXds(current)
+		// remember the inputs only once envoy has their snapshot, so inputs that failed to translate
+		// or whose snapshot was refused are translated again on the next sync
+		if e.updateXds(current) && err == nil {
+			hash = newHash
+		}
 	}
+
+	// bursts of events are coalesced into a single sync
+	debounce := newDebouncer(e.syncOptions)
+	event := func(source string) {
+		e.stats.event(source)
+		debounce.event()
+	}
+	// the first snapshot waits for every endpoint discovery to report, up to a timeout
+	endpointsTimeout := time.After(e.syncOptions.EndpointsTimeout)
 	for {
 		select {
+		case <-endpointsTimeout:
+			current.endpointsTimedOut = true
+			for _, eds := range e.endpointDiscoveries {
+				if _, ok := current.endpoints[eds]; !ok {
+					log.Printf("%v has not reported endpoints after %v, not waiting for it", discoveryName(eds), e.syncOptions.EndpointsTimeout)
+				}
+			}
+			debounce.event()
+		case reply := <-e.acceptRequests:
+			reply <- e.acceptRefused()
 		case cfg := <-e.configWatcher.Config():
 			log.Debugf("change triggered by config")
+			if e.resolver != nil {
+				cfg = e.resolver.Config(cfg)
+			}
 			current.cfg = cfg
 			dependencies := e.getDependencies(cfg)
 			var secretRefs, fileRefs []string
@@ -165,25 +317,52 @@ func (e *eventLoop) Run(stop <-chan struct{}) error {
 					secretRefs = append(secretRefs, vhost.SslConfig.SecretRef)
 				}
 			}
-			go e.secretWatcher.TrackSecrets(secretRefs)
+			current.secretRefs = secretRefs
+			trackedSecretRefs := secretRefs
+			if e.resolver != nil {
+				trackedSecretRefs = e.resolver.SecretRefs(secretRefs)
+			}
+			e.debug.update(func(state *admin.State) {
+				state.Config = cfg
+				state.SecretRefs = trackedSecretRefs
+				state.FileRefs = fileRefs
+				state.Ready[configWatcherName] = true
+			})
+			go e.secretWatcher.TrackSecrets(trackedSecretRefs)
 			go e.fileWatcher.TrackFiles(fileRefs)
 			for _, discovery := range e.endpointDiscoveries {
 				go func(epd endpointdiscovery.Interface) {
 					epd.TrackUpstreams(cfg.Upstreams)
 				}(discovery)
 			}
-			sync(current)
+			event(eventSourceConfig)
 		case secrets := <-e.secretWatcher.Secrets():
 			log.Debugf("change triggered by secrets")
 			current.secrets = secrets
-			sync(current)
+			e.debug.update(func(state *admin.State) {
+				state.Ready[secretWatcherName] = true
+			})
+			event(eventSourceSecrets)
 		case files := <-e.fileWatcher.Files():
 			log.Debugf("change triggered by files")
 			current.files = files
-			sync(current)
+			e.debug.update(func(state *admin.State) {
+				state.Ready[fileWatcherName] = true
+			})
+			event(eventSourceFiles)
 		case endpointTuple := <-endpointDiscovery:
 			log.Debugf("change triggered by endpoints")
 			current.endpoints[endpointTuple.discoveredBy] = endpointTuple.endpoints
+			e.debug.update(func(state *admin.State) {
+				name := discoveryName(endpointTuple.discoveredBy)
+				state.Endpoints[name] = endpointTuple.endpoints
+				state.Ready[name] = true
+			})
+			event(eventSourceEndpoints)
+		case <-debounce.ready():
+			events := debounce.flush()
+			log.Debugf("syncing after %v events", events)
+			e.stats.sync(events)
 			sync(current)
 		case err := <-workerErrors:
 			log.Warnf("error in control plane event loop: %v", err)
@@ -191,29 +370,38 @@ func (e *eventLoop) Run(stop <-chan struct{}) error {
 	}
 }
 
-func (e *eventLoop) updateXds(cache *cache) {
-	if !cache.ready() {
-		log.Debugf("cache is not fully constructed to produce a first snapshot yet")
-		return
-	}
+// Stats returns the number of events received and syncs run so far
+func (e *eventLoop) Stats() SyncStats {
+	return e.stats.get()
+}
 
+// updateXds translates the inputs and sends the snapshot to envoy. it returns false if envoy keeps the last snapshot
+func (e *eventLoop) updateXds(cache *cache) bool {
 	aggregatedEndpoints := make(endpointdiscovery.EndpointGroups)
 	for _, endpointGroups := range cache.endpoints {
 		for upstreamName, endpointSet := range endpointGroups {
 			aggregatedEndpoints[upstreamName] = endpointSet
 		}
 	}
+	secrets := cache.secrets
+	if e.resolver != nil {
+		secrets = e.resolver.Secrets(secrets, cache.secretRefs)
+	}
+	start := time.Now()
 	snapshot, reports, err := e.translator.Translate(translator.Inputs{
 		Cfg:       cache.cfg,
-		Secrets:   cache.secrets,
+		Secrets:   secrets,
 		Files:     cache.files,
 		Endpoints: aggregatedEndpoints,
 	})
+	translationDuration.Observe(time.Since(start).Seconds())
 	if err != nil {
 		// TODO: panic or handle these internal errors smartly
-		log.Warnf("failed to translate based on the latest config: %v", err)
-		return
+		log.Warnf("failed to translate based on the latest config, envoy keeps the last good snapshot: %v", err)
+		translationErrors.Inc()
+		return false
 	}
+	recordRejected(reports)
 
 	if err := e.reporter.WriteReports(reports); err != nil {
 		log.Warnf("error writing reports: %v", err)
@@ -225,8 +413,57 @@ func (e *eventLoop) updateXds(cache *cache) {
 		}
 	}
 
+	e.debug.update(func(state *admin.State) {
+		state.Reports = reports
+	})
+
+	if err := e.guard.check(snapshot); err != nil {
+		log.Warnf("refusing snapshot, envoy keeps the last good snapshot: %v. "+
+			"POST to %v on the admin api to send it anyway", err, admin.PathAcceptSnapshot)
+		snapshotsRefused.Inc()
+		e.guard.refuse(snapshot)
+		e.debug.update(func(state *admin.State) {
+			state.RefusedSnapshot = snapshot
+			state.RefusedReason = err.Error()
+		})
+		return false
+	}
+	e.sendSnapshot(snapshot)
+	return true
+}
+
+func (e *eventLoop) sendSnapshot(snapshot *envoycache.Snapshot) {
 	log.Debugf("FINAL: XDS Snapshot: %v", snapshot)
 	e.xdsConfig.SetSnapshot(xds.NodeKey, *snapshot)
+	e.guard.sent(snapshot)
+	e.debug.update(func(state *admin.State) {
+		state.Snapshot = snapshot
+		state.RefusedSnapshot = nil
+		state.RefusedReason = ""
+	})
+}
+
+// acceptSnapshot is called by the admin api; the snapshot is sent by the event loop
+func (e *eventLoop) acceptSnapshot() error {
+	reply := make(chan error)
+	e.acceptRequests <- reply
+	return <-reply
+}
+
+func (e *eventLoop) acceptRefused() error {
+	if e.guard.refused == nil {
+		return errors.New("no snapshot has been refused")
+	}
+	log.Printf("sending the refused snapshot, accepted through the admin api")
+	e.sendSnapshot(e.guard.refused)
+	return nil
+}
+
+// dryRun is called by the admission webhook. plugins keep the state of a translation in its context,
+// so the dry run does not need to wait for the event loop's own translations
+func (e *eventLoop) dryRun(inputs translator.Inputs) ([]reporter.ConfigObjectReport, error) {
+	_, reports, err := e.dryRunTranslator.Translate(inputs)
+	return reports, err
 }
 
 // fan out to cover all endpoint discovery services
@@ -275,11 +512,15 @@ func (e *eventLoop) errors() <-chan error {
 
 // cache contains the latest "gloo snapshot"
 type cache struct {
-	cfg     *v1.Config
-	secrets secretwatcher.SecretMap
-	files   filewatcher.Files
+	cfg *v1.Config
+	// the refs of the secrets the config needs, as the config spells them
+	secretRefs []string
+	secrets    secretwatcher.SecretMap
+	files      filewatcher.Files
 	// need to separate endpoints by the service who discovered them
 	endpoints map[endpointdiscovery.Interface]endpointdiscovery.EndpointGroups
+	// whether the first snapshot has stopped waiting for endpoint discoveries
+	endpointsTimedOut bool
 }
 
 func newCache() *cache {
@@ -288,31 +529,49 @@ func newCache() *cache {
 	}
 }
 
-// ready doesn't necessarily tell us whetehr endpoints have been discovered yet
-// but that's okay. envoy won't mind
-func (c *cache) ready() bool {
-	return c.cfg != nil
+// ready returns true once every watcher has read its inputs successfully, so the first snapshot is never
+// translated from an empty or partial config. the secret and file watchers send their first read even if it is empty.
+// endpoint discoveries are waited for until the endpoints timeout
+func (c *cache) ready(discoveries []endpointdiscovery.Interface) bool {
+	if c.cfg == nil || c.secrets == nil || c.files == nil {
+		return false
+	}
+	if c.endpointsTimedOut {
+		return true
+	}
+	for _, discovery := range discoveries {
+		if _, ok := c.endpoints[discovery]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
-func (c *cache) hash() uint64 {
+func (c *cache) hash() (uint64, error) {
 	h0, err := hashstructure.Hash(*c.cfg, nil)
 	if err != nil {
-		panic(err)
+		return 0, errors.Wrap(err, "hashing config")
 	}
 	h1, err := hashstructure.Hash(c.secrets, nil)
 	if err != nil {
-		panic(err)
+		return 0, errors.Wrap(err, "hashing secrets")
 	}
-	h2, err := hashstructure.Hash(c.endpoints, nil)
-	if err != nil {
-		panic(err)
+	// the endpoint discovery clients used as keys are not inputs to the translation,
+	// so only hash the endpoint groups, independent of map order
+	var h2 uint64
+	for _, endpoints := range c.endpoints {
+		h, err := hashstructure.Hash(endpoints, nil)
+		if err != nil {
+			return 0, errors.Wrap(err, "hashing endpoints")
+		}
+		h2 += h
 	}
 	h3, err := hashstructure.Hash(c.files, nil)
 	if err != nil {
-		panic(err)
+		return 0, errors.Wrap(err, "hashing files")
 	}
 	h := h0 + h1 + h2 + h3
-	return h
+	return h, nil
 }
 
 type endpointTuple struct {
@@ -0,0 +1,91 @@
+package eventloop
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/metrics"
+)
+
+const (
+	eventSourceConfig    = "config"
+	eventSourceSecrets   = "secrets"
+	eventSourceFiles     = "files"
+	eventSourceEndpoints = "endpoints"
+)
+
+var (
+	translationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "translation_duration_seconds",
+		Help:      "time taken to translate the gloo config into an envoy snapshot",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	})
+	translationErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "translation_errors_total",
+		Help:      "translations that failed to produce a snapshot",
+	})
+	rejectedConfigObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "rejected_config_objects",
+		Help:      "upstreams and virtual hosts rejected by the last translation",
+	}, []string{"kind"})
+	watchEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "watch_events_total",
+		Help:      "updates received from the config, secret, file and endpoint watchers",
+	}, []string{"source"})
+	syncs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "syncs_total",
+		Help:      "syncs run after coalescing watch events",
+	})
+	coalescedEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "coalesced_events_total",
+		Help:      "watch events that were coalesced into a sync triggered by another event",
+	})
+	snapshotsRefused = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "control_plane",
+		Name:      "snapshots_refused_total",
+		Help:      "snapshots that were not sent to envoy because they remove too many routes",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		translationDuration,
+		translationErrors,
+		rejectedConfigObjects,
+		watchEvents,
+		syncs,
+		coalescedEvents,
+		snapshotsRefused,
+	)
+}
+
+func recordRejected(reports []reporter.ConfigObjectReport) {
+	var upstreams, virtualHosts float64
+	for _, report := range reports {
+		if report.Err == nil {
+			continue
+		}
+		switch report.CfgObject.(type) {
+		case *v1.Upstream:
+			upstreams++
+		case *v1.VirtualHost:
+			virtualHosts++
+		}
+	}
+	rejectedConfigObjects.WithLabelValues("upstream").Set(upstreams)
+	rejectedConfigObjects.WithLabelValues("virtual_host").Set(virtualHosts)
+}
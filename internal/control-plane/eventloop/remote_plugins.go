@@ -0,0 +1,59 @@
+package eventloop
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/plugins/remote"
+)
+
+// startRemotePlugins connects to and launches the plugins that run outside of the control plane
+func startRemotePlugins(opts bootstrap.PluginOptions, stop <-chan struct{}) ([]plugins.TranslatorPlugin, error) {
+	var creds credentials.TransportCredentials
+	if opts.CaFile != "" {
+		var err error
+		creds, err = remote.ClientTLS(opts.CaFile, opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+	} else if opts.CertFile != "" || opts.KeyFile != "" {
+		return nil, errors.New("a ca to verify plugins with must be given with the client certificate for plugins")
+	}
+	var remotePlugins []plugins.TranslatorPlugin
+	for _, spec := range opts.Connect {
+		name, address, err := parsePluginSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		plug, err := remote.Connect(name, address, creds, opts.StartTimeout, opts.CallTimeout)
+		if err != nil {
+			return nil, err
+		}
+		remotePlugins = append(remotePlugins, plug)
+	}
+	for _, spec := range opts.Launch {
+		name, command, err := parsePluginSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		plug, err := remote.Launch(name, strings.Fields(command), opts.StartTimeout, opts.CallTimeout, stop)
+		if err != nil {
+			return nil, err
+		}
+		remotePlugins = append(remotePlugins, plug)
+	}
+	return remotePlugins, nil
+}
+
+// parsePluginSpec splits a name=value plugin flag
+func parsePluginSpec(spec string) (string, string, error) {
+	parts := strings.SplitN(spec, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid plugin %q. must be name=value", spec)
+	}
+	return parts[0], parts[1], nil
+}
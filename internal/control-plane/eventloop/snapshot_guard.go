@@ -0,0 +1,85 @@
+package eventloop
+
+import (
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+)
+
+// snapshotGuard keeps the last snapshot sent to envoy, and refuses new snapshots
+// that would remove too many of its routes, e.g. because a storage backend returned a partial config
+type snapshotGuard struct {
+	maxRouteRemoval   float64
+	allowRouteRemoval bool
+
+	lastGood *envoycache.Snapshot
+	// the last snapshot refused by check, until it is accepted or a later snapshot is sent
+	refused *envoycache.Snapshot
+}
+
+func newSnapshotGuard(opts bootstrap.SyncOptions) *snapshotGuard {
+	return &snapshotGuard{
+		maxRouteRemoval:   opts.MaxRouteRemoval,
+		allowRouteRemoval: opts.AllowRouteRemoval,
+	}
+}
+
+// check returns an error if snapshot removes more than the allowed fraction of the routes of the last good snapshot
+func (g *snapshotGuard) check(snapshot *envoycache.Snapshot) error {
+	if g.lastGood == nil || g.allowRouteRemoval {
+		return nil
+	}
+	before, after := countRoutes(g.lastGood), countRoutes(snapshot)
+	if before == 0 || after >= before {
+		return nil
+	}
+	if float64(before-after)/float64(before) <= g.maxRouteRemoval {
+		return nil
+	}
+	return errors.Errorf("the new snapshot removes %v of the %v routes currently served, more than the allowed %v%%",
+		before-after, before, g.maxRouteRemoval*100)
+}
+
+func (g *snapshotGuard) refuse(snapshot *envoycache.Snapshot) {
+	g.refused = snapshot
+}
+
+func (g *snapshotGuard) sent(snapshot *envoycache.Snapshot) {
+	g.lastGood = snapshot
+	g.refused = nil
+}
+
+// the network filter of the filter chains that serve tcp routes
+const tcpProxyFilter = "envoy.tcp_proxy"
+
+// countRoutes counts the http routes of the snapshot's route configurations,
+// and the tcp routes served by the tcp_proxy filter chains of its listeners
+func countRoutes(snapshot *envoycache.Snapshot) int {
+	var routes int
+	for _, item := range snapshot.Routes.Items {
+		routeConfig, ok := item.(*envoyapi.RouteConfiguration)
+		if !ok {
+			continue
+		}
+		for _, vhost := range routeConfig.VirtualHosts {
+			routes += len(vhost.Routes)
+		}
+	}
+	for _, item := range snapshot.Listeners.Items {
+		listener, ok := item.(*envoyapi.Listener)
+		if !ok {
+			continue
+		}
+		for _, filterChain := range listener.FilterChains {
+			for _, filter := range filterChain.Filters {
+				if filter.Name == tcpProxyFilter {
+					routes++
+					break
+				}
+			}
+		}
+	}
+	return routes
+}
@@ -0,0 +1,73 @@
+package eventloop
+
+import (
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoylistener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/internal/control-plane/bootstrap"
+)
+
+func snapshotWithRoutes(n int) *envoycache.Snapshot {
+	vhost := envoyroute.VirtualHost{Name: "vhost"}
+	for i := 0; i < n; i++ {
+		vhost.Routes = append(vhost.Routes, envoyroute.Route{})
+	}
+	return &envoycache.Snapshot{
+		Routes: envoycache.NewResources("1", []envoycache.Resource{
+			&envoyapi.RouteConfiguration{Name: "routes", VirtualHosts: []envoyroute.VirtualHost{vhost}},
+		}),
+	}
+}
+
+func snapshotWithTcpRoutes(n int) *envoycache.Snapshot {
+	listener := &envoyapi.Listener{Name: "listener-tcp-5432"}
+	for i := 0; i < n; i++ {
+		listener.FilterChains = append(listener.FilterChains, envoylistener.FilterChain{
+			Filters: []envoylistener.Filter{{Name: "envoy.tcp_proxy"}},
+		})
+	}
+	return &envoycache.Snapshot{
+		Listeners: envoycache.NewResources("1", []envoycache.Resource{listener}),
+	}
+}
+
+var _ = Describe("snapshotGuard", func() {
+	It("allows any first snapshot", func() {
+		g := newSnapshotGuard(bootstrap.SyncOptions{MaxRouteRemoval: 0.5})
+		Expect(g.check(snapshotWithRoutes(0))).To(Succeed())
+	})
+	It("refuses snapshots that remove more than the allowed fraction of routes", func() {
+		g := newSnapshotGuard(bootstrap.SyncOptions{MaxRouteRemoval: 0.5})
+		g.sent(snapshotWithRoutes(10))
+		Expect(g.check(snapshotWithRoutes(5))).To(Succeed())
+		Expect(g.check(snapshotWithRoutes(20))).To(Succeed())
+		Expect(g.check(snapshotWithRoutes(4))).NotTo(Succeed())
+		Expect(g.check(snapshotWithRoutes(0))).NotTo(Succeed())
+	})
+	It("refuses snapshots that remove more than the allowed fraction of tcp routes", func() {
+		g := newSnapshotGuard(bootstrap.SyncOptions{MaxRouteRemoval: 0.5})
+		g.sent(snapshotWithTcpRoutes(4))
+		Expect(g.check(snapshotWithTcpRoutes(2))).To(Succeed())
+		Expect(g.check(snapshotWithTcpRoutes(1))).NotTo(Succeed())
+		Expect(g.check(snapshotWithTcpRoutes(0))).NotTo(Succeed())
+	})
+	It("forgets the refused snapshot once a snapshot is sent", func() {
+		g := newSnapshotGuard(bootstrap.SyncOptions{MaxRouteRemoval: 0.5})
+		g.sent(snapshotWithRoutes(10))
+		refused := snapshotWithRoutes(1)
+		g.refuse(refused)
+		Expect(g.refused).To(Equal(refused))
+		g.sent(refused)
+		Expect(g.refused).To(BeNil())
+		Expect(g.check(snapshotWithRoutes(1))).To(Succeed())
+	})
+	It("allows any removal when configured to", func() {
+		g := newSnapshotGuard(bootstrap.SyncOptions{MaxRouteRemoval: 0.5, AllowRouteRemoval: true})
+		g.sent(snapshotWithRoutes(10))
+		Expect(g.check(snapshotWithRoutes(0))).To(Succeed())
+	})
+})
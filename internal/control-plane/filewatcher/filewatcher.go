@@ -95,11 +95,8 @@ func (w *fileWatcher) TrackFiles(fileRefs []string) {
 		log.Warnf("failed to get updated file list: %v", err)
 		return
 	}
-	files := filterFiles(fileRefs, list)
-	if len(files) < 1 {
-		return
-	}
-	w.files <- files
+	// sent even if none of the files exist, so the receiver knows the refs have been read
+	w.files <- filterFiles(fileRefs, list)
 }
 
 func (w *fileWatcher) Files() <-chan Files {
@@ -0,0 +1,107 @@
+package namespaces
+
+import (
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+)
+
+// Resolver spells every name and ref in the config the same way, so the rest of the control plane
+// can match refs to objects by name: objects in the default namespace are referred to by their name,
+// objects in any other namespace as "namespace/name".
+// a ref without a namespace refers to an object in the namespace of the object that contains the ref
+type Resolver struct {
+	defaultNamespace string
+}
+
+func NewResolver(defaultNamespace string) *Resolver {
+	return &Resolver{defaultNamespace: defaultNamespace}
+}
+
+// Ref returns the ref made by an object in the given namespace, as the control plane spells it
+func (r *Resolver) Ref(ref, namespace string) string {
+	return v1.ShortenRef(v1.QualifyRef(ref, namespace), r.defaultNamespace)
+}
+
+func (r *Resolver) namespace(namespace string) string {
+	if namespace == r.defaultNamespace {
+		return ""
+	}
+	return namespace
+}
+
+// Config returns a copy of the config in which the names of upstreams and virtual hosts,
+// route and tcp route destinations and ssl secret refs are qualified with their namespace.
+// the namespace of objects in the default namespace is cleared, so plugins that qualify
+// the refs in their specs with the namespace of the upstream spell them the same way
+func (r *Resolver) Config(cfg *v1.Config) *v1.Config {
+	resolved := proto.Clone(cfg).(*v1.Config)
+	for _, us := range resolved.Upstreams {
+		namespace := us.GetMetadata().GetNamespace()
+		us.Name = r.Ref(us.Name, namespace)
+		if us.Metadata != nil {
+			us.Metadata.Namespace = r.namespace(namespace)
+		}
+	}
+	for _, vhost := range resolved.VirtualHosts {
+		namespace := vhost.GetMetadata().GetNamespace()
+		vhost.Name = r.Ref(vhost.Name, namespace)
+		if vhost.Metadata != nil {
+			vhost.Metadata.Namespace = r.namespace(namespace)
+		}
+		if vhost.SslConfig != nil && vhost.SslConfig.SecretRef != "" {
+			vhost.SslConfig.SecretRef = r.Ref(vhost.SslConfig.SecretRef, namespace)
+		}
+		for _, route := range vhost.Routes {
+			r.destination(route.SingleDestination, namespace)
+			for _, weighted := range route.MultipleDestinations {
+				r.destination(weighted.Destination, namespace)
+			}
+		}
+		for _, route := range vhost.TcpRoutes {
+			r.destination(route.SingleDestination, namespace)
+			for _, weighted := range route.MultipleDestinations {
+				r.destination(weighted.Destination, namespace)
+			}
+		}
+	}
+	return resolved
+}
+
+func (r *Resolver) destination(destination *v1.Destination, namespace string) {
+	switch dest := destination.GetDestinationType().(type) {
+	case *v1.Destination_Upstream:
+		if dest.Upstream != nil {
+			dest.Upstream.Name = r.Ref(dest.Upstream.Name, namespace)
+		}
+	case *v1.Destination_Function:
+		if dest.Function != nil {
+			dest.Function.UpstreamName = r.Ref(dest.Function.UpstreamName, namespace)
+		}
+	}
+}
+
+// SecretRefs returns the refs as secret storage spells them
+func (r *Resolver) SecretRefs(refs []string) []string {
+	var resolved []string
+	for _, ref := range refs {
+		resolved = append(resolved, r.Ref(ref, ""))
+	}
+	return resolved
+}
+
+// Secrets returns the secrets read for the resolved refs under each ref as it was given,
+// so a plugin finds its secrets however it spells the ref
+func (r *Resolver) Secrets(secrets secretwatcher.SecretMap, refs []string) secretwatcher.SecretMap {
+	found := make(secretwatcher.SecretMap)
+	for ref, secret := range secrets {
+		found[ref] = secret
+	}
+	for _, ref := range refs {
+		if secret, ok := secrets[r.Ref(ref, "")]; ok {
+			found[ref] = secret
+		}
+	}
+	return found
+}
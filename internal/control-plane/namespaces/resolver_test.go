@@ -0,0 +1,116 @@
+package namespaces
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+func upstreamDestination(name string) *v1.Destination {
+	return &v1.Destination{DestinationType: &v1.Destination_Upstream{Upstream: &v1.UpstreamDestination{Name: name}}}
+}
+
+func functionDestination(upstreamName, functionName string) *v1.Destination {
+	return &v1.Destination{DestinationType: &v1.Destination_Function{Function: &v1.FunctionDestination{
+		UpstreamName: upstreamName,
+		FunctionName: functionName,
+	}}}
+}
+
+var _ = Describe("Resolver", func() {
+	resolver := NewResolver("gloo-system")
+
+	Describe("Ref", func() {
+		It("refers to objects in the default namespace by name", func() {
+			Expect(resolver.Ref("petstore", "gloo-system")).To(Equal("petstore"))
+			Expect(resolver.Ref("gloo-system/petstore", "team-a")).To(Equal("petstore"))
+			Expect(resolver.Ref("petstore", "")).To(Equal("petstore"))
+		})
+		It("qualifies refs without a namespace with the namespace of the referring object", func() {
+			Expect(resolver.Ref("petstore", "team-a")).To(Equal("team-a/petstore"))
+		})
+		It("keeps the namespace of qualified refs", func() {
+			Expect(resolver.Ref("team-b/petstore", "team-a")).To(Equal("team-b/petstore"))
+			Expect(resolver.Ref("team-b/petstore", "gloo-system")).To(Equal("team-b/petstore"))
+		})
+	})
+
+	Describe("Config", func() {
+		var cfg *v1.Config
+		BeforeEach(func() {
+			cfg = &v1.Config{
+				Upstreams: []*v1.Upstream{
+					{Name: "default-us", Metadata: &v1.Metadata{Namespace: "gloo-system"}},
+					{Name: "team-us", Metadata: &v1.Metadata{Namespace: "team-a"}},
+					{Name: "no-metadata"},
+				},
+				VirtualHosts: []*v1.VirtualHost{
+					{
+						Name:      "team-vhost",
+						Metadata:  &v1.Metadata{Namespace: "team-a"},
+						SslConfig: &v1.SSLConfig{SecretRef: "team-cert"},
+						Routes: []*v1.Route{
+							{SingleDestination: upstreamDestination("team-us")},
+							{SingleDestination: functionDestination("gloo-system/default-us", "fn")},
+							{MultipleDestinations: []*v1.WeightedDestination{
+								{Destination: upstreamDestination("team-us"), Weight: 1},
+								{Destination: upstreamDestination("team-b/other-us"), Weight: 1},
+							}},
+						},
+						TcpRoutes: []*v1.TcpRoute{
+							{Port: 5432, SingleDestination: upstreamDestination("team-us")},
+							{Port: 6379, MultipleDestinations: []*v1.WeightedDestination{
+								{Destination: upstreamDestination("team-us"), Weight: 1},
+								{Destination: upstreamDestination("gloo-system/default-us"), Weight: 1},
+							}},
+						},
+					},
+				},
+			}
+		})
+		It("qualifies the names of objects outside the default namespace", func() {
+			resolved := resolver.Config(cfg)
+			Expect(resolved.Upstreams[0].Name).To(Equal("default-us"))
+			Expect(resolved.Upstreams[0].Metadata.Namespace).To(Equal(""))
+			Expect(resolved.Upstreams[1].Name).To(Equal("team-a/team-us"))
+			Expect(resolved.Upstreams[1].Metadata.Namespace).To(Equal("team-a"))
+			Expect(resolved.Upstreams[2].Name).To(Equal("no-metadata"))
+			Expect(resolved.VirtualHosts[0].Name).To(Equal("team-a/team-vhost"))
+		})
+		It("resolves refs relative to the namespace of the virtual host", func() {
+			vhost := resolver.Config(cfg).VirtualHosts[0]
+			Expect(vhost.SslConfig.SecretRef).To(Equal("team-a/team-cert"))
+			Expect(vhost.Routes[0].SingleDestination).To(Equal(upstreamDestination("team-a/team-us")))
+			Expect(vhost.Routes[1].SingleDestination).To(Equal(functionDestination("default-us", "fn")))
+			Expect(vhost.Routes[2].MultipleDestinations[0].Destination).To(Equal(upstreamDestination("team-a/team-us")))
+			Expect(vhost.Routes[2].MultipleDestinations[1].Destination).To(Equal(upstreamDestination("team-b/other-us")))
+		})
+		It("resolves the destinations of tcp routes relative to the namespace of the virtual host", func() {
+			vhost := resolver.Config(cfg).VirtualHosts[0]
+			Expect(vhost.TcpRoutes[0].SingleDestination).To(Equal(upstreamDestination("team-a/team-us")))
+			Expect(vhost.TcpRoutes[1].MultipleDestinations[0].Destination).To(Equal(upstreamDestination("team-a/team-us")))
+			Expect(vhost.TcpRoutes[1].MultipleDestinations[1].Destination).To(Equal(upstreamDestination("default-us")))
+		})
+		It("does not modify the original config", func() {
+			resolver.Config(cfg)
+			Expect(cfg.Upstreams[1].Name).To(Equal("team-us"))
+			Expect(cfg.VirtualHosts[0].Routes[0].SingleDestination).To(Equal(upstreamDestination("team-us")))
+		})
+	})
+
+	Describe("Secrets", func() {
+		It("returns the secrets under each ref as it was given", func() {
+			secret := &dependencies.Secret{Ref: "aws-creds"}
+			refs := []string{"gloo-system/aws-creds", "aws-creds"}
+			Expect(resolver.SecretRefs(refs)).To(Equal([]string{"aws-creds", "aws-creds"}))
+			secrets := resolver.Secrets(secretwatcher.SecretMap{"aws-creds": secret}, refs)
+			Expect(secrets).To(Equal(secretwatcher.SecretMap{
+				"aws-creds":             secret,
+				"gloo-system/aws-creds": secret,
+			}))
+		})
+	})
+})
@@ -0,0 +1,172 @@
+// Package offline translates a config stored in local directories without running the control plane
+package offline
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/internal/control-plane/dump"
+	"github.com/solo-io/gloo/internal/control-plane/filewatcher"
+	"github.com/solo-io/gloo/internal/control-plane/translator"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage/dependencies/file"
+	filestorage "github.com/solo-io/gloo/pkg/storage/file"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Options locate the config in the layout used by file storage
+type Options struct {
+	ConfigDir string
+	SecretDir string
+	FilesDir  string
+
+	IngressBindAddress string
+}
+
+// Output is the result of an offline translation
+type Output struct {
+	Snapshot map[string]dump.Resources `json:"snapshot"`
+	Reports  []dump.Report             `json:"reports"`
+}
+
+// Rejected returns the number of config objects that failed translation
+func (o *Output) Rejected() int {
+	var rejected int
+	for _, report := range o.Reports {
+		if report.Error != "" {
+			rejected++
+		}
+	}
+	return rejected
+}
+
+// Translate reads the config, secrets and files and translates them with the given plugins
+func Translate(opts Options, plugs []plugins.TranslatorPlugin) (*Output, error) {
+	cfg, err := readConfig(opts.ConfigDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading config from %v", opts.ConfigDir)
+	}
+	secrets, err := readSecrets(opts.SecretDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading secrets from %v", opts.SecretDir)
+	}
+	files, err := readFiles(opts.FilesDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading files from %v", opts.FilesDir)
+	}
+
+	t := translator.NewTranslator(translator.TranslatorConfig{IngressBindAddress: opts.IngressBindAddress}, plugs)
+	snap, reports, err := t.Translate(translator.Inputs{
+		Cfg:     cfg,
+		Secrets: secrets,
+		Files:   files,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "translating config")
+	}
+	rendered, err := dump.Snapshot(snap)
+	if err != nil {
+		return nil, err
+	}
+	return &Output{
+		Snapshot: rendered,
+		Reports:  dump.Reports(reports),
+	}, nil
+}
+
+// Print writes the output as json or yaml
+func Print(w io.Writer, out *Output, format string) error {
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshalling output")
+	}
+	switch format {
+	case FormatJSON:
+		data = append(data, '\n')
+	case FormatYAML:
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return errors.Wrap(err, "converting output to yaml")
+		}
+	default:
+		return errors.Errorf("unknown output format %v, must be one of %v or %v", format, FormatJSON, FormatYAML)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func readConfig(dir string) (*v1.Config, error) {
+	store, err := filestorage.NewStorage(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	upstreams, err := store.V1().Upstreams().List(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing upstreams")
+	}
+	virtualHosts, err := store.V1().VirtualHosts().List(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing virtual hosts")
+	}
+	// storage lists in no particular order
+	sort.SliceStable(upstreams, func(i, j int) bool {
+		return upstreams[i].Name < upstreams[j].Name
+	})
+	sort.SliceStable(virtualHosts, func(i, j int) bool {
+		return virtualHosts[i].Name < virtualHosts[j].Name
+	})
+	return &v1.Config{
+		Upstreams:    upstreams,
+		VirtualHosts: virtualHosts,
+	}, nil
+}
+
+// secrets and files are optional, so a missing directory is treated as empty
+func readSecrets(dir string) (secretwatcher.SecretMap, error) {
+	secrets := make(secretwatcher.SecretMap)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return secrets, nil
+	}
+	store, err := file.NewSecretStorage(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	list, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range list {
+		secrets[secret.Ref] = secret
+	}
+	return secrets, nil
+}
+
+func readFiles(dir string) (filewatcher.Files, error) {
+	files := make(filewatcher.Files)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files, nil
+	}
+	store, err := file.NewFileStorage(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	list, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range list {
+		files[f.Ref] = f
+	}
+	return files, nil
+}
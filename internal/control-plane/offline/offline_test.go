@@ -0,0 +1,115 @@
+package offline_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/internal/control-plane/dump"
+	. "github.com/solo-io/gloo/internal/control-plane/offline"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/coreplugins/service"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/storage"
+	filestorage "github.com/solo-io/gloo/pkg/storage/file"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("Offline translation", func() {
+	var (
+		dir   string
+		opts  Options
+		store storage.Interface
+		plugs []plugins.TranslatorPlugin
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "offlinetest")
+		Must(err)
+		opts = Options{
+			ConfigDir: filepath.Join(dir, "_gloo_config"),
+			SecretDir: filepath.Join(dir, "_gloo_secrets"),
+			FilesDir:  filepath.Join(dir, "_gloo_files"),
+		}
+		store, err = filestorage.NewStorage(opts.ConfigDir, 0)
+		Must(err)
+		Must(store.V1().Register())
+		plugs = []plugins.TranslatorPlugin{&service.Plugin{}}
+
+		_, err = store.V1().Upstreams().Create(&v1.Upstream{
+			Name: "my-service",
+			Type: service.UpstreamTypeService,
+			Spec: service.EncodeUpstreamSpec(service.UpstreamSpec{
+				Hosts: []service.Host{{Addr: "localhost", Port: 1234}},
+			}),
+		})
+		Must(err)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	createVirtualHost := func(name, upstreamName string) {
+		_, err := store.V1().VirtualHosts().Create(&v1.VirtualHost{
+			Name:    name,
+			Domains: []string{name + ".example.com"},
+			Routes: []*v1.Route{{
+				Matcher: &v1.Route_RequestMatcher{
+					RequestMatcher: &v1.RequestMatcher{
+						Path: &v1.RequestMatcher_PathPrefix{PathPrefix: "/"},
+					},
+				},
+				SingleDestination: &v1.Destination{
+					DestinationType: &v1.Destination_Upstream{
+						Upstream: &v1.UpstreamDestination{Name: upstreamName},
+					},
+				},
+			}},
+		})
+		Must(err)
+	}
+	It("translates the config in the directory", func() {
+		createVirtualHost("my-vhost", "my-service")
+		out, err := Translate(opts, plugs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.Rejected()).To(Equal(0))
+		Expect(out.Reports).To(Equal([]dump.Report{
+			{Kind: "upstream", Name: "my-service"},
+			{Kind: "virtual_host", Name: "my-vhost"},
+		}))
+		Expect(out.Snapshot["clusters"].Items).To(HaveKey("my-service"))
+		Expect(out.Snapshot["listeners"].Items).NotTo(BeEmpty())
+		Expect(out.Snapshot["routes"].Items).NotTo(BeEmpty())
+	})
+	It("reports rejected config objects", func() {
+		createVirtualHost("my-vhost", "missing-service")
+		out, err := Translate(opts, plugs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.Rejected()).To(Equal(1))
+		Expect(out.Reports[1].Error).To(ContainSubstring("missing-service"))
+	})
+	It("prints the output as json or yaml", func() {
+		createVirtualHost("my-vhost", "my-service")
+		out, err := Translate(opts, plugs)
+		Expect(err).NotTo(HaveOccurred())
+
+		jsn := &bytes.Buffer{}
+		Expect(Print(jsn, out, FormatJSON)).To(Succeed())
+		var fromJSON Output
+		Expect(json.Unmarshal(jsn.Bytes(), &fromJSON)).To(Succeed())
+		Expect(fromJSON.Reports).To(Equal(out.Reports))
+
+		yml := &bytes.Buffer{}
+		Expect(Print(yml, out, FormatYAML)).To(Succeed())
+		converted, err := yaml.YAMLToJSON(yml.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted).To(MatchJSON(jsn.Bytes()))
+
+		Expect(Print(yml, out, "xml")).NotTo(Succeed())
+	})
+})
@@ -12,10 +12,10 @@ import (
 	"github.com/solo-io/gloo/pkg/storage/crd"
 	"k8s.io/client-go/tools/clientcmd"
 
-	"github.com/solo-io/gloo/pkg/api/types/v1"
-	. "github.com/solo-io/gloo/test/helpers"
 	. "github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/log"
+	. "github.com/solo-io/gloo/test/helpers"
 )
 
 var _ = Describe("CrdReporter", func() {
@@ -78,13 +78,13 @@ var _ = Describe("CrdReporter", func() {
 			It("writes an acceptance status for each crd", func() {
 				err := rptr.WriteReports(reports)
 				Expect(err).NotTo(HaveOccurred())
-				updatedUpstreams, err := glooClient.V1().Upstreams().List()
+				updatedUpstreams, err := glooClient.V1().Upstreams().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(updatedUpstreams).To(HaveLen(len(upstreams)))
 				for _, updatedUpstream := range updatedUpstreams {
 					Expect(updatedUpstream.Status.State).To(Equal(v1.Status_Accepted))
 				}
-				updatedVhosts, err := glooClient.V1().VirtualHosts().List()
+				updatedVhosts, err := glooClient.V1().VirtualHosts().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(updatedVhosts).To(HaveLen(len(upstreams)))
 				for _, updatedVhost := range updatedVhosts {
@@ -125,13 +125,13 @@ var _ = Describe("CrdReporter", func() {
 			It("writes an rejected status for each crd", func() {
 				err := rptr.WriteReports(reports)
 				Expect(err).NotTo(HaveOccurred())
-				updatedUpstreams, err := glooClient.V1().Upstreams().List()
+				updatedUpstreams, err := glooClient.V1().Upstreams().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(updatedUpstreams).To(HaveLen(len(upstreams)))
 				for _, updatedUpstream := range updatedUpstreams {
 					Expect(updatedUpstream.Status.State).To(Equal(v1.Status_Rejected))
 				}
-				updatedVhosts, err := glooClient.V1().VirtualHosts().List()
+				updatedVhosts, err := glooClient.V1().VirtualHosts().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(updatedVhosts).To(HaveLen(len(upstreams)))
 				for _, updatedVhost := range updatedVhosts {
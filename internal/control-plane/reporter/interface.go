@@ -1,12 +1,21 @@
 package reporter
 
 import (
+	"time"
+
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 )
 
 type ConfigObjectReport struct {
 	CfgObject v1.ConfigObject
 	Err       error
+	// problems that did not cause the config object to be rejected
+	Warnings []string
+	// errors for individual routes of a virtual host, by the index of the route.
+	// they are also included in Err
+	RouteErrors map[int]error
+	// when the config object was evaluated
+	EvaluationTime time.Time
 }
 
 type Interface interface {
@@ -1,67 +1,224 @@
 package reporter
 
 import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
-	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/metrics"
+	"github.com/solo-io/gloo/pkg/storage"
 )
 
+const (
+	// number of reports written at the same time
+	parallelism = 10
+	// times a status update is retried after it conflicted with another update to the config object
+	maxConflictRetries = 5
+	conflictBackoff    = 100 * time.Millisecond
+)
+
+var writeFailures = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "control_plane",
+	Name:      "report_write_failures_total",
+	Help:      "status reports that could not be written to storage",
+})
+
+func init() {
+	prometheus.MustRegister(writeFailures)
+}
+
 type reporter struct {
 	store storage.Interface
+
+	// the resource version each config object was given by the last status update the reporter made.
+	// a config object at that version has not been changed since it was evaluated, other than its status
+	writtenLock sync.Mutex
+	written     map[string]string
 }
 
 func NewReporter(store storage.Interface) *reporter {
-	return &reporter{store: store}
+	return &reporter{store: store, written: make(map[string]string)}
 }
 
+// WriteReports writes the reports concurrently. a report that fails to be written
+// does not stop the others; all failures are returned together
 func (r *reporter) WriteReports(reports []ConfigObjectReport) error {
+	var (
+		wg        sync.WaitGroup
+		errsLock  sync.Mutex
+		errs      error
+		semaphore = make(chan struct{}, parallelism)
+	)
 	for _, report := range reports {
-		if err := r.writeReport(report); err != nil {
-			return errors.Wrapf(err, "failed to write report for config object %v", report.CfgObject)
-		}
-		log.Debugf("wrote report for %v", report.CfgObject.GetName())
+		report := report
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := r.writeReport(report); err != nil {
+				writeFailures.Inc()
+				errsLock.Lock()
+				errs = multierror.Append(errs, errors.Wrapf(err, "failed to write report for config object %v", report.CfgObject.GetName()))
+				errsLock.Unlock()
+				return
+			}
+			log.Debugf("wrote report for %v", report.CfgObject.GetName())
+		}()
 	}
-	return nil
+	wg.Wait()
+	return errs
 }
 
-func (r *reporter) writeReport(report ConfigObjectReport) error {
-	status := &v1.Status{
-		State: v1.Status_Accepted,
+// statusClient reads config objects of one kind and writes their status
+type statusClient struct {
+	kind string
+	get  func(name string) (v1.ConfigObject, error)
+	// writes the status of the config object read with the given name.
+	// returns the resource version of the config object after the write
+	write func(name string, cfgObject v1.ConfigObject, status *v1.Status) (string, error)
+}
+
+func (r *reporter) statusClientFor(cfgObject v1.ConfigObject) (statusClient, bool) {
+	switch cfgObject.(type) {
+	case *v1.Upstream:
+		upstreams := r.store.V1().Upstreams()
+		return statusClient{
+			kind: "upstream",
+			get: func(name string) (v1.ConfigObject, error) {
+				return upstreams.Get(name)
+			},
+			write: func(name string, cfgObject v1.ConfigObject, status *v1.Status) (string, error) {
+				if statusWriter, ok := upstreams.(storage.StatusWriter); ok {
+					return statusWriter.UpdateStatus(name, status)
+				}
+				us := cfgObject.(*v1.Upstream)
+				us.Status = status
+				updated, err := upstreams.Update(us)
+				if err != nil {
+					return "", err
+				}
+				return updated.GetMetadata().GetResourceVersion(), nil
+			},
+		}, true
+	case *v1.VirtualHost:
+		virtualHosts := r.store.V1().VirtualHosts()
+		return statusClient{
+			kind: "virtualhost",
+			get: func(name string) (v1.ConfigObject, error) {
+				return virtualHosts.Get(name)
+			},
+			write: func(name string, cfgObject v1.ConfigObject, status *v1.Status) (string, error) {
+				if statusWriter, ok := virtualHosts.(storage.StatusWriter); ok {
+					return statusWriter.UpdateStatus(name, status)
+				}
+				virtualHost := cfgObject.(*v1.VirtualHost)
+				virtualHost.Status = status
+				updated, err := virtualHosts.Update(virtualHost)
+				if err != nil {
+					return "", err
+				}
+				return updated.GetMetadata().GetResourceVersion(), nil
+			},
+		}, true
 	}
-	if report.Err != nil {
-		status.State = v1.Status_Rejected
-		status.Reason = report.Err.Error()
+	return statusClient{}, false
+}
+
+// writeReport uses the status-only write path of the storage backend if it has one.
+// otherwise the whole config object is updated, which fails if the object was changed since it was read;
+// in that case the latest version is read and the update is retried
+func (r *reporter) writeReport(report ConfigObjectReport) error {
+	client, ok := r.statusClientFor(report.CfgObject)
+	if !ok {
+		return nil
 	}
+	status := statusForReport(report)
+	// the name may be qualified with the namespace of the config object, which the storage client understands
 	name := report.CfgObject.GetName()
-	switch report.CfgObject.(type) {
-	case *v1.Upstream:
-		us, err := r.store.V1().Upstreams().Get(report.CfgObject.GetName())
+	key := client.kind + "/" + name
+	for attempt := 0; ; attempt++ {
+		current, err := client.get(name)
 		if err != nil {
-			return errors.Wrapf(err, "failed to find upstream %v", name)
+			return errors.Wrapf(err, "failed to find %v %v", client.kind, name)
 		}
 		// only update if status doesn't match
-		if us.Status.Equal(status) {
+		if r.upToDate(key, current.GetStatus(), status) {
 			return nil
 		}
-		us.Status = status
-		if _, err := r.store.V1().Upstreams().Update(us); err != nil {
-			return errors.Wrapf(err, "failed to update upstream store with status report")
-		}
-	case *v1.VirtualHost:
-		virtualHost, err := r.store.V1().VirtualHosts().Get(name)
-		if err != nil {
-			return errors.Wrapf(err, "failed to find virtualhost %v", name)
-		}
-		// only update if status doesn't match
-		if virtualHost.Status.Equal(status) {
+		resourceVersion, err := client.write(name, current, status)
+		if err == nil {
+			r.setWritten(key, resourceVersion)
 			return nil
 		}
-		virtualHost.Status = status
-		if _, err := r.store.V1().VirtualHosts().Update(virtualHost); err != nil {
-			return errors.Wrapf(err, "failed to update virtualhost store with status report")
+		if !storage.IsConflict(err) || attempt >= maxConflictRetries {
+			return errors.Wrapf(err, "failed to update %v %v with status report", client.kind, name)
 		}
+		log.Debugf("status update for %v %v conflicted, retrying: %v", client.kind, name, err)
+		time.Sleep(conflictBackoff * time.Duration(attempt+1))
+	}
+}
+
+func statusForReport(report ConfigObjectReport) *v1.Status {
+	status := &v1.Status{
+		State:                   v1.Status_Accepted,
+		Warnings:                report.Warnings,
+		ObservedResourceVersion: report.CfgObject.GetMetadata().GetResourceVersion(),
 	}
-	return nil
+	if report.Err != nil {
+		status.State = v1.Status_Rejected
+		status.Reason = report.Err.Error()
+	}
+	for index, err := range report.RouteErrors {
+		status.RouteErrors = append(status.RouteErrors, &v1.RouteError{
+			Index:  uint32(index),
+			Reason: err.Error(),
+		})
+	}
+	sort.SliceStable(status.RouteErrors, func(i, j int) bool {
+		return status.RouteErrors[i].Index < status.RouteErrors[j].Index
+	})
+	if !report.EvaluationTime.IsZero() {
+		evaluationTime := report.EvaluationTime
+		status.EvaluationTime = &evaluationTime
+	}
+	return status
+}
+
+// upToDate returns true if writing the status would not tell the user anything new.
+// every status update changes the resource version of the config object, which causes it to be evaluated again;
+// the status is not written again for an evaluation of the version the reporter itself created
+func (r *reporter) upToDate(key string, current, status *v1.Status) bool {
+	if current == nil || !withoutEvaluation(current).Equal(withoutEvaluation(status)) {
+		return false
+	}
+	if current.ObservedResourceVersion == status.ObservedResourceVersion {
+		return true
+	}
+	r.writtenLock.Lock()
+	defer r.writtenLock.Unlock()
+	return r.written[key] == status.ObservedResourceVersion
+}
+
+func (r *reporter) setWritten(key, resourceVersion string) {
+	r.writtenLock.Lock()
+	defer r.writtenLock.Unlock()
+	r.written[key] = resourceVersion
+}
+
+// the parts of the status that describe the outcome of the evaluation, rather than when it happened
+func withoutEvaluation(status *v1.Status) *v1.Status {
+	out := *status
+	out.ObservedResourceVersion = ""
+	out.EvaluationTime = nil
+	return &out
 }
@@ -0,0 +1,156 @@
+package reporter_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	. "github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	filestorage "github.com/solo-io/gloo/pkg/storage/file"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("Reporter", func() {
+	var (
+		dir   string
+		store storage.Interface
+		rptr  Interface
+		vhost *v1.VirtualHost
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "reportertest")
+		Must(err)
+		store, err = filestorage.NewStorage(dir, 0)
+		Must(err)
+		Must(store.V1().Register())
+		vhost, err = store.V1().VirtualHosts().Create(&v1.VirtualHost{Name: "my-vhost"})
+		Must(err)
+		rptr = NewReporter(store)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	It("writes warnings, route errors and the evaluated version", func() {
+		evaluated := time.Now().Round(time.Second)
+		err := rptr.WriteReports([]ConfigObjectReport{{
+			CfgObject:      vhost,
+			Err:            errors.New("route 1 is bad"),
+			Warnings:       []string{"something looks off"},
+			RouteErrors:    map[int]error{1: errors.New("route 1 is bad")},
+			EvaluationTime: evaluated,
+		}})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated, err := store.V1().VirtualHosts().Get("my-vhost")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.State).To(Equal(v1.Status_Rejected))
+		Expect(updated.Status.Reason).To(Equal("route 1 is bad"))
+		Expect(updated.Status.Warnings).To(Equal([]string{"something looks off"}))
+		Expect(updated.Status.RouteErrors).To(Equal([]*v1.RouteError{{Index: 1, Reason: "route 1 is bad"}}))
+		Expect(updated.Status.ObservedResourceVersion).To(Equal(vhost.Metadata.ResourceVersion))
+		Expect(updated.Status.EvaluationTime.Equal(evaluated)).To(BeTrue())
+	})
+	It("does not rewrite a status when only the evaluation time changed", func() {
+		first := time.Now().Round(time.Second)
+		Expect(rptr.WriteReports([]ConfigObjectReport{{CfgObject: vhost, EvaluationTime: first}})).To(Succeed())
+		Expect(rptr.WriteReports([]ConfigObjectReport{{CfgObject: vhost, EvaluationTime: first.Add(time.Minute)}})).To(Succeed())
+
+		updated, err := store.V1().VirtualHosts().Get("my-vhost")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.State).To(Equal(v1.Status_Accepted))
+		Expect(updated.Status.EvaluationTime.Equal(first)).To(BeTrue())
+
+		Expect(rptr.WriteReports([]ConfigObjectReport{{
+			CfgObject:      vhost,
+			Warnings:       []string{"new warning"},
+			EvaluationTime: first.Add(time.Minute),
+		}})).To(Succeed())
+		updated, err = store.V1().VirtualHosts().Get("my-vhost")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.Warnings).To(Equal([]string{"new warning"}))
+		Expect(updated.Status.EvaluationTime.Equal(first.Add(time.Minute))).To(BeTrue())
+	})
+	It("writes the status next to the virtual host without touching its spec", func() {
+		specFile := filepath.Join(dir, "virtualhosts", "my-vhost.yml")
+		before, err := ioutil.ReadFile(specFile)
+		Must(err)
+		Expect(rptr.WriteReports([]ConfigObjectReport{{CfgObject: vhost, Warnings: []string{"warning"}}})).To(Succeed())
+
+		after, err := ioutil.ReadFile(specFile)
+		Must(err)
+		Expect(after).To(Equal(before))
+		_, err = os.Stat(specFile + ".status")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("writes all the reports when some of them fail", func() {
+		var reports []ConfigObjectReport
+		for i := 0; i < 25; i++ {
+			us, err := store.V1().Upstreams().Create(&v1.Upstream{Name: fmt.Sprintf("upstream-%v", i), Type: "test"})
+			Must(err)
+			reports = append(reports, ConfigObjectReport{CfgObject: us})
+		}
+		reports = append(reports, ConfigObjectReport{CfgObject: &v1.Upstream{Name: "does-not-exist"}})
+
+		err := rptr.WriteReports(reports)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does-not-exist"))
+		for i := 0; i < 25; i++ {
+			us, err := store.V1().Upstreams().Get(fmt.Sprintf("upstream-%v", i))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(us.Status).NotTo(BeNil())
+			Expect(us.Status.State).To(Equal(v1.Status_Accepted))
+		}
+	})
+	It("retries updates that conflict with another write", func() {
+		conflicting := &conflictingStorage{Interface: store, conflicts: 2}
+		rptr = NewReporter(conflicting)
+		Expect(rptr.WriteReports([]ConfigObjectReport{{CfgObject: vhost}})).To(Succeed())
+		Expect(conflicting.conflicts).To(Equal(0))
+
+		updated, err := store.V1().VirtualHosts().Get("my-vhost")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.State).To(Equal(v1.Status_Accepted))
+	})
+})
+
+// conflictingStorage hides the status-only write path of the storage it wraps,
+// and fails the first updates to virtual hosts with a conflict
+type conflictingStorage struct {
+	storage.Interface
+	conflicts int
+}
+
+func (s *conflictingStorage) V1() storage.V1 {
+	return conflictingV1{V1: s.Interface.V1(), storage: s}
+}
+
+type conflictingV1 struct {
+	storage.V1
+	storage *conflictingStorage
+}
+
+func (v conflictingV1) VirtualHosts() storage.VirtualHosts {
+	return conflictingVirtualHosts{VirtualHosts: v.V1.VirtualHosts(), storage: v.storage}
+}
+
+type conflictingVirtualHosts struct {
+	storage.VirtualHosts
+	storage *conflictingStorage
+}
+
+func (c conflictingVirtualHosts) Update(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	if c.storage.conflicts > 0 {
+		c.storage.conflicts--
+		return nil, storage.NewConflictErr(errors.Errorf("resource version outdated for %v", item.Name))
+	}
+	return c.VirtualHosts.Update(item)
+}
@@ -0,0 +1,212 @@
+package translator
+
+import (
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	"github.com/mitchellh/hashstructure"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+)
+
+// translationCache holds the output of the previous translation for each upstream and virtual host,
+// keyed by a hash of all the inputs that went into computing it.
+// entries that are not used during a translation are dropped at the end of it
+type translationCache struct {
+	loadAssignments map[string]cachedLoadAssignment
+	clusters        map[string]cachedCluster
+	virtualHosts    map[string]cachedVirtualHost
+
+	// entries used by the current translation
+	nextLoadAssignments map[string]cachedLoadAssignment
+	nextClusters        map[string]cachedCluster
+	nextVirtualHosts    map[string]cachedVirtualHost
+}
+
+type cachedLoadAssignment struct {
+	hash           uint64
+	loadAssignment *envoyapi.ClusterLoadAssignment
+}
+
+// the contributions plugins made to the context while computing a cluster or virtual host
+// are kept with it, so the filters they lead to are still added when it is reused
+type cachedCluster struct {
+	hash          uint64
+	cluster       *envoyapi.Cluster
+	err           error
+	contributions []plugins.Contribution
+}
+
+type cachedVirtualHost struct {
+	hash          uint64
+	virtualHost   envoyroute.VirtualHost
+	problems      virtualHostProblems
+	contributions []plugins.Contribution
+}
+
+func newTranslationCache() *translationCache {
+	c := &translationCache{
+		loadAssignments: make(map[string]cachedLoadAssignment),
+		clusters:        make(map[string]cachedCluster),
+		virtualHosts:    make(map[string]cachedVirtualHost),
+	}
+	c.begin()
+	return c
+}
+
+// start a new translation
+func (c *translationCache) begin() {
+	c.nextLoadAssignments = make(map[string]cachedLoadAssignment)
+	c.nextClusters = make(map[string]cachedCluster)
+	c.nextVirtualHosts = make(map[string]cachedVirtualHost)
+}
+
+// finish the current translation, evicting any entries that were not used by it
+func (c *translationCache) commit() {
+	c.loadAssignments = c.nextLoadAssignments
+	c.clusters = c.nextClusters
+	c.virtualHosts = c.nextVirtualHosts
+	c.begin()
+}
+
+func (c *translationCache) loadAssignment(name string, hash uint64) (*envoyapi.ClusterLoadAssignment, bool) {
+	cached, ok := c.loadAssignments[name]
+	if !ok || cached.hash != hash {
+		return nil, false
+	}
+	c.nextLoadAssignments[name] = cached
+	return cached.loadAssignment, true
+}
+
+func (c *translationCache) setLoadAssignment(name string, hash uint64, loadAssignment *envoyapi.ClusterLoadAssignment) {
+	c.nextLoadAssignments[name] = cachedLoadAssignment{hash: hash, loadAssignment: loadAssignment}
+}
+
+func (c *translationCache) cluster(name string, hash uint64) (cachedCluster, bool) {
+	cached, ok := c.clusters[name]
+	if !ok || cached.hash != hash {
+		return cachedCluster{}, false
+	}
+	c.nextClusters[name] = cached
+	return cached, true
+}
+
+func (c *translationCache) setCluster(name string, cached cachedCluster) {
+	c.nextClusters[name] = cached
+}
+
+func (c *translationCache) virtualHost(name string, hash uint64) (cachedVirtualHost, bool) {
+	cached, ok := c.virtualHosts[name]
+	if !ok || cached.hash != hash {
+		return cachedVirtualHost{}, false
+	}
+	c.nextVirtualHosts[name] = cached
+	return cached, true
+}
+
+func (c *translationCache) setVirtualHost(name string, cached cachedVirtualHost) {
+	c.nextVirtualHosts[name] = cached
+}
+
+// cacheKeys are the hashes of the inputs for each upstream and virtual host in a translation
+type cacheKeys struct {
+	loadAssignments map[string]uint64
+	clusters        map[string]uint64
+	virtualHosts    map[string]uint64
+}
+
+// hashInputs hashes everything that goes into the translation of a single resource
+// if the inputs cannot be hashed, the resource will not be cached
+func hashInputs(inputs ...interface{}) (uint64, bool) {
+	hash, err := hashstructure.Hash(inputs, nil)
+	if err != nil {
+		log.Warnf("failed to hash translation inputs, skipping cache: %v", err)
+		return 0, false
+	}
+	return hash, true
+}
+
+// status and resource version are written by gloo itself,
+// so they are excluded when computing cache keys
+func upstreamForHash(upstream *v1.Upstream) v1.Upstream {
+	us := *upstream
+	us.Status = nil
+	us.Metadata = metadataForHash(us.Metadata)
+	return us
+}
+
+func virtualHostForHash(virtualHost *v1.VirtualHost) v1.VirtualHost {
+	vh := *virtualHost
+	vh.Status = nil
+	vh.Metadata = metadataForHash(vh.Metadata)
+	return vh
+}
+
+func metadataForHash(metadata *v1.Metadata) *v1.Metadata {
+	if metadata == nil {
+		return nil
+	}
+	md := *metadata
+	md.ResourceVersion = ""
+	return &md
+}
+
+func (t *Translator) computeCacheKeys(cfg *v1.Config,
+	pluginDeps []*pluginDependencies,
+	secrets secretwatcher.SecretMap,
+	endpoints endpointdiscovery.EndpointGroups) cacheKeys {
+	keys := cacheKeys{
+		loadAssignments: make(map[string]uint64),
+		clusters:        make(map[string]uint64),
+		virtualHosts:    make(map[string]uint64),
+	}
+	add := func(m map[string]uint64, name string, inputs ...interface{}) {
+		if hash, ok := hashInputs(inputs...); ok {
+			m[name] = hash
+		}
+	}
+
+	// every upstream receives the same dependencies from each plugin
+	depsHash, depsHashed := hashInputs(pluginDeps)
+
+	upstreamNames := make(map[string]bool)
+	for _, upstream := range cfg.Upstreams {
+		upstreamNames[upstream.Name] = true
+		endpointGroup, edsCluster := endpoints[upstream.Name]
+		if edsCluster {
+			add(keys.loadAssignments, upstream.Name, endpointGroup)
+		}
+		if depsHashed {
+			add(keys.clusters, upstream.Name, upstreamForHash(upstream), edsCluster, depsHash)
+		}
+	}
+
+	// a virtual host depends on the upstreams its routes refer to: route plugins receive them,
+	// and routes to errored or missing upstreams are invalid
+	for _, virtualHost := range cfg.VirtualHosts {
+		referenced := make(map[string]uint64)
+		hashed := true
+		for _, name := range referencedUpstreamNames(virtualHost) {
+			hash, ok := keys.clusters[name]
+			if !ok && upstreamNames[name] {
+				// the upstream could not be hashed, so neither can the virtual host
+				hashed = false
+				break
+			}
+			// missing upstreams are keyed as 0, so the virtual host is recomputed once they are added
+			referenced[name] = hash
+		}
+		if !hashed {
+			continue
+		}
+		var sslSecret interface{}
+		if virtualHost.SslConfig != nil {
+			sslSecret = secrets[virtualHost.SslConfig.SecretRef]
+		}
+		add(keys.virtualHosts, virtualHost.Name, virtualHostForHash(virtualHost), referenced, sslSecret)
+	}
+	return keys
+}
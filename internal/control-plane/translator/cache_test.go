@@ -0,0 +1,243 @@
+package translator
+
+import (
+	"time"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	envoyutil "github.com/envoyproxy/go-control-plane/pkg/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/internal/control-plane/filewatcher"
+	_ "github.com/solo-io/gloo/internal/control-plane/install"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/coreplugins/route-extensions"
+	"github.com/solo-io/gloo/pkg/coreplugins/service"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/plugins/aws"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+type countingPlugin struct {
+	fileRef   string
+	processed map[string]int
+	routed    map[string]int
+}
+
+func (p *countingPlugin) GetDependencies(_ *v1.Config) *plugins.Dependencies {
+	return &plugins.Dependencies{FileRefs: []string{p.fileRef}}
+}
+
+func (p *countingPlugin) ProcessUpstream(_ *plugins.UpstreamPluginParams, in *v1.Upstream, _ *envoyapi.Cluster) error {
+	p.processed[in.Name]++
+	return nil
+}
+
+func (p *countingPlugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, _ *envoyroute.Route) error {
+	if upstream := in.GetSingleDestination().GetUpstream(); upstream != nil {
+		p.routed[upstream.Name]++
+	}
+	return nil
+}
+
+// httpFilterNames returns the names of the http filters of the http listener in the snapshot
+func httpFilterNames(snap *envoycache.Snapshot) []string {
+	_, _, _, listeners := getSnapshotResources(snap)
+	var names []string
+	for _, listener := range listeners {
+		for _, chain := range listener.FilterChains {
+			for _, filter := range chain.Filters {
+				if filter.Name != connMgrFilter {
+					continue
+				}
+				var hcm envoyhttp.HttpConnectionManager
+				Expect(envoyutil.StructToMessage(filter.Config, &hcm)).NotTo(HaveOccurred())
+				for _, httpFilter := range hcm.HttpFilters {
+					names = append(names, httpFilter.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+var _ = Describe("Translation cache", func() {
+	var (
+		t       *Translator
+		counter *countingPlugin
+		cfg     *v1.Config
+		files   filewatcher.Files
+	)
+	BeforeEach(func() {
+		counter = &countingPlugin{fileRef: "some-file", processed: make(map[string]int), routed: make(map[string]int)}
+		t = NewTranslator(TranslatorConfig{"::"}, []plugins.TranslatorPlugin{counter})
+		cfg = ValidConfigNoSsl()
+		cfg.Upstreams = append(cfg.Upstreams, &v1.Upstream{
+			Name: "other-service",
+			Type: service.UpstreamTypeService,
+			Spec: service.EncodeUpstreamSpec(service.UpstreamSpec{
+				Hosts: []service.Host{{Addr: "localhost", Port: 5678}},
+			}),
+		})
+		files = filewatcher.Files{
+			"some-file": &dependencies.File{Ref: "some-file", Contents: []byte("foo")},
+		}
+	})
+	It("reuses every resource when the inputs have not changed", func() {
+		snap1, reports1, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		snap2, reports2, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.processed).To(Equal(map[string]int{"valid-service": 1, "other-service": 1}))
+		Expect(snap2).To(Equal(snap1))
+		Expect(reports2).To(Equal(reports1))
+	})
+	It("does not recompute clusters or virtual hosts when only endpoints change", func() {
+		endpoints := endpointdiscovery.EndpointGroups{
+			"valid-service": {{Address: "10.0.0.1", Port: 1234}},
+		}
+		snap1, _, err := t.Translate(Inputs{Cfg: cfg, Files: files, Endpoints: endpoints})
+		Expect(err).NotTo(HaveOccurred())
+		endpoints = endpointdiscovery.EndpointGroups{
+			"valid-service": {{Address: "10.0.0.2", Port: 1234}},
+		}
+		snap2, _, err := t.Translate(Inputs{Cfg: cfg, Files: files, Endpoints: endpoints})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.processed).To(Equal(map[string]int{"valid-service": 1, "other-service": 1}))
+		clas1, clusters1, routes1, _ := getSnapshotResources(snap1)
+		clas2, clusters2, routes2, _ := getSnapshotResources(snap2)
+		Expect(clusters2).To(Equal(clusters1))
+		Expect(routes2).To(Equal(routes1))
+		Expect(clas2).To(HaveLen(1))
+		Expect(clas2).NotTo(Equal(clas1))
+		Expect(clas2[0].Endpoints[0].LbEndpoints[0].Endpoint.Address.GetSocketAddress().Address).To(Equal("10.0.0.2"))
+	})
+	It("ignores status and resource version changes", func() {
+		_, _, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Upstreams[0].Status = &v1.Status{State: v1.Status_Accepted}
+		cfg.Upstreams[0].Metadata = &v1.Metadata{ResourceVersion: "2"}
+		_, _, err = t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.processed).To(Equal(map[string]int{"valid-service": 1, "other-service": 1}))
+	})
+	It("recomputes clusters when a plugin dependency changes", func() {
+		_, _, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		files["some-file"] = &dependencies.File{Ref: "some-file", Contents: []byte("bar")}
+		_, _, err = t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.processed).To(Equal(map[string]int{"valid-service": 2, "other-service": 2}))
+	})
+	It("ignores changes to files no plugin depends on", func() {
+		_, _, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		files["unrelated-file"] = &dependencies.File{Ref: "unrelated-file", Contents: []byte("bar")}
+		_, _, err = t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.processed).To(Equal(map[string]int{"valid-service": 1, "other-service": 1}))
+	})
+	It("only recomputes the upstream that changed", func() {
+		snap1, _, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Upstreams[1].ConnectionTimeout = time.Minute
+		snap2, _, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.processed).To(Equal(map[string]int{"valid-service": 1, "other-service": 2}))
+		_, clusters1, _, _ := getSnapshotResources(snap1)
+		_, clusters2, _, _ := getSnapshotResources(snap2)
+		Expect(clusters2[0]).To(BeIdenticalTo(clusters1[0]))
+		Expect(clusters2[1].ConnectTimeout).To(Equal(time.Minute))
+	})
+	It("only recomputes the virtual hosts that use the upstream that changed", func() {
+		cfg.VirtualHosts = append(cfg.VirtualHosts, &v1.VirtualHost{
+			Name:    "other-vhost",
+			Domains: []string{"other.example.com"},
+			Routes: []*v1.Route{{
+				Matcher: &v1.Route_RequestMatcher{
+					RequestMatcher: &v1.RequestMatcher{Path: &v1.RequestMatcher_PathPrefix{PathPrefix: "/"}},
+				},
+				SingleDestination: &v1.Destination{
+					DestinationType: &v1.Destination_Upstream{Upstream: &v1.UpstreamDestination{Name: "other-service"}},
+				},
+			}},
+		})
+		_, _, err := t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Upstreams[1].ConnectionTimeout = time.Minute
+		_, _, err = t.Translate(Inputs{Cfg: cfg, Files: files})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.routed).To(Equal(map[string]int{"valid-service": 1, "other-service": 2}))
+	})
+	Context("with the default plugins", func() {
+		var secrets secretwatcher.SecretMap
+		BeforeEach(func() {
+			t = NewTranslator(TranslatorConfig{"::"}, append(append([]plugins.TranslatorPlugin{}, plugins.RegisteredPlugins()...), counter))
+			cfg.VirtualHosts[0].Routes[0].Extensions = extensions.EncodeRouteExtensionSpec(extensions.RouteExtensionSpec{
+				Cors: &extensions.CorsPolicy{AllowOrigin: []string{"*"}},
+			})
+			cfg.Upstreams = append(cfg.Upstreams, &v1.Upstream{
+				Name: "lambda",
+				Type: aws.UpstreamTypeAws,
+				Spec: aws.EncodeUpstreamSpec(aws.UpstreamSpec{Region: "us-east-1", SecretRef: "aws-secret"}),
+				Functions: []*v1.Function{{
+					Name: "fn",
+					Spec: aws.EncodeFunctionSpec(aws.FunctionSpec{FunctionName: "fn", Qualifier: "v1"}),
+				}},
+			})
+			cfg.VirtualHosts = append(cfg.VirtualHosts, &v1.VirtualHost{
+				Name:    "lambda-vhost",
+				Domains: []string{"lambda.example.com"},
+				Routes: []*v1.Route{{
+					Matcher: &v1.Route_RequestMatcher{
+						RequestMatcher: &v1.RequestMatcher{Path: &v1.RequestMatcher_PathPrefix{PathPrefix: "/"}},
+					},
+					SingleDestination: &v1.Destination{
+						DestinationType: &v1.Destination_Function{Function: &v1.FunctionDestination{
+							UpstreamName: "lambda",
+							FunctionName: "fn",
+						}},
+					},
+				}},
+			})
+			secrets = secretwatcher.SecretMap{
+				"aws-secret": &dependencies.Secret{Ref: "aws-secret", Data: map[string]string{
+					aws.AwsAccessKey: "access",
+					aws.AwsSecretKey: "secret",
+				}},
+			}
+		})
+		It("keeps the filters of resources that are reused", func() {
+			snap1, reports, err := t.Translate(Inputs{Cfg: cfg, Secrets: secrets, Files: files})
+			Expect(err).NotTo(HaveOccurred())
+			for _, report := range reports {
+				Expect(report.Err).NotTo(HaveOccurred())
+			}
+			Expect(httpFilterNames(snap1)).To(ContainElement("io.solo.lambda"))
+			Expect(httpFilterNames(snap1)).To(ContainElement("envoy.cors"))
+
+			cfg.Upstreams[1].ConnectionTimeout = time.Minute
+			snap2, _, err := t.Translate(Inputs{Cfg: cfg, Secrets: secrets, Files: files})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counter.processed).To(Equal(map[string]int{"valid-service": 1, "other-service": 2, "lambda": 1}))
+			Expect(httpFilterNames(snap2)).To(Equal(httpFilterNames(snap1)))
+		})
+		It("drops the filters of resources that are removed", func() {
+			snap1, _, err := t.Translate(Inputs{Cfg: cfg, Secrets: secrets, Files: files})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(httpFilterNames(snap1)).To(ContainElement("io.solo.lambda"))
+
+			cfg.Upstreams = cfg.Upstreams[:2]
+			cfg.VirtualHosts = cfg.VirtualHosts[:1]
+			snap2, _, err := t.Translate(Inputs{Cfg: cfg, Secrets: secrets, Files: files})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(httpFilterNames(snap2)).NotTo(ContainElement("io.solo.lambda"))
+			Expect(httpFilterNames(snap2)).To(ContainElement("envoy.cors"))
+		})
+	})
+})
@@ -0,0 +1,73 @@
+package translator
+
+import (
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/pkg/plugins"
+)
+
+func filterNames(filters []*envoyhttp.HttpFilter) []string {
+	var names []string
+	for _, f := range filters {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func staged(name string, stage plugins.Stage, before, after []string) stagedFilter {
+	return stagedFilter{
+		filter: &envoyhttp.HttpFilter{Name: name},
+		stage:  stage,
+		before: before,
+		after:  after,
+	}
+}
+
+var _ = Describe("sortFilters", func() {
+	It("orders filters by stage, then by name", func() {
+		sorted, err := sortFilters([]stagedFilter{
+			staged("c", plugins.OutAuth, nil, nil),
+			staged("b", plugins.PreInAuth, nil, nil),
+			staged("a", plugins.OutAuth, nil, nil),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filterNames(sorted)).To(Equal([]string{"b", "a", "c"}))
+	})
+	It("moves a filter after the filters it names", func() {
+		sorted, err := sortFilters([]stagedFilter{
+			staged("a", plugins.PreInAuth, nil, []string{"c"}),
+			staged("b", plugins.PreInAuth, nil, nil),
+			staged("c", plugins.OutAuth, nil, nil),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filterNames(sorted)).To(Equal([]string{"b", "c", "a"}))
+	})
+	It("moves a filter before the filters it names", func() {
+		sorted, err := sortFilters([]stagedFilter{
+			staged("a", plugins.PreInAuth, nil, nil),
+			staged("b", plugins.PreInAuth, nil, nil),
+			staged("c", plugins.OutAuth, []string{"a"}, nil),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filterNames(sorted)).To(Equal([]string{"b", "c", "a"}))
+	})
+	It("ignores constraints on filters that are not present", func() {
+		sorted, err := sortFilters([]stagedFilter{
+			staged("b", plugins.PreInAuth, []string{"missing"}, nil),
+			staged("a", plugins.PreInAuth, nil, []string{"missing"}),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filterNames(sorted)).To(Equal([]string{"a", "b"}))
+	})
+	It("returns an error when the constraints form a cycle", func() {
+		_, err := sortFilters([]stagedFilter{
+			staged("a", plugins.PreInAuth, nil, []string{"b"}),
+			staged("b", plugins.PreInAuth, nil, []string{"a"}),
+			staged("c", plugins.PreInAuth, nil, nil),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("[a b]"))
+	})
+})
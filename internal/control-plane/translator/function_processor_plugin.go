@@ -24,7 +24,7 @@ func (p *functionalPluginProcessor) GetDependencies(_ *v1.Config) *plugins.Depen
 
 func (p *functionalPluginProcessor) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Upstream, out *envoyapi.Cluster) error {
 	for _, function := range in.Functions {
-		envoyFunctionSpec, err := p.getFunctionSpec(in, function.Spec)
+		envoyFunctionSpec, err := p.getFunctionSpec(params.Context, in, function.Spec)
 		if err != nil {
 			return errors.Wrapf(err, "processing function %v/%v failed", in.Name, function.Name)
 		}
@@ -38,13 +38,15 @@ func (p *functionalPluginProcessor) ProcessUpstream(params *plugins.UpstreamPlug
 	return nil
 }
 
-func (p *functionalPluginProcessor) getFunctionSpec(upstream *v1.Upstream, spec v1.FunctionSpec) (*types.Struct, error) {
+func (p *functionalPluginProcessor) getFunctionSpec(ctx *plugins.Context, upstream *v1.Upstream, spec v1.FunctionSpec) (*types.Struct, error) {
 	for _, functionPlugin := range p.functionPlugins {
 		var serviceType string
 		if upstream.ServiceInfo != nil {
 			serviceType = upstream.ServiceInfo.Type
 		}
 		params := &plugins.FunctionPluginParams{
+			Context:      ctx,
+			UpstreamName: upstream.Name,
 			UpstreamType: upstream.Type,
 			ServiceType:  serviceType,
 		}
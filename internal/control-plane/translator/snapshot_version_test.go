@@ -0,0 +1,95 @@
+package translator
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/solo-io/gloo/internal/control-plane/xds"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
+	"github.com/solo-io/gloo/test/utilities/xdsclient"
+)
+
+var _ = Describe("Snapshot versions", func() {
+	var (
+		srv           *grpc.Server
+		snapshotCache envoycache.SnapshotCache
+		client        *xdsclient.Client
+	)
+	BeforeEach(func() {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		snapshotCache, srv = xds.ServeXDS(lis)
+		client, err = xdsclient.NewClient(fmt.Sprintf("localhost:%v", lis.Addr().(*net.TCPAddr).Port))
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		client.Close()
+		srv.Stop()
+	})
+
+	type versions struct {
+		endpoints, clusters, routes, listeners string
+	}
+	fetchVersions := func() versions {
+		var (
+			v   versions
+			err error
+		)
+		ctx := context.Background()
+		v.endpoints, _, err = client.Endpoints(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		v.clusters, _, err = client.Clusters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		v.routes, _, err = client.Routes(ctx, []string{nosslRdsName})
+		Expect(err).NotTo(HaveOccurred())
+		v.listeners, _, err = client.Listeners(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return v
+	}
+	translateAndServe := func(t *Translator, inputs Inputs) {
+		snap, _, err := t.Translate(inputs)
+		Expect(err).NotTo(HaveOccurred())
+		err = snapshotCache.SetSnapshot(xds.NodeKey, *snap)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("only changes the endpoints version when only endpoints change", func() {
+		t := newTranslator()
+		cfg := ValidConfigNoSsl()
+		translateAndServe(t, Inputs{Cfg: cfg, Endpoints: endpointdiscovery.EndpointGroups{
+			"valid-service": {{Address: "10.0.0.1", Port: 1234}},
+		}})
+		before := fetchVersions()
+
+		translateAndServe(t, Inputs{Cfg: cfg, Endpoints: endpointdiscovery.EndpointGroups{
+			"valid-service": {{Address: "10.0.0.2", Port: 1234}},
+		}})
+		after := fetchVersions()
+
+		Expect(after.endpoints).NotTo(Equal(before.endpoints))
+		Expect(after.clusters).To(Equal(before.clusters))
+		Expect(after.routes).To(Equal(before.routes))
+		Expect(after.listeners).To(Equal(before.listeners))
+	})
+	It("does not change the listener version when only routes change", func() {
+		t := newTranslator()
+		cfg := ValidConfigNoSsl()
+		translateAndServe(t, Inputs{Cfg: cfg})
+		before := fetchVersions()
+
+		cfg.VirtualHosts[0].Domains = []string{"foo.example.com"}
+		translateAndServe(t, Inputs{Cfg: cfg})
+		after := fetchVersions()
+
+		Expect(after.routes).NotTo(Equal(before.routes))
+		Expect(after.endpoints).To(Equal(before.endpoints))
+		Expect(after.clusters).To(Equal(before.clusters))
+		Expect(after.listeners).To(Equal(before.listeners))
+	})
+})
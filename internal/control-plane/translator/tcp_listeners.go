@@ -0,0 +1,215 @@
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoycore "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoylistener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
+	envoyutil "github.com/envoyproxy/go-control-plane/pkg/util"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/internal/control-plane/reporter"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+)
+
+const (
+	tcpListenerPrefix  = "listener-tcp-"
+	tcpProxyFilter     = "envoy.tcp_proxy"
+	tlsInspectorFilter = "envoy.listener.tls_inspector"
+)
+
+// virtual hosts that only define tcp routes are not served by the http listeners
+func isTcpOnly(virtualHost *v1.VirtualHost) bool {
+	return len(virtualHost.Routes) == 0 && len(virtualHost.TcpRoutes) > 0
+}
+
+func validateTcpRoute(destinations destinationIndex, route *v1.TcpRoute) error {
+	if route.Port == 0 {
+		return errors.New("tcp route must specify a port")
+	}
+	if route.Port == nosslListenerPort || route.Port == sslListenerPort {
+		return errors.Errorf("tcp route port %v conflicts with http listener port", route.Port)
+	}
+	switch {
+	case route.SingleDestination != nil && len(route.MultipleDestinations) == 0:
+		return validateTcpDestination(destinations, route.SingleDestination)
+	case route.SingleDestination == nil && len(route.MultipleDestinations) > 0:
+		for _, dest := range route.MultipleDestinations {
+			if err := validateTcpDestination(destinations, dest.Destination); err != nil {
+				return errors.Wrap(err, "invalid destination in weighted destination list")
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("must specify either 'single_destination' or 'multiple_destinations' for tcp route")
+}
+
+func validateTcpDestination(destinations destinationIndex, destination *v1.Destination) error {
+	dest, ok := destination.DestinationType.(*v1.Destination_Upstream)
+	if !ok {
+		return errors.New("tcp routes only support upstream destinations")
+	}
+	return validateUpstreamDestination(destinations, dest)
+}
+
+// sniDomains returns the sni domains the route matches, normalized so that domains matching the same
+// server names compare equal. a route that matches every server name has none
+func sniDomains(route *v1.TcpRoute) []string {
+	var domains []string
+	seen := make(map[string]bool)
+	for _, domain := range route.SniDomains {
+		// server names are case insensitive and may be given fully qualified
+		domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+		if domain == "" || domain == "*" {
+			return nil
+		}
+		if !seen[domain] {
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// adds errors to report if two tcp routes match the same port and sni domain
+func virtualHostsWithConflictingTcpRoutes(virtualHosts []*v1.VirtualHost) map[string]error {
+	type portAndDomain struct {
+		port   uint32
+		domain string
+	}
+	matchesToVirtualHosts := make(map[portAndDomain][]string)
+	for _, vhost := range virtualHosts {
+		for _, route := range vhost.TcpRoutes {
+			domains := sniDomains(route)
+			if len(domains) == 0 {
+				// match-all route for the port
+				domains = []string{"*"}
+			}
+			for _, domain := range domains {
+				match := portAndDomain{port: route.Port, domain: domain}
+				matchesToVirtualHosts[match] = append(matchesToVirtualHosts[match], vhost.Name)
+			}
+		}
+	}
+	erroredVHosts := make(map[string]error)
+	for match, vHosts := range matchesToVirtualHosts {
+		if len(vHosts) > 1 {
+			for _, name := range vHosts {
+				erroredVHosts[name] = multierror.Append(erroredVHosts[name], errors.Errorf("tcp port %v with sni domain %v is "+
+					"shared by the following tcp routes on virtual hosts: %v", match.port, match.domain, vHosts))
+			}
+		}
+	}
+	return erroredVHosts
+}
+
+// creates one listener per port used by the tcp routes of valid virtual hosts
+func (t *Translator) constructTcpListeners(virtualHostReports []reporter.ConfigObjectReport,
+	secrets secretwatcher.SecretMap) ([]*envoyapi.Listener, error) {
+	listenersByPort := make(map[uint32]*envoyapi.Listener)
+	for _, report := range virtualHostReports {
+		vhost, ok := report.CfgObject.(*v1.VirtualHost)
+		if !ok || report.Err != nil {
+			continue
+		}
+		for _, route := range vhost.TcpRoutes {
+			filterChain, err := newTcpFilterChain(vhost, route, secrets)
+			if err != nil {
+				return nil, errors.Wrapf(err, "constructing tcp filter chain for virtual host %v", vhost.Name)
+			}
+			listener, ok := listenersByPort[route.Port]
+			if !ok {
+				listener = t.constructTcpListener(route.Port)
+				listenersByPort[route.Port] = listener
+			}
+			if filterChain.FilterChainMatch != nil && len(listener.ListenerFilters) == 0 {
+				// sni matching requires envoy to inspect the tls client hello
+				listener.ListenerFilters = []envoylistener.ListenerFilter{{Name: tlsInspectorFilter}}
+			}
+			listener.FilterChains = append(listener.FilterChains, filterChain)
+		}
+	}
+
+	var listeners []*envoyapi.Listener
+	for _, listener := range listenersByPort {
+		listeners = append(listeners, listener)
+	}
+	// sort for a stable snapshot version
+	sort.SliceStable(listeners, func(i, j int) bool {
+		return listeners[i].Name < listeners[j].Name
+	})
+	return listeners, nil
+}
+
+func (t *Translator) constructTcpListener(port uint32) *envoyapi.Listener {
+	return &envoyapi.Listener{
+		Name: fmt.Sprintf("%v%v", tcpListenerPrefix, port),
+		Address: envoycore.Address{
+			Address: &envoycore.Address_SocketAddress{
+				SocketAddress: &envoycore.SocketAddress{
+					Protocol: envoycore.TCP,
+					Address:  t.config.IngressBindAddress,
+					PortSpecifier: &envoycore.SocketAddress_PortValue{
+						PortValue: port,
+					},
+					Ipv4Compat: true,
+				},
+			},
+		},
+	}
+}
+
+func newTcpFilterChain(vhost *v1.VirtualHost, route *v1.TcpRoute, secrets secretwatcher.SecretMap) (envoylistener.FilterChain, error) {
+	tcpProxy := &envoytcp.TcpProxy{
+		StatPrefix: virtualHostName(vhost.Name),
+	}
+	if route.SingleDestination != nil {
+		tcpProxy.ClusterSpecifier = &envoytcp.TcpProxy_Cluster{
+			Cluster: clusterName(route.SingleDestination.GetUpstream().Name),
+		}
+	} else {
+		weightedClusters := &envoytcp.TcpProxy_WeightedCluster{}
+		for _, dest := range route.MultipleDestinations {
+			weightedClusters.Clusters = append(weightedClusters.Clusters, &envoytcp.TcpProxy_WeightedCluster_ClusterWeight{
+				Name:   clusterName(dest.GetUpstream().Name),
+				Weight: dest.Weight,
+			})
+		}
+		tcpProxy.ClusterSpecifier = &envoytcp.TcpProxy_WeightedClusters{
+			WeightedClusters: weightedClusters,
+		}
+	}
+
+	tcpProxyCfg, err := envoyutil.MessageToStruct(tcpProxy)
+	if err != nil {
+		return envoylistener.FilterChain{}, errors.Wrap(err, "failed to convert proto message to struct")
+	}
+	filters := []envoylistener.Filter{
+		{
+			Name:   tcpProxyFilter,
+			Config: tcpProxyCfg,
+		},
+	}
+
+	// terminate tls if the virtual host specifies an ssl config, otherwise pass connections through
+	filterChain := envoylistener.FilterChain{Filters: filters}
+	if vhost.SslConfig != nil && vhost.SslConfig.SecretRef != "" {
+		certChain, privateKey, err := getSslSecrets(vhost.SslConfig.SecretRef, secrets)
+		if err != nil {
+			return envoylistener.FilterChain{}, err
+		}
+		filterChain = newSslFilterChain(certChain, privateKey, filters)
+	}
+	if domains := sniDomains(route); len(domains) > 0 {
+		filterChain.FilterChainMatch = &envoylistener.FilterChainMatch{
+			ServerNames: domains,
+		}
+	}
+	return filterChain, nil
+}
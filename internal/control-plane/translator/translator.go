@@ -3,6 +3,8 @@ package translator
 import (
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
 	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoyauth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
@@ -51,6 +53,9 @@ type TranslatorConfig struct {
 type Translator struct {
 	plugins []plugins.TranslatorPlugin
 	config  TranslatorConfig
+
+	cacheLock sync.Mutex
+	cache     *translationCache
 }
 
 // all built-in plugins should go here
@@ -95,6 +100,7 @@ func NewTranslator(cfg TranslatorConfig, translatorPlugins []plugins.TranslatorP
 	return &Translator{
 		plugins: translatorPlugins,
 		config:  addDefaults(cfg),
+		cache:   newTranslationCache(),
 	}
 }
 
@@ -117,17 +123,36 @@ func (t *Translator) Translate(inputs Inputs) (*envoycache.Snapshot, []reporter.
 	endpoints := inputs.Endpoints
 
 	log.Printf("Translation loop starting")
+
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	// drop whatever a failed translation left behind
+	t.cache.begin()
+
+	// every phase of this translation shares the context
+	ctx := plugins.NewContext(cfg)
+	if err := t.initPlugins(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	// resolve the dependencies for each plugin once, rather than once per upstream
+	pluginDeps := t.computePluginDependencies(cfg, dependencies)
+
+	// only resources whose inputs have changed since the last translation are recomputed.
+	// reused resources replay what plugins contributed to the context when they were computed
+	keys := t.computeCacheKeys(cfg, pluginDeps, secrets, endpoints)
+
 	// endpoints
-	clusterLoadAssignments := computeClusterEndpoints(cfg.Upstreams, endpoints)
+	clusterLoadAssignments := t.computeClusterEndpoints(cfg.Upstreams, endpoints, keys)
 
 	// clusters
-	clusters, upstreamReports := t.computeClusters(cfg, dependencies, endpoints)
+	clusters, upstreamReports := t.computeClusters(ctx, cfg.Upstreams, pluginDeps, endpoints, keys)
 
 	// mark errored upstreams; routes that point to them are considered invalid
 	errored := getErroredUpstreams(upstreamReports)
 
 	// virtualhosts
-	sslVirtualHosts, nosslVirtualHosts, virtualHostReports := t.computeVirtualHosts(cfg, errored, secrets)
+	sslVirtualHosts, nosslVirtualHosts, virtualHostReports := t.computeVirtualHosts(ctx, cfg, errored, secrets, keys)
 
 	nosslRouteConfig := &envoyapi.RouteConfiguration{
 		Name:         nosslRdsName,
@@ -135,7 +160,10 @@ func (t *Translator) Translate(inputs Inputs) (*envoycache.Snapshot, []reporter.
 	}
 
 	// create the base http filters which both listeners will implement
-	httpFilters := t.createHttpFilters()
+	httpFilters, err := t.createHttpFilters(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing http filters")
+	}
 
 	// filters
 	// they are basically the same, but have different rds names
@@ -169,6 +197,12 @@ func (t *Translator) Translate(inputs Inputs) (*envoycache.Snapshot, []reporter.
 		return nil, nil, errors.Wrapf(err, "constructing https listener %v", sslListenerName)
 	}
 
+	// tcp listeners for tcp routes
+	tcpListeners, err := t.constructTcpListeners(virtualHostReports, secrets)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing tcp listeners")
+	}
+
 	// proto-ify everything
 	var endpointsProto []envoycache.Resource
 	for _, cla := range clusterLoadAssignments {
@@ -194,37 +228,89 @@ func (t *Translator) Translate(inputs Inputs) (*envoycache.Snapshot, []reporter.
 		routesProto = append(routesProto, sslRouteConfig)
 	}
 
-	// construct version
-	// TODO: investigate whether we need a more sophisticated versionining algorithm
-	version, err := hashstructure.Hash([][]envoycache.Resource{
-		endpointsProto,
-		clustersProto,
-		routesProto,
-		listenersProto,
-	}, nil)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "constructing version hash for envoy snapshot components")
+	for _, listener := range tcpListeners {
+		listenersProto = append(listenersProto, listener)
 	}
+
 	// construct snapshot
-	snapshot := envoycache.NewSnapshot(fmt.Sprintf("%v", version), endpointsProto, clustersProto, routesProto, listenersProto)
+	// each xds type is versioned separately, so that envoy only reloads the types that changed
+	// (e.g. an endpoint update should not cause clusters to re-warm or listeners to drain)
+	endpointResources, err := versionedResources(endpointsProto)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing version hash for endpoints")
+	}
+	clusterResources, err := versionedResources(clustersProto)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing version hash for clusters")
+	}
+	routeResources, err := versionedResources(routesProto)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing version hash for routes")
+	}
+	listenerResources, err := versionedResources(listenersProto)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing version hash for listeners")
+	}
+	snapshot := envoycache.Snapshot{
+		Endpoints: endpointResources,
+		Clusters:  clusterResources,
+		Routes:    routeResources,
+		Listeners: listenerResources,
+	}
+
+	t.cache.commit()
 
 	// aggregate reports
 	reports := append(upstreamReports, virtualHostReports...)
+	evaluationTime := time.Now()
+	for i := range reports {
+		reports[i].EvaluationTime = evaluationTime
+	}
 
 	return &snapshot, reports, nil
 }
 
+func (t *Translator) initPlugins(ctx *plugins.Context) error {
+	for _, plug := range t.plugins {
+		initPlugin, ok := plug.(plugins.InitPlugin)
+		if !ok {
+			continue
+		}
+		if err := initPlugin.Init(ctx); err != nil {
+			return errors.Wrap(err, "initializing plugin")
+		}
+	}
+	return nil
+}
+
+func versionedResources(items []envoycache.Resource) (envoycache.Resources, error) {
+	version, err := hashstructure.Hash(items, nil)
+	if err != nil {
+		return envoycache.Resources{}, err
+	}
+	return envoycache.NewResources(fmt.Sprintf("%v", version), items), nil
+}
+
 // Endpoints
 
-func computeClusterEndpoints(upstreams []*v1.Upstream, endpoints endpointdiscovery.EndpointGroups) []*envoyapi.ClusterLoadAssignment {
+func (t *Translator) computeClusterEndpoints(upstreams []*v1.Upstream, endpoints endpointdiscovery.EndpointGroups, keys cacheKeys) []*envoyapi.ClusterLoadAssignment {
 	var clusterEndpointAssignments []*envoyapi.ClusterLoadAssignment
 	for _, upstream := range upstreams {
 		// if there is an endpoint group for this upstream,
 		// it's using eds and we need to create a load assignment for it
-		if endpointGroup, ok := endpoints[upstream.Name]; ok {
-			loadAssignment := loadAssignmentForCluster(upstream.Name, endpointGroup)
-			clusterEndpointAssignments = append(clusterEndpointAssignments, loadAssignment)
+		endpointGroup, ok := endpoints[upstream.Name]
+		if !ok {
+			continue
+		}
+		hash, cacheable := keys.loadAssignments[upstream.Name]
+		loadAssignment, cached := t.cache.loadAssignment(upstream.Name, hash)
+		if !cacheable || !cached {
+			loadAssignment = loadAssignmentForCluster(upstream.Name, endpointGroup)
+			if cacheable {
+				t.cache.setLoadAssignment(upstream.Name, hash, loadAssignment)
+			}
 		}
+		clusterEndpointAssignments = append(clusterEndpointAssignments, loadAssignment)
 	}
 	return clusterEndpointAssignments
 }
@@ -260,24 +346,34 @@ func loadAssignmentForCluster(clusterName string, addresses []endpointdiscovery.
 
 // Clusters
 
-func (t *Translator) computeClusters(cfg *v1.Config, dependencies *pluginDependencies, endpoints endpointdiscovery.EndpointGroups) ([]*envoyapi.Cluster, []reporter.ConfigObjectReport) {
+func (t *Translator) computeClusters(ctx *plugins.Context, upstreams []*v1.Upstream, pluginDeps []*pluginDependencies, endpoints endpointdiscovery.EndpointGroups, keys cacheKeys) ([]*envoyapi.Cluster, []reporter.ConfigObjectReport) {
 	var (
 		reports  []reporter.ConfigObjectReport
 		clusters []*envoyapi.Cluster
 	)
-	for _, upstream := range cfg.Upstreams {
-		_, edsCluster := endpoints[upstream.Name]
-		cluster, err := t.computeCluster(cfg, dependencies, upstream, edsCluster)
+	for _, upstream := range upstreams {
+		hash, cacheable := keys.clusters[upstream.Name]
+		computed, cached := t.cache.cluster(upstream.Name, hash)
+		if !cacheable || !cached {
+			_, edsCluster := endpoints[upstream.Name]
+			resourceCtx := ctx.ForResource()
+			cluster, err := t.computeCluster(resourceCtx, pluginDeps, upstream, edsCluster)
+			computed = cachedCluster{hash: hash, cluster: cluster, err: err, contributions: resourceCtx.Recorded()}
+			if cacheable {
+				t.cache.setCluster(upstream.Name, computed)
+			}
+		}
 		// only append valid clusters
-		if err == nil {
-			clusters = append(clusters, cluster)
+		if computed.err == nil {
+			clusters = append(clusters, computed.cluster)
+			ctx.Replay(computed.contributions)
 		}
-		reports = append(reports, createReport(upstream, err))
+		reports = append(reports, createReport(upstream, computed.err))
 	}
 	return clusters, reports
 }
 
-func (t *Translator) computeCluster(cfg *v1.Config, dependencies *pluginDependencies, upstream *v1.Upstream, edsCluster bool) (*envoyapi.Cluster, error) {
+func (t *Translator) computeCluster(ctx *plugins.Context, pluginDeps []*pluginDependencies, upstream *v1.Upstream, edsCluster bool) (*envoyapi.Cluster, error) {
 	out := &envoyapi.Cluster{
 		Name:     upstream.Name,
 		Metadata: new(envoycore.Metadata),
@@ -293,15 +389,16 @@ func (t *Translator) computeCluster(cfg *v1.Config, dependencies *pluginDependen
 	out.ConnectTimeout = timeout
 
 	var upstreamErrors error
-	for _, plug := range t.plugins {
+	for i, plug := range t.plugins {
 		upstreamPlugin, ok := plug.(plugins.UpstreamPlugin)
 		if !ok {
 			continue
 		}
 		params := &plugins.UpstreamPluginParams{
+			Context:              ctx,
 			EnvoyNameForUpstream: clusterName,
 		}
-		deps := dependenciesForPlugin(cfg, upstreamPlugin, dependencies)
+		deps := pluginDeps[i]
 		if deps != nil {
 			params.Secrets = deps.Secrets
 			params.Files = deps.Files
@@ -327,6 +424,18 @@ func validateCluster(c *envoyapi.Cluster) error {
 	return nil
 }
 
+// returns the dependencies of each upstream plugin, indexed by the plugin's position in the plugin chain
+func (t *Translator) computePluginDependencies(cfg *v1.Config, dependencies *pluginDependencies) []*pluginDependencies {
+	pluginDeps := make([]*pluginDependencies, len(t.plugins))
+	for i, plug := range t.plugins {
+		if _, ok := plug.(plugins.UpstreamPlugin); !ok {
+			continue
+		}
+		pluginDeps[i] = dependenciesForPlugin(cfg, plug, dependencies)
+	}
+	return pluginDeps
+}
+
 func dependenciesForPlugin(cfg *v1.Config, plug plugins.TranslatorPlugin, dependencies *pluginDependencies) *pluginDependencies {
 	dependencyRefs := plug.GetDependencies(cfg)
 	if dependencyRefs == nil {
@@ -353,9 +462,11 @@ func dependenciesForPlugin(cfg *v1.Config, plug plugins.TranslatorPlugin, depend
 
 // VirtualHosts
 
-func (t *Translator) computeVirtualHosts(cfg *v1.Config,
+func (t *Translator) computeVirtualHosts(ctx *plugins.Context,
+	cfg *v1.Config,
 	erroredUpstreams map[string]bool,
-	secrets secretwatcher.SecretMap) ([]envoyroute.VirtualHost, []envoyroute.VirtualHost, []reporter.ConfigObjectReport) {
+	secrets secretwatcher.SecretMap,
+	keys cacheKeys) ([]envoyroute.VirtualHost, []envoyroute.VirtualHost, []reporter.ConfigObjectReport) {
 	var (
 		reports           []reporter.ConfigObjectReport
 		sslVirtualHosts   []envoyroute.VirtualHost
@@ -364,17 +475,56 @@ func (t *Translator) computeVirtualHosts(cfg *v1.Config,
 
 	// check for bad domains, then add those errors to the vhost error list
 	vHostsWithBadDomains := virtualHostsWithConflictingDomains(cfg.VirtualHosts, reports)
+	vHostsWithBadTcpRoutes := virtualHostsWithConflictingTcpRoutes(cfg.VirtualHosts)
+
+	// index the valid destinations once for all routes
+	destinations := newDestinationIndex(cfg.Upstreams, erroredUpstreams)
+
+	upstreamsByName := make(map[string]*v1.Upstream)
+	for _, upstream := range cfg.Upstreams {
+		upstreamsByName[upstream.Name] = upstream
+	}
 
 	for _, virtualHost := range cfg.VirtualHosts {
-		envoyVirtualHost, err := t.computeVirtualHost(cfg.Upstreams, virtualHost, erroredUpstreams, secrets)
+		hash, cacheable := keys.virtualHosts[virtualHost.Name]
+		computed, cached := t.cache.virtualHost(virtualHost.Name, hash)
+		if !cacheable || !cached {
+			// route plugins only see the upstreams the virtual host refers to, which is all its cache key covers
+			var upstreams []*v1.Upstream
+			for _, name := range referencedUpstreamNames(virtualHost) {
+				if upstream, ok := upstreamsByName[name]; ok {
+					upstreams = append(upstreams, upstream)
+				}
+			}
+			resourceCtx := ctx.ForResource()
+			envoyVirtualHost, problems := t.computeVirtualHost(resourceCtx, upstreams, virtualHost, destinations, secrets)
+			computed = cachedVirtualHost{hash: hash, virtualHost: envoyVirtualHost, problems: problems, contributions: resourceCtx.Recorded()}
+			if cacheable {
+				t.cache.setVirtualHost(virtualHost.Name, computed)
+			}
+		}
+		envoyVirtualHost, problems := computed.virtualHost, computed.problems
+		err := problems.err
 		if domainErr, invalidVHost := vHostsWithBadDomains[virtualHost.Name]; invalidVHost {
 			err = multierror.Append(err, domainErr)
 		}
-		reports = append(reports, createReport(virtualHost, err))
+		if tcpErr, invalidVHost := vHostsWithBadTcpRoutes[virtualHost.Name]; invalidVHost {
+			err = multierror.Append(err, tcpErr)
+		}
+		report := createReport(virtualHost, err)
+		// copied, since the https listener may add to the warnings of a cached virtual host
+		report.Warnings = append([]string(nil), problems.warnings...)
+		report.RouteErrors = problems.routeErrors
+		reports = append(reports, report)
 		// don't append errored virtual hosts
 		if err != nil {
 			continue
 		}
+		ctx.Replay(computed.contributions)
+		// tcp-only virtual hosts are served by the tcp listeners
+		if isTcpOnly(virtualHost) {
+			continue
+		}
 		if virtualHost.SslConfig != nil && virtualHost.SslConfig.SecretRef != "" {
 			// TODO: allow user to specify require ALL tls or just external
 			envoyVirtualHost.RequireTls = envoyroute.VirtualHost_ALL
@@ -392,6 +542,10 @@ func virtualHostsWithConflictingDomains(virtualHosts []*v1.VirtualHost, reports
 	domainsToVirtualhosts := make(map[string][]string) // this shouldbe a 1-1 mapping
 	// if len(domainsToVirtualhosts[domain]) > 1, error
 	for _, vhost := range virtualHosts {
+		// tcp-only virtual hosts do not match on http domains
+		if isTcpOnly(vhost) {
+			continue
+		}
 		if len(vhost.Domains) == 0 {
 			// default virtualhost
 			domainsToVirtualhosts["*"] = append(domainsToVirtualhosts["*"], vhost.Name)
@@ -417,15 +571,31 @@ func virtualHostsWithConflictingDomains(virtualHosts []*v1.VirtualHost, reports
 	return erroredVHosts
 }
 
-func (t *Translator) computeVirtualHost(upstreams []*v1.Upstream,
+// virtualHostProblems are the problems found while translating a virtual host
+type virtualHostProblems struct {
+	// all errors, including the route errors
+	err error
+	// errors for individual routes, by the index of the route
+	routeErrors map[int]error
+	warnings    []string
+}
+
+func (t *Translator) computeVirtualHost(ctx *plugins.Context,
+	upstreams []*v1.Upstream,
 	virtualHost *v1.VirtualHost,
-	erroredUpstreams map[string]bool,
-	secrets secretwatcher.SecretMap) (envoyroute.VirtualHost, error) {
+	destinations destinationIndex,
+	secrets secretwatcher.SecretMap) (envoyroute.VirtualHost, virtualHostProblems) {
 	var envoyRoutes []envoyroute.Route
+	var problems virtualHostProblems
 	var vHostErrors error
-	for _, route := range virtualHost.Routes {
-		if err := validateRouteDestinations(upstreams, route, erroredUpstreams); err != nil {
-			vHostErrors = multierror.Append(vHostErrors, err)
+	for i, route := range virtualHost.Routes {
+		var routeErrors error
+		warnings, err := validateRouteDestinations(destinations, route)
+		if err != nil {
+			routeErrors = multierror.Append(routeErrors, err)
+		}
+		for _, warning := range warnings {
+			problems.warnings = append(problems.warnings, fmt.Sprintf("route %v: %v", i, warning))
 		}
 		out := envoyroute.Route{}
 		for _, plug := range t.plugins {
@@ -434,15 +604,29 @@ func (t *Translator) computeVirtualHost(upstreams []*v1.Upstream,
 				continue
 			}
 			params := &plugins.RoutePluginParams{
+				Context:   ctx,
 				Upstreams: upstreams,
 			}
 			if err := routePlugin.ProcessRoute(params, route, &out); err != nil {
-				vHostErrors = multierror.Append(vHostErrors, err)
+				routeErrors = multierror.Append(routeErrors, err)
+			}
+		}
+		if routeErrors != nil {
+			if problems.routeErrors == nil {
+				problems.routeErrors = make(map[int]error)
 			}
+			problems.routeErrors[i] = routeErrors
+			vHostErrors = multierror.Append(vHostErrors, routeErrors)
 		}
 		envoyRoutes = append(envoyRoutes, out)
 	}
 
+	for _, route := range virtualHost.TcpRoutes {
+		if err := validateTcpRoute(destinations, route); err != nil {
+			vHostErrors = multierror.Append(vHostErrors, err)
+		}
+	}
+
 	// validate ssl config if the host specifies one
 	if err := validateVirtualHostSSLConfig(virtualHost, secrets); err != nil {
 		vHostErrors = multierror.Append(vHostErrors, err)
@@ -453,40 +637,79 @@ func (t *Translator) computeVirtualHost(upstreams []*v1.Upstream,
 		domains = []string{"*"}
 	}
 
+	problems.err = vHostErrors
+
 	// TODO: handle default virtualhost
 	// TODO: handle ssl
 	return envoyroute.VirtualHost{
 		Name:    virtualHostName(virtualHost.Name),
 		Domains: domains,
 		Routes:  envoyRoutes,
-	}, vHostErrors
+	}, problems
 }
 
-func validateRouteDestinations(upstreams []*v1.Upstream, route *v1.Route, erroredUpstreams map[string]bool) error {
-	// collect existing upstreams/functions for matching
-	upstreamsAndTheirFunctions := make(map[string][]string)
+// returns warnings for destinations that are valid but likely to be mistakes
+func validateRouteDestinations(destinations destinationIndex, route *v1.Route) ([]string, error) {
+	// make sure the destination itself has the right structure
+	switch {
+	case route.SingleDestination != nil && len(route.MultipleDestinations) == 0:
+		return validateSingleDestination(destinations, route.SingleDestination)
+	case route.SingleDestination == nil && len(route.MultipleDestinations) > 0:
+		return validateMultiDestination(destinations, route.MultipleDestinations)
+	}
+	return nil, errors.Errorf("must specify either 'single_destination' or 'multiple_destinations' for route")
+}
+
+// referencedUpstreamNames returns the names of the upstreams the routes and tcp routes of the virtual host send requests to,
+// sorted and without duplicates
+func referencedUpstreamNames(virtualHost *v1.VirtualHost) []string {
+	referenced := make(map[string]bool)
+	addDestination := func(dest *v1.Destination) {
+		switch d := dest.GetDestinationType().(type) {
+		case *v1.Destination_Upstream:
+			referenced[d.Upstream.GetName()] = true
+		case *v1.Destination_Function:
+			referenced[d.Function.GetUpstreamName()] = true
+		}
+	}
+	for _, route := range virtualHost.Routes {
+		addDestination(route.SingleDestination)
+		for _, dest := range route.MultipleDestinations {
+			addDestination(dest.Destination)
+		}
+	}
+	for _, route := range virtualHost.TcpRoutes {
+		addDestination(route.SingleDestination)
+		for _, dest := range route.MultipleDestinations {
+			addDestination(dest.Destination)
+		}
+	}
+	var names []string
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// destinationIndex maps the name of each valid upstream to the set of its function names
+type destinationIndex map[string]map[string]bool
 
+func newDestinationIndex(upstreams []*v1.Upstream, erroredUpstreams map[string]bool) destinationIndex {
+	destinations := make(destinationIndex)
 	for _, upstream := range upstreams {
 		// don't consider errored upstreams to be valid destinations
 		if erroredUpstreams[upstream.Name] {
 			log.Debugf("upstream %v had errors, it will not be a considered destination", upstream.Name)
 			continue
 		}
-		var funcsForUpstream []string
+		funcsForUpstream := make(map[string]bool)
 		for _, fn := range upstream.Functions {
-			funcsForUpstream = append(funcsForUpstream, fn.Name)
+			funcsForUpstream[fn.Name] = true
 		}
-		upstreamsAndTheirFunctions[upstream.Name] = funcsForUpstream
+		destinations[upstream.Name] = funcsForUpstream
 	}
-
-	// make sure the destination itself has the right structure
-	switch {
-	case route.SingleDestination != nil && len(route.MultipleDestinations) == 0:
-		return validateSingleDestination(upstreamsAndTheirFunctions, route.SingleDestination)
-	case route.SingleDestination == nil && len(route.MultipleDestinations) > 0:
-		return validateMultiDestination(upstreamsAndTheirFunctions, route.MultipleDestinations)
-	}
-	return errors.Errorf("must specify either 'single_destination' or 'multiple_destinations' for route")
+	return destinations
 }
 
 func getErroredUpstreams(clusterReports []reporter.ConfigObjectReport) map[string]bool {
@@ -503,53 +726,49 @@ func getErroredUpstreams(clusterReports []reporter.ConfigObjectReport) map[strin
 	return erroredUpstreams
 }
 
-func validateMultiDestination(upstreamsAndTheirFunctions map[string][]string, destinations []*v1.WeightedDestination) error {
-	for _, dest := range destinations {
-		if err := validateSingleDestination(upstreamsAndTheirFunctions, dest.Destination); err != nil {
-			return errors.Wrap(err, "invalid destination in weighted destination list")
+func validateMultiDestination(destinations destinationIndex, weightedDestinations []*v1.WeightedDestination) ([]string, error) {
+	var warnings []string
+	for _, dest := range weightedDestinations {
+		destWarnings, err := validateSingleDestination(destinations, dest.Destination)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid destination in weighted destination list")
 		}
+		warnings = append(warnings, destWarnings...)
 	}
-	return nil
+	return warnings, nil
 }
 
-func validateSingleDestination(upstreamsAndTheirFunctions map[string][]string, destination *v1.Destination) error {
+func validateSingleDestination(destinations destinationIndex, destination *v1.Destination) ([]string, error) {
 	switch dest := destination.DestinationType.(type) {
 	case *v1.Destination_Upstream:
-		return validateUpstreamDestination(upstreamsAndTheirFunctions, dest)
+		return nil, validateUpstreamDestination(destinations, dest)
 	case *v1.Destination_Function:
-		return validateFunctionDestination(upstreamsAndTheirFunctions, dest)
+		return validateFunctionDestination(destinations, dest)
 	}
-	return errors.New("must specify either a function or upstream on a single destination")
+	return nil, errors.New("must specify either a function or upstream on a single destination")
 }
 
-func validateUpstreamDestination(upstreamsAndTheirFunctions map[string][]string, upstreamDestination *v1.Destination_Upstream) error {
+func validateUpstreamDestination(destinations destinationIndex, upstreamDestination *v1.Destination_Upstream) error {
 	upstreamName := upstreamDestination.Upstream.Name
-	if _, ok := upstreamsAndTheirFunctions[upstreamName]; !ok {
+	if _, ok := destinations[upstreamName]; !ok {
 		return errors.Errorf("upstream %v was not found or had errors for upstream destination", upstreamName)
 	}
 	return nil
 }
 
-func validateFunctionDestination(upstreamsAndTheirFunctions map[string][]string, functionDestination *v1.Destination_Function) error {
+// functions may be discovered after the route is created, so a missing function is only a warning
+func validateFunctionDestination(destinations destinationIndex, functionDestination *v1.Destination_Function) ([]string, error) {
 	upstreamName := functionDestination.Function.UpstreamName
-	upstreamFuncs, ok := upstreamsAndTheirFunctions[upstreamName]
+	upstreamFuncs, ok := destinations[upstreamName]
 	if !ok {
-		return errors.Errorf("upstream %v was not found or had errors for function destination", upstreamName)
+		return nil, errors.Errorf("upstream %v was not found or had errors for function destination", upstreamName)
 	}
 	functionName := functionDestination.Function.FunctionName
-	if !stringInSlice(upstreamFuncs, functionName) {
+	if !upstreamFuncs[functionName] {
 		log.Warnf("function %v/%v was not found for function destination", upstreamName, functionName)
+		return []string{fmt.Sprintf("function %v/%v was not found for function destination", upstreamName, functionName)}, nil
 	}
-	return nil
-}
-
-func stringInSlice(slice []string, s string) bool {
-	for _, el := range slice {
-		if el == s {
-			return true
-		}
-	}
-	return false
+	return nil, nil
 }
 
 func validateVirtualHostSSLConfig(virtualHost *v1.VirtualHost, secrets secretwatcher.SecretMap) error {
@@ -581,6 +800,8 @@ func getSslSecrets(ref string, secrets secretwatcher.SecretMap) (string, string,
 type stagedFilter struct {
 	filter *envoyhttp.HttpFilter
 	stage  plugins.Stage
+	before []string
+	after  []string
 }
 
 func (t *Translator) constructHttpListener(name string, port uint32, filters []envoylistener.Filter) *envoyapi.Listener {
@@ -620,13 +841,14 @@ func (t *Translator) constructHttpsListener(name string,
 	// we will copy the filter chain for each virtualhost that specifies an ssl config
 	var filterChains []envoylistener.FilterChain
 	for _, vhost := range virtualHosts {
-		if vhost.SslConfig == nil || vhost.SslConfig.SecretRef == "" {
+		if vhost.SslConfig == nil || vhost.SslConfig.SecretRef == "" || isTcpOnly(vhost) {
 			continue
 		}
 		ref := vhost.SslConfig.SecretRef
 		certChain, privateKey, err := getSslSecrets(ref, secrets)
 		if err != nil {
 			log.Warnf("skipping ssl vhost with invalid secrets: %v", vhost.Name)
+			addWarning(virtualHostReports, vhost, fmt.Sprintf("not served on the https listener: %v", err))
 			continue
 		}
 		filterChain := newSslFilterChain(certChain, privateKey, filters)
@@ -678,14 +900,14 @@ func newSslFilterChain(certChain, privateKey string, filters []envoylistener.Fil
 	}
 }
 
-func (t *Translator) createHttpFilters() []*envoyhttp.HttpFilter {
+func (t *Translator) createHttpFilters(ctx *plugins.Context) ([]*envoyhttp.HttpFilter, error) {
 	var filtersByStage []stagedFilter
 	for _, plug := range t.plugins {
 		filterPlugin, ok := plug.(plugins.FilterPlugin)
 		if !ok {
 			continue
 		}
-		params := &plugins.FilterPluginParams{}
+		params := &plugins.FilterPluginParams{Context: ctx}
 		stagedFilters := filterPlugin.HttpFilters(params)
 		for _, httpFilter := range stagedFilters {
 			if httpFilter.HttpFilter == nil {
@@ -695,14 +917,22 @@ func (t *Translator) createHttpFilters() []*envoyhttp.HttpFilter {
 			filtersByStage = append(filtersByStage, stagedFilter{
 				filter: httpFilter.HttpFilter,
 				stage:  httpFilter.Stage,
+				before: httpFilter.Before,
+				after:  httpFilter.After,
 			})
 		}
 	}
+	// a filter plugin that could not tell which filters are needed would leave them out of the snapshot
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-	// sort filters by stage
-	httpFilters := sortFilters(filtersByStage)
+	httpFilters, err := sortFilters(filtersByStage)
+	if err != nil {
+		return nil, err
+	}
 	httpFilters = append(httpFilters, &envoyhttp.HttpFilter{Name: routerFilter})
-	return httpFilters
+	return httpFilters, nil
 }
 
 func (t *Translator) constructFilters(routeConfigName string, httpFilters []*envoyhttp.HttpFilter) ([]envoylistener.Filter, error) {
@@ -734,7 +964,9 @@ func (t *Translator) constructFilters(routeConfigName string, httpFilters []*env
 	}, nil
 }
 
-func sortFilters(filters []stagedFilter) []*envoyhttp.HttpFilter {
+// sortFilters orders the filters by stage, then by name, and moves filters only as far as
+// the before and after constraints of the filters require
+func sortFilters(filters []stagedFilter) ([]*envoyhttp.HttpFilter, error) {
 	// sort them first by stage, then by name.
 	less := func(i, j int) bool {
 		filteri := filters[i]
@@ -746,12 +978,61 @@ func sortFilters(filters []stagedFilter) []*envoyhttp.HttpFilter {
 	}
 	sort.SliceStable(filters, less)
 
+	// a constraint on a name applies to every filter with that name
+	byName := make(map[string][]int)
+	for i, filter := range filters {
+		byName[filter.filter.Name] = append(byName[filter.filter.Name], i)
+	}
+	successors := make([][]int, len(filters))
+	predecessors := make([]int, len(filters))
+	mustPrecede := func(i, j int) {
+		if i == j {
+			return
+		}
+		successors[i] = append(successors[i], j)
+		predecessors[j]++
+	}
+	for i, filter := range filters {
+		for _, name := range filter.before {
+			for _, j := range byName[name] {
+				mustPrecede(i, j)
+			}
+		}
+		for _, name := range filter.after {
+			for _, j := range byName[name] {
+				mustPrecede(j, i)
+			}
+		}
+	}
+
+	// repeatedly take the first filter in stage order that has no filter left to wait for
+	placed := make([]bool, len(filters))
 	var sortedFilters []*envoyhttp.HttpFilter
-	for _, filter := range filters {
-		sortedFilters = append(sortedFilters, filter.filter)
+	for len(sortedFilters) < len(filters) {
+		next := -1
+		for i := range filters {
+			if !placed[i] && predecessors[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var cycle []string
+			for i, filter := range filters {
+				if !placed[i] {
+					cycle = append(cycle, filter.filter.Name)
+				}
+			}
+			return nil, errors.Errorf("the ordering of filters %v cannot be satisfied", cycle)
+		}
+		placed[next] = true
+		sortedFilters = append(sortedFilters, filters[next].filter)
+		for _, j := range successors[next] {
+			predecessors[j]--
+		}
 	}
 
-	return sortedFilters
+	return sortedFilters, nil
 }
 
 // for future-proofing possible safety issues with bad upstream names
@@ -770,3 +1051,12 @@ func createReport(cfgObject v1.ConfigObject, err error) reporter.ConfigObjectRep
 		Err:       err,
 	}
 }
+
+func addWarning(reports []reporter.ConfigObjectReport, cfgObject v1.ConfigObject, warning string) {
+	for i, report := range reports {
+		if report.CfgObject == cfgObject {
+			reports[i].Warnings = append(reports[i].Warnings, warning)
+			return
+		}
+	}
+}
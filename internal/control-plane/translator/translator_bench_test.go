@@ -0,0 +1,128 @@
+package translator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/coreplugins/service"
+)
+
+const routesPerVirtualHost = 100
+
+// translation time should grow roughly linearly with the size of the config,
+// and a translation that only has to recompute what changed should take a fraction of a full one
+func BenchmarkTranslate(b *testing.B) {
+	for _, size := range []struct {
+		upstreams, routes int
+	}{
+		{upstreams: 100, routes: 500},
+		{upstreams: 1000, routes: 5000},
+		{upstreams: 10000, routes: 50000},
+	} {
+		cfg := largeConfig(size.upstreams, size.routes)
+		name := fmt.Sprintf("%dupstreams-%droutes", size.upstreams, size.routes)
+		b.Run(name+"/cold", func(b *testing.B) {
+			benchmarkTranslate(b, cfg, true, func(int) {})
+		})
+		b.Run(name+"/unchanged", func(b *testing.B) {
+			benchmarkTranslate(b, cfg, false, func(int) {})
+		})
+		b.Run(name+"/upstream-changed", func(b *testing.B) {
+			upstream := cfg.Upstreams[len(cfg.Upstreams)-1]
+			defer func() { upstream.ConnectionTimeout = 0 }()
+			benchmarkTranslate(b, cfg, false, func(i int) {
+				upstream.ConnectionTimeout = time.Duration(i%2+1) * time.Second
+			})
+		})
+		b.Run(name+"/virtual-host-changed", func(b *testing.B) {
+			route := cfg.VirtualHosts[len(cfg.VirtualHosts)-1].Routes[0]
+			defer func() { route.PrefixRewrite = "" }()
+			benchmarkTranslate(b, cfg, false, func(i int) {
+				route.PrefixRewrite = fmt.Sprintf("/rewrite-%d", i%2)
+			})
+		})
+	}
+}
+
+// benchmarkTranslate translates the config b.N times, calling change before each translation.
+// unless cold is set, the translation cache is warmed up before the timer starts
+func benchmarkTranslate(b *testing.B, cfg *v1.Config, cold bool, change func(i int)) {
+	t := NewTranslator(TranslatorConfig{"::"}, nil)
+	translate := func() {
+		_, reports, err := t.Translate(Inputs{Cfg: cfg})
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, report := range reports {
+			if report.Err != nil {
+				b.Fatal(report.Err)
+			}
+		}
+	}
+	if !cold {
+		translate()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if cold {
+			// drop the cache so nothing is served from it
+			t.cache = newTranslationCache()
+		}
+		change(i)
+		translate()
+	}
+}
+
+// largeConfig spreads the routes over virtual hosts with unique domains,
+// alternating between upstream and function destinations
+func largeConfig(numUpstreams, numRoutes int) *v1.Config {
+	cfg := &v1.Config{}
+	for i := 0; i < numUpstreams; i++ {
+		cfg.Upstreams = append(cfg.Upstreams, &v1.Upstream{
+			Name: fmt.Sprintf("upstream-%d", i),
+			Type: service.UpstreamTypeService,
+			Spec: service.EncodeUpstreamSpec(service.UpstreamSpec{
+				Hosts: []service.Host{{Addr: fmt.Sprintf("host-%d", i), Port: 8080}},
+			}),
+			Functions: []*v1.Function{
+				{Name: "get"},
+				{Name: "put"},
+			},
+		})
+	}
+	var vhost *v1.VirtualHost
+	for i := 0; i < numRoutes; i++ {
+		if i%routesPerVirtualHost == 0 {
+			vhost = &v1.VirtualHost{
+				Name:    fmt.Sprintf("vhost-%d", i/routesPerVirtualHost),
+				Domains: []string{fmt.Sprintf("vhost-%d.example.com", i/routesPerVirtualHost)},
+			}
+			cfg.VirtualHosts = append(cfg.VirtualHosts, vhost)
+		}
+		upstreamName := cfg.Upstreams[i%numUpstreams].Name
+		destination := &v1.Destination{
+			DestinationType: &v1.Destination_Upstream{
+				Upstream: &v1.UpstreamDestination{Name: upstreamName},
+			},
+		}
+		if i%2 == 1 {
+			destination = &v1.Destination{
+				DestinationType: &v1.Destination_Function{
+					Function: &v1.FunctionDestination{UpstreamName: upstreamName, FunctionName: "get"},
+				},
+			}
+		}
+		vhost.Routes = append(vhost.Routes, &v1.Route{
+			Matcher: &v1.Route_RequestMatcher{
+				RequestMatcher: &v1.RequestMatcher{
+					Path: &v1.RequestMatcher_PathPrefix{PathPrefix: fmt.Sprintf("/route-%d", i)},
+				},
+			},
+			SingleDestination: destination,
+		})
+	}
+	return cfg
+}
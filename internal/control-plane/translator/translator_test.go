@@ -3,6 +3,7 @@ package translator
 import (
 	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
 	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/plugins"
 	"github.com/solo-io/gloo/pkg/secretwatcher"
 
@@ -14,14 +15,34 @@ import (
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/coreplugins/route-extensions"
 	"github.com/solo-io/gloo/pkg/coreplugins/service"
+	"github.com/solo-io/gloo/pkg/endpointdiscovery"
 	"github.com/solo-io/gloo/pkg/storage/dependencies"
 )
 
+// failingFilterPlugin cannot tell which filters are needed
+type failingFilterPlugin struct{}
+
+func (p *failingFilterPlugin) GetDependencies(_ *v1.Config) *plugins.Dependencies {
+	return nil
+}
+
+func (p *failingFilterPlugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
+	params.Context.Fail(errors.New("filters unavailable"))
+	return nil
+}
+
 func newTranslator() *Translator {
 	return NewTranslator(TranslatorConfig{"::"}, []plugins.TranslatorPlugin{&service.Plugin{}})
 }
 
 var _ = Describe("Translator", func() {
+	It("fails the translation when a filter plugin fails", func() {
+		t := NewTranslator(TranslatorConfig{"::"}, []plugins.TranslatorPlugin{&failingFilterPlugin{}})
+		snap, _, err := t.Translate(Inputs{Cfg: ValidConfigNoSsl()})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("filters unavailable"))
+		Expect(snap).To(BeNil())
+	})
 	Context("invalid config", func() {
 		Context("domains are not unique amongst virtual hosts", func() {
 			cfg := InvalidConfigSharedDomains()
@@ -71,6 +92,17 @@ var _ = Describe("Translator", func() {
 				Expect(reports[1].Err.Error()).To(ContainSubstring("ip cannot be empty"))
 				Expect(reports[3].Err.Error()).To(ContainSubstring("upstream invalid-service was not found or had errors for function destination"))
 			})
+			It("reports the error by the index of the bad route", func() {
+				Expect(reports[2].RouteErrors).To(BeEmpty())
+				Expect(reports[3].RouteErrors).To(HaveLen(1))
+				Expect(reports[3].RouteErrors).To(HaveKey(0))
+				Expect(reports[3].RouteErrors[0].Error()).To(ContainSubstring("upstream invalid-service was not found"))
+			})
+			It("records when the config objects were evaluated", func() {
+				for _, report := range reports {
+					Expect(report.EvaluationTime).NotTo(BeZero())
+				}
+			})
 			It("returns one cluster and one vhost", func() {
 				clas, clusters, routeConfigs, listeners := getSnapshotResources(snap)
 				Expect(clas).To(HaveLen(0))
@@ -125,6 +157,31 @@ var _ = Describe("Translator", func() {
 				Expect(listeners).To(HaveLen(1))
 			})
 		})
+		Context("with a function destination for a function the upstream does not list", func() {
+			cfg := ValidConfigNoSsl()
+			cfg.VirtualHosts[0].Routes[0].SingleDestination = &v1.Destination{
+				DestinationType: &v1.Destination_Function{
+					Function: &v1.FunctionDestination{
+						UpstreamName: cfg.Upstreams[0].Name,
+						FunctionName: "not-yet-discovered",
+					},
+				},
+			}
+			t := newTranslator()
+			It("accepts the virtual host with a warning", func() {
+				_, reports, err := t.Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports).To(HaveLen(2))
+				Expect(reports[1].Err).To(BeNil())
+				Expect(reports[1].Warnings).To(HaveLen(1))
+				Expect(reports[1].Warnings[0]).To(ContainSubstring("not-yet-discovered was not found"))
+			})
+			It("keeps the warning when the virtual host is served from the cache", func() {
+				_, reports, err := t.Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports[1].Warnings).To(HaveLen(1))
+			})
+		})
 		Context("with an ssl secret specified", func() {
 			cfg := ValidConfigSsl()
 			t := newTranslator()
@@ -138,6 +195,7 @@ var _ = Describe("Translator", func() {
 					Expect(reports[0].Err).To(BeNil())
 					Expect(reports[1].Err).NotTo(BeNil())
 					Expect(reports[1].Err.Error()).To(ContainSubstring("secret not found for ref ssl-secret-ref"))
+					Expect(reports[1].Warnings).To(ContainElement(ContainSubstring("not served on the https listener")))
 				})
 			})
 			Context("the desired ssl secret not present in the secret map", func() {
@@ -168,6 +226,117 @@ var _ = Describe("Translator", func() {
 				})
 			})
 		})
+		Context("with tcp routes", func() {
+			cfg := ValidConfigTcp()
+			t := newTranslator()
+			endpoints := endpointdiscovery.EndpointGroups{
+				"redis-a": {{Address: "10.0.0.1", Port: 6379}},
+			}
+			snap, reports, err := t.Translate(Inputs{Cfg: cfg, Endpoints: endpoints})
+			It("returns no error reports", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports).To(HaveLen(7))
+				for _, report := range reports {
+					Expect(report.Err).To(BeNil())
+				}
+			})
+			It("does not add tcp-only virtual hosts to the http route config", func() {
+				_, _, routeConfigs, _ := getSnapshotResources(snap)
+				Expect(routeConfigs).To(HaveLen(1))
+				Expect(routeConfigs[0].Name).To(Equal(nosslRdsName))
+				Expect(routeConfigs[0].VirtualHosts).To(HaveLen(1))
+				Expect(routeConfigs[0].VirtualHosts[0].Name).To(Equal("valid-vhost"))
+			})
+			It("creates clusters and eds assignments for tcp upstreams", func() {
+				clas, clusters, _, _ := getSnapshotResources(snap)
+				Expect(clas).To(HaveLen(1))
+				Expect(clas[0].ClusterName).To(Equal("redis-a"))
+				Expect(clusters).To(HaveLen(4))
+			})
+			It("creates a tcp proxy listener for each port", func() {
+				_, _, _, listeners := getSnapshotResources(snap)
+				Expect(listeners).To(HaveLen(3))
+				Expect(listeners[0].Name).To(Equal(nosslListenerName))
+
+				postgres := listeners[1]
+				Expect(postgres.Name).To(Equal("listener-tcp-5432"))
+				Expect(postgres.ListenerFilters).To(BeEmpty())
+				Expect(postgres.FilterChains).To(HaveLen(1))
+				Expect(postgres.FilterChains[0].FilterChainMatch).To(BeNil())
+				Expect(postgres.FilterChains[0].Filters).To(HaveLen(1))
+				Expect(postgres.FilterChains[0].Filters[0].Name).To(Equal(tcpProxyFilter))
+				Expect(postgres.FilterChains[0].Filters[0].Config.Fields["cluster"].GetStringValue()).To(Equal("postgres"))
+
+				redis := listeners[2]
+				Expect(redis.Name).To(Equal("listener-tcp-6379"))
+				Expect(redis.ListenerFilters).To(HaveLen(1))
+				Expect(redis.ListenerFilters[0].Name).To(Equal(tlsInspectorFilter))
+				Expect(redis.FilterChains).To(HaveLen(1))
+				Expect(redis.FilterChains[0].FilterChainMatch.ServerNames).To(Equal([]string{"redis.example.com"}))
+				weighted := redis.FilterChains[0].Filters[0].Config.Fields["weighted_clusters"].GetStructValue()
+				Expect(weighted).NotTo(BeNil())
+				Expect(weighted.Fields["clusters"].GetListValue().Values).To(HaveLen(2))
+			})
+		})
+		Context("with invalid tcp routes", func() {
+			It("returns an error for tcp routes with function destinations", func() {
+				cfg := ValidConfigTcp()
+				cfg.VirtualHosts[1].TcpRoutes[0].SingleDestination = &v1.Destination{
+					DestinationType: &v1.Destination_Function{
+						Function: &v1.FunctionDestination{
+							UpstreamName: "postgres",
+							FunctionName: "query",
+						},
+					},
+				}
+				snap, reports, err := newTranslator().Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports[5].CfgObject).To(Equal(cfg.VirtualHosts[1]))
+				Expect(reports[5].Err).NotTo(BeNil())
+				Expect(reports[5].Err.Error()).To(ContainSubstring("tcp routes only support upstream destinations"))
+				_, _, _, listeners := getSnapshotResources(snap)
+				Expect(listeners).To(HaveLen(2))
+			})
+			It("returns an error for tcp routes which share a port and sni domain", func() {
+				cfg := ValidConfigTcp()
+				cfg.VirtualHosts[2].TcpRoutes[0].Port = 5432
+				cfg.VirtualHosts[2].TcpRoutes[0].SniDomains = nil
+				_, reports, err := newTranslator().Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports[5].Err).NotTo(BeNil())
+				Expect(reports[5].Err.Error()).To(ContainSubstring("tcp port 5432 with sni domain * is shared by the following tcp routes on virtual hosts: [postgres-vhost redis-vhost]"))
+				Expect(reports[6].Err).NotTo(BeNil())
+			})
+			It("treats a wildcard sni domain as matching every server name", func() {
+				cfg := ValidConfigTcp()
+				cfg.VirtualHosts[2].TcpRoutes[0].Port = 5432
+				cfg.VirtualHosts[2].TcpRoutes[0].SniDomains = []string{"*"}
+				_, reports, err := newTranslator().Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports[5].Err).NotTo(BeNil())
+				Expect(reports[5].Err.Error()).To(ContainSubstring("tcp port 5432 with sni domain * is shared by the following tcp routes on virtual hosts: [postgres-vhost redis-vhost]"))
+				Expect(reports[6].Err).NotTo(BeNil())
+			})
+			It("returns an error for tcp routes whose wildcard sni domains differ only in case", func() {
+				cfg := ValidConfigTcp()
+				cfg.VirtualHosts[1].TcpRoutes[0].Port = 6379
+				cfg.VirtualHosts[1].TcpRoutes[0].SniDomains = []string{"*.Example.com."}
+				cfg.VirtualHosts[2].TcpRoutes[0].SniDomains = []string{"*.example.com"}
+				_, reports, err := newTranslator().Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports[5].Err).NotTo(BeNil())
+				Expect(reports[5].Err.Error()).To(ContainSubstring("tcp port 6379 with sni domain *.example.com is shared by the following tcp routes on virtual hosts: [postgres-vhost redis-vhost]"))
+				Expect(reports[6].Err).NotTo(BeNil())
+			})
+			It("returns an error for tcp routes on an http listener port", func() {
+				cfg := ValidConfigTcp()
+				cfg.VirtualHosts[1].TcpRoutes[0].Port = nosslListenerPort
+				_, reports, err := newTranslator().Translate(Inputs{Cfg: cfg})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports[5].Err).NotTo(BeNil())
+				Expect(reports[5].Err.Error()).To(ContainSubstring("conflicts with http listener port"))
+			})
+		})
 	})
 })
 
@@ -387,6 +556,73 @@ func ValidConfigSsl() *v1.Config {
 	}
 }
 
+func ValidConfigTcp() *v1.Config {
+	cfg := ValidConfigNoSsl()
+	for _, name := range []string{"postgres", "redis-a", "redis-b"} {
+		cfg.Upstreams = append(cfg.Upstreams, &v1.Upstream{
+			Name: name,
+			Type: service.UpstreamTypeService,
+			Spec: service.EncodeUpstreamSpec(service.UpstreamSpec{
+				Hosts: []service.Host{
+					{
+						Addr: "localhost",
+						Port: 1234,
+					},
+				},
+			}),
+		})
+	}
+	cfg.VirtualHosts = append(cfg.VirtualHosts,
+		&v1.VirtualHost{
+			Name: "postgres-vhost",
+			TcpRoutes: []*v1.TcpRoute{
+				{
+					Port: 5432,
+					SingleDestination: &v1.Destination{
+						DestinationType: &v1.Destination_Upstream{
+							Upstream: &v1.UpstreamDestination{
+								Name: "postgres",
+							},
+						},
+					},
+				},
+			},
+		},
+		&v1.VirtualHost{
+			Name: "redis-vhost",
+			TcpRoutes: []*v1.TcpRoute{
+				{
+					Port:       6379,
+					SniDomains: []string{"redis.example.com"},
+					MultipleDestinations: []*v1.WeightedDestination{
+						{
+							Destination: &v1.Destination{
+								DestinationType: &v1.Destination_Upstream{
+									Upstream: &v1.UpstreamDestination{
+										Name: "redis-a",
+									},
+								},
+							},
+							Weight: 90,
+						},
+						{
+							Destination: &v1.Destination{
+								DestinationType: &v1.Destination_Upstream{
+									Upstream: &v1.UpstreamDestination{
+										Name: "redis-b",
+									},
+								},
+							},
+							Weight: 10,
+						},
+					},
+				},
+			},
+		},
+	)
+	return cfg
+}
+
 func PartiallyValidConfig() *v1.Config {
 	upstreams := []*v1.Upstream{
 		{
@@ -0,0 +1,149 @@
+package xds
+
+import (
+	"sync"
+
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/solo-io/gloo/pkg/metrics"
+)
+
+var (
+	openStreams = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "xds",
+		Name:      "open_streams",
+		Help:      "open xDS streams from envoy",
+	})
+	connectedNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "xds",
+		Name:      "connected_nodes",
+		Help:      "distinct envoy nodes with at least one open xDS stream",
+	})
+	acks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "xds",
+		Name:      "acks_total",
+		Help:      "xDS responses accepted by envoy",
+	}, []string{"type_url"})
+	nacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "xds",
+		Name:      "nacks_total",
+		Help:      "xDS responses rejected by envoy",
+	}, []string{"type_url"})
+	nodeVersions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "xds",
+		Name:      "node_version_info",
+		Help:      "the version of each resource type last acked or nacked by each envoy node",
+	}, []string{"node", "type_url", "version", "state"})
+)
+
+func init() {
+	prometheus.MustRegister(openStreams, connectedNodes, acks, nacks, nodeVersions)
+}
+
+const (
+	versionAcked  = "acked"
+	versionNacked = "nacked"
+)
+
+// metricsCallbacks tracks connected nodes and the versions they have acked or nacked
+type metricsCallbacks struct {
+	lock    sync.Mutex
+	streams map[int64]*streamState
+}
+
+type streamState struct {
+	node string
+	// the last response sent for each type url
+	responses map[string]*v2.DiscoveryResponse
+	// the labels of the version info currently exported for each type url
+	versions map[string]prometheus.Labels
+}
+
+func newMetricsCallbacks() *metricsCallbacks {
+	return &metricsCallbacks{streams: make(map[int64]*streamState)}
+}
+
+func (c *metricsCallbacks) OnStreamOpen(id int64, _ string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.streams[id] = &streamState{
+		responses: make(map[string]*v2.DiscoveryResponse),
+		versions:  make(map[string]prometheus.Labels),
+	}
+	openStreams.Inc()
+}
+
+func (c *metricsCallbacks) OnStreamClosed(id int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if stream, ok := c.streams[id]; ok {
+		for _, labels := range stream.versions {
+			nodeVersions.Delete(labels)
+		}
+		delete(c.streams, id)
+	}
+	openStreams.Dec()
+	c.updateConnectedNodes()
+}
+
+func (c *metricsCallbacks) OnStreamRequest(id int64, req *v2.DiscoveryRequest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stream, ok := c.streams[id]
+	if !ok {
+		return
+	}
+	// envoy only sends its node on the first request of a stream
+	if stream.node == "" && req.Node != nil {
+		stream.node = req.Node.Id
+		c.updateConnectedNodes()
+	}
+
+	// a request carrying the nonce of the last response acks or nacks it
+	res, ok := stream.responses[req.TypeUrl]
+	if !ok || req.ResponseNonce == "" || req.ResponseNonce != res.Nonce {
+		return
+	}
+	state := versionAcked
+	if req.ErrorDetail != nil {
+		state = versionNacked
+		nacks.WithLabelValues(req.TypeUrl).Inc()
+	} else {
+		acks.WithLabelValues(req.TypeUrl).Inc()
+	}
+	if previous, ok := stream.versions[req.TypeUrl]; ok {
+		nodeVersions.Delete(previous)
+	}
+	labels := prometheus.Labels{"node": stream.node, "type_url": req.TypeUrl, "version": res.VersionInfo, "state": state}
+	nodeVersions.With(labels).Set(1)
+	stream.versions[req.TypeUrl] = labels
+}
+
+func (c *metricsCallbacks) OnStreamResponse(id int64, req *v2.DiscoveryRequest, res *v2.DiscoveryResponse) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if stream, ok := c.streams[id]; ok {
+		stream.responses[res.TypeUrl] = res
+	}
+}
+
+func (c *metricsCallbacks) OnFetchRequest(*v2.DiscoveryRequest) {}
+
+func (c *metricsCallbacks) OnFetchResponse(*v2.DiscoveryRequest, *v2.DiscoveryResponse) {}
+
+// must be called with the lock held
+func (c *metricsCallbacks) updateConnectedNodes() {
+	nodes := make(map[string]bool)
+	for _, stream := range c.streams {
+		if stream.node != "" {
+			nodes[stream.node] = true
+		}
+	}
+	connectedNodes.Set(float64(len(nodes)))
+}
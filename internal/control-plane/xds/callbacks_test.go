@@ -0,0 +1,50 @@
+package xds
+
+import (
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoycore "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/gogo/googleapis/google/rpc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = Describe("metricsCallbacks", func() {
+	const typeUrl = "type.googleapis.com/envoy.api.v2.Cluster"
+	var c *metricsCallbacks
+	BeforeEach(func() {
+		c = newMetricsCallbacks()
+		c.OnStreamOpen(1, typeUrl)
+		c.OnStreamRequest(1, &v2.DiscoveryRequest{Node: &envoycore.Node{Id: "envoy-1"}, TypeUrl: typeUrl})
+		c.OnStreamResponse(1, nil, &v2.DiscoveryResponse{TypeUrl: typeUrl, VersionInfo: "v1", Nonce: "1"})
+	})
+	AfterEach(func() {
+		c.OnStreamClosed(1)
+	})
+	It("records the version acked by the node", func() {
+		c.OnStreamRequest(1, &v2.DiscoveryRequest{TypeUrl: typeUrl, VersionInfo: "v1", ResponseNonce: "1"})
+		Expect(c.streams[1].versions[typeUrl]).To(Equal(prometheus.Labels{
+			"node": "envoy-1", "type_url": typeUrl, "version": "v1", "state": versionAcked,
+		}))
+	})
+	It("records the version nacked by the node", func() {
+		c.OnStreamRequest(1, &v2.DiscoveryRequest{
+			TypeUrl:       typeUrl,
+			ResponseNonce: "1",
+			ErrorDetail:   &rpc.Status{Message: "bad cluster"},
+		})
+		Expect(c.streams[1].versions[typeUrl]).To(Equal(prometheus.Labels{
+			"node": "envoy-1", "type_url": typeUrl, "version": "v1", "state": versionNacked,
+		}))
+	})
+	It("ignores requests for stale responses", func() {
+		c.OnStreamResponse(1, nil, &v2.DiscoveryResponse{TypeUrl: typeUrl, VersionInfo: "v2", Nonce: "2"})
+		c.OnStreamRequest(1, &v2.DiscoveryRequest{TypeUrl: typeUrl, VersionInfo: "v1", ResponseNonce: "1"})
+		Expect(c.streams[1].versions).To(BeEmpty())
+	})
+	It("forgets the stream when it closes", func() {
+		c.OnStreamClosed(1)
+		Expect(c.streams).To(BeEmpty())
+		c.OnStreamOpen(1, typeUrl)
+	})
+})
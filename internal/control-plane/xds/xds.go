@@ -40,6 +40,12 @@ func RunXDS(port int) (envoycache.SnapshotCache, *grpc.Server, error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to listen: %v", err)
 	}
+	cache, grpcServer := ServeXDS(lis)
+	return cache, grpcServer, nil
+}
+
+// ServeXDS serves xds on a listener the caller opened, e.g. on a port picked by the system
+func ServeXDS(lis net.Listener) (envoycache.SnapshotCache, *grpc.Server) {
 	envoyCache := envoycache.NewSnapshotCache(true, hasher{}, &logger{})
 	grpcServer := grpc.NewServer(grpc.StreamInterceptor(
 		grpc_middleware.ChainStreamServer(
@@ -51,7 +57,7 @@ func RunXDS(port int) (envoycache.SnapshotCache, *grpc.Server, error) {
 			},
 		)),
 	)
-	xdsServer := xds.NewServer(envoyCache, nil)
+	xdsServer := xds.NewServer(envoyCache, newMetricsCallbacks())
 	envoyv2.RegisterAggregatedDiscoveryServiceServer(grpcServer, xdsServer)
 	v2.RegisterEndpointDiscoveryServiceServer(grpcServer, xdsServer)
 	v2.RegisterClusterDiscoveryServiceServer(grpcServer, xdsServer)
@@ -59,10 +65,10 @@ func RunXDS(port int) (envoycache.SnapshotCache, *grpc.Server, error) {
 	v2.RegisterListenerDiscoveryServiceServer(grpcServer, xdsServer)
 
 	go func() {
-		log.Debugf("xDS server listening on %s", port)
-		if err = grpcServer.Serve(lis); err != nil {
+		log.Debugf("xDS server listening on %v", lis.Addr())
+		if err := grpcServer.Serve(lis); err != nil {
 			log.Fatalf("failed to serve grpc: %v", err)
 		}
 	}()
-	return envoyCache, grpcServer, nil
+	return envoyCache, grpcServer
 }
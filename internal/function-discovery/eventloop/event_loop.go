@@ -22,10 +22,13 @@ import (
 	"github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/bootstrap/artifactstorage"
 	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
+	leaderelectionsetup "github.com/solo-io/gloo/pkg/bootstrap/leaderelection"
 	"github.com/solo-io/gloo/pkg/bootstrap/secretstorage"
 	secretwatchersetup "github.com/solo-io/gloo/pkg/bootstrap/secretwatcher"
+	"github.com/solo-io/gloo/pkg/leaderelection"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage"
 )
 
 const (
@@ -38,10 +41,21 @@ type workItem struct {
 }
 
 func Run(opts bootstrap.Options, discoveryOpts options.DiscoveryOptions, stop <-chan struct{}, errs chan error) error {
+	elector, err := leaderelectionsetup.Bootstrap(opts, "gloo-function-discovery")
+	if err != nil {
+		return errors.Wrap(err, "failed to set up leader election")
+	}
+	go func() {
+		if err := elector.Run(stop); err != nil {
+			errs <- errors.Wrap(err, "leader election stopped")
+		}
+	}()
+
 	store, err := configstorage.Bootstrap(opts)
 	if err != nil {
 		return errors.Wrap(err, "failed to create config store client")
 	}
+	store = leaderelection.Storage(store, elector)
 
 	upstreams, err := upstreamwatcher.WatchUpstreams(store, stop, errs)
 	if err != nil {
@@ -57,6 +71,7 @@ func Run(opts bootstrap.Options, discoveryOpts options.DiscoveryOptions, stop <-
 	if err != nil {
 		return errors.Wrap(err, "failed to set up secret storage client")
 	}
+	secretStore = leaderelection.SecretStorage(secretStore, elector)
 
 	go secretWatcher.Run(stop)
 
@@ -97,10 +112,11 @@ func Run(opts bootstrap.Options, discoveryOpts options.DiscoveryOptions, stop <-
 
 	updateUpstream := func(us *v1.Upstream, secrets secretwatcher.SecretMap) {
 		log.Debugf("attempting update for %v", us.Name)
-		if err := updater.UpdateServiceInfo(store, us.Name, marker); err != nil {
+		// leadership may have been lost since the work was queued
+		if err := updater.UpdateServiceInfo(store, us.Name, marker); err != nil && !storage.IsNotLeader(err) {
 			errs <- errors.Wrapf(err, "updating upstream %v", us.Name)
 		}
-		if err := updater.UpdateFunctions(resolve, store, secretStore, us.Name, secrets); err != nil {
+		if err := updater.UpdateFunctions(resolve, store, secretStore, us.Name, secrets); err != nil && !storage.IsNotLeader(err) {
 			errs <- errors.Wrapf(err, "updating upstream %v", us.Name)
 		}
 	}
@@ -135,6 +151,11 @@ func Run(opts bootstrap.Options, discoveryOpts options.DiscoveryOptions, stop <-
 			secretWatcher.TrackSecrets(refs)
 		}(cache.upstreams)
 
+		// replicas that are not the leader keep watching upstreams and secrets, but don't discover functions
+		if !elector.IsLeader() {
+			return
+		}
+
 		for _, us := range cache.upstreams {
 			_, ok := workQueues[us.Name]
 			if !ok {
@@ -154,10 +175,14 @@ func Run(opts bootstrap.Options, discoveryOpts options.DiscoveryOptions, stop <-
 		}
 	}
 
+	elected := elector.Elected()
 	ticker := time.NewTicker(opts.ConfigStorageOptions.SyncFrequency)
 	defer ticker.Stop()
 	for {
 		select {
+		case <-elected:
+			log.Printf("elected leader, discovering functions")
+			update()
 		case cache.secrets = <-secretWatcher.Secrets():
 			update()
 		case cache.upstreams = <-upstreams:
@@ -0,0 +1,27 @@
+package updater
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/solo-io/gloo/internal/function-discovery/functiontypes"
+	"github.com/solo-io/gloo/pkg/metrics"
+)
+
+var functionDiscoveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "function_discovery",
+	Name:      "discoveries_total",
+	Help:      "attempts to discover the functions of an upstream, by upstream type, function type and result",
+}, []string{"upstream_type", "function_type", "result"})
+
+func init() {
+	prometheus.MustRegister(functionDiscoveries)
+}
+
+func recordDiscovery(upstreamType string, functionType functiontypes.FunctionType, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	functionDiscoveries.WithLabelValues(upstreamType, string(functionType), result).Inc()
+}
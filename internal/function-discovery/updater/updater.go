@@ -61,9 +61,20 @@ func UpdateFunctions(resolve resolver.Resolver, gloo storage.Interface, secretSt
 	if err != nil {
 		return errors.Wrapf(err, "failed to get existing upstream with name %v", upstreamName)
 	}
+	functionType := functiontypes.GetFunctionType(us)
+	err = updateFunctions(resolve, gloo, secretStore, us, functionType, secrets)
+	if functionType != functiontypes.NonFunctional {
+		recordDiscovery(us.Type, functionType, err)
+	}
+	return err
+}
 
-	var funcs []*v1.Function
-	switch functiontypes.GetFunctionType(us) {
+func updateFunctions(resolve resolver.Resolver, gloo storage.Interface, secretStore dependencies.SecretStorage, us *v1.Upstream, functionType functiontypes.FunctionType, secrets secretwatcher.SecretMap) error {
+	var (
+		funcs []*v1.Function
+		err   error
+	)
+	switch functionType {
 	case functiontypes.FunctionTypeLambda:
 		if len(secrets) == 0 {
 			log.Warnf("lambda upstream detected, but no secrets have been read yet")
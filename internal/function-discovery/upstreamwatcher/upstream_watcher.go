@@ -18,7 +18,7 @@ func WatchUpstreams(gloo storage.Interface,
 		}
 	}
 
-	w, err := gloo.V1().Upstreams().Watch(storage.UpstreamEventHandlerFuncs{
+	w, err := gloo.V1().Upstreams().Watch(nil, storage.UpstreamEventHandlerFuncs{
 		AddFunc:    syncFunc,
 		UpdateFunc: syncFunc,
 	})
@@ -0,0 +1,352 @@
+package glooctl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/config"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+// kinds of resources that are exported and imported
+const (
+	kindUpstreams    = "upstreams"
+	kindVirtualHosts = "virtualhosts"
+	kindSecrets      = "secrets"
+	kindArtifacts    = "artifacts"
+)
+
+var allKinds = []string{kindUpstreams, kindVirtualHosts, kindSecrets, kindArtifacts}
+
+// resources are the contents of an archive. in an archive, every resource is a file in the directory of its kind:
+// upstreams and virtual hosts as yaml named after the object, secrets as a yaml map of their data at
+// <ref>.yml, and artifacts as their raw contents at <ref>. secrets whose ref is prefixed with a scheme are
+// stored in a directory of their own, secrets.<scheme>, so their names cannot clash with the refs of
+// unprefixed secrets, which may contain slashes themselves
+type resources struct {
+	upstreams    []*v1.Upstream
+	virtualHosts []*v1.VirtualHost
+	secrets      []*dependencies.Secret
+	artifacts    []*dependencies.File
+}
+
+// portable returns the resources without what only has meaning in the storage backend they were read from:
+// resource versions and statuses. objects generated by discovery are dropped if skipGenerated is set,
+// so discovery can recreate them in the new backend
+func (r *resources) portable(skipGenerated bool) *resources {
+	out := &resources{}
+	for _, us := range r.upstreams {
+		if skipGenerated && isGenerated(us.Metadata) {
+			continue
+		}
+		us = proto.Clone(us).(*v1.Upstream)
+		us.Metadata = portableMetadata(us.Metadata)
+		us.Status = nil
+		out.upstreams = append(out.upstreams, us)
+	}
+	for _, vh := range r.virtualHosts {
+		if skipGenerated && isGenerated(vh.Metadata) {
+			continue
+		}
+		vh = proto.Clone(vh).(*v1.VirtualHost)
+		vh.Metadata = portableMetadata(vh.Metadata)
+		vh.Status = nil
+		out.virtualHosts = append(out.virtualHosts, vh)
+	}
+	for _, secret := range r.secrets {
+		out.secrets = append(out.secrets, &dependencies.Secret{Ref: secret.Ref, Data: secret.Data})
+	}
+	for _, file := range r.artifacts {
+		out.artifacts = append(out.artifacts, &dependencies.File{Ref: file.Ref, Contents: file.Contents})
+	}
+	return out
+}
+
+func isGenerated(metadata *v1.Metadata) bool {
+	_, ok := metadata.GetAnnotations()[config.OwnerAnnotationKey]
+	return ok
+}
+
+func portableMetadata(metadata *v1.Metadata) *v1.Metadata {
+	if metadata == nil {
+		return nil
+	}
+	metadata.ResourceVersion = ""
+	if metadata.Namespace == "" && len(metadata.Annotations) == 0 && len(metadata.Labels) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// archivePath returns the path of the resource's file in the archive
+func archivePath(kind, ref string) (string, error) {
+	dir, name := kind, ref
+	if kind == kindSecrets {
+		scheme, schemeName := dependencies.ParseSecretRef(ref)
+		if scheme != "" {
+			if strings.ContainsAny(scheme, "/.") {
+				return "", errors.Errorf("%v %q cannot be stored in an archive", kind, ref)
+			}
+			dir, name = kindSecrets+"."+scheme, schemeName
+		}
+	}
+	clean := path.Clean(name)
+	if name == "" || clean != name || path.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", errors.Errorf("%v %q cannot be stored in an archive", kind, ref)
+	}
+	switch kind {
+	case kindUpstreams, kindVirtualHosts, kindSecrets:
+		return path.Join(dir, name+".yml"), nil
+	}
+	return path.Join(dir, name), nil
+}
+
+// files returns the contents of the archive's files by path
+func (r *resources) files() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	add := func(kind, ref string, data []byte) error {
+		p, err := archivePath(kind, ref)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[p]; ok {
+			return errors.Errorf("%v %v is given more than once", kind, ref)
+		}
+		files[p] = data
+		return nil
+	}
+	for _, us := range r.upstreams {
+		data, err := toYaml(us)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting upstream %v", us.Name)
+		}
+		if err := add(kindUpstreams, us.Name, data); err != nil {
+			return nil, err
+		}
+	}
+	for _, vh := range r.virtualHosts {
+		data, err := toYaml(vh)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting virtual host %v", vh.Name)
+		}
+		if err := add(kindVirtualHosts, vh.Name, data); err != nil {
+			return nil, err
+		}
+	}
+	for _, secret := range r.secrets {
+		data, err := yaml.Marshal(secret.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting secret %v", secret.Ref)
+		}
+		if err := add(kindSecrets, secret.Ref, data); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range r.artifacts {
+		if err := add(kindArtifacts, file.Ref, file.Contents); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// fromFiles parses the files of an archive
+func fromFiles(files map[string][]byte) (*resources, error) {
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	r := &resources{}
+	for _, p := range paths {
+		data := files[p]
+		parts := strings.SplitN(p, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		kind, ref := parts[0], parts[1]
+		var scheme string
+		if strings.HasPrefix(kind, kindSecrets+".") {
+			kind, scheme = kindSecrets, strings.TrimPrefix(kind, kindSecrets+".")
+		}
+		switch kind {
+		case kindUpstreams:
+			var us v1.Upstream
+			if err := fromYaml(data, &us); err != nil {
+				return nil, errors.Wrapf(err, "parsing %v", p)
+			}
+			r.upstreams = append(r.upstreams, &us)
+		case kindVirtualHosts:
+			var vh v1.VirtualHost
+			if err := fromYaml(data, &vh); err != nil {
+				return nil, errors.Wrapf(err, "parsing %v", p)
+			}
+			r.virtualHosts = append(r.virtualHosts, &vh)
+		case kindSecrets:
+			var secretData map[string]string
+			if err := yaml.Unmarshal(data, &secretData); err != nil {
+				return nil, errors.Wrapf(err, "parsing %v", p)
+			}
+			r.secrets = append(r.secrets, &dependencies.Secret{Ref: dependencies.SchemeRef(scheme, strings.TrimSuffix(ref, ".yml")), Data: secretData})
+		case kindArtifacts:
+			r.artifacts = append(r.artifacts, &dependencies.File{Ref: ref, Contents: data})
+		}
+	}
+	return r, nil
+}
+
+func toYaml(pb proto.Message) ([]byte, error) {
+	jsn, err := protoutil.Marshal(pb)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(jsn)
+}
+
+func fromYaml(data []byte, pb proto.Message) error {
+	jsn, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return err
+	}
+	return protoutil.Unmarshal(jsn, pb)
+}
+
+// isTarball returns true if the archive at the path is a gzipped tarball rather than a directory
+func isTarball(archive string) bool {
+	return strings.HasSuffix(archive, ".tar.gz") || strings.HasSuffix(archive, ".tgz")
+}
+
+// writeArchive writes the resources to a directory, or a gzipped tarball if the path ends with .tar.gz or .tgz
+func writeArchive(archive string, r *resources) error {
+	files, err := r.files()
+	if err != nil {
+		return err
+	}
+	if isTarball(archive) {
+		return writeTarball(archive, files)
+	}
+	for p, data := range files {
+		filename := filepath.Join(archive, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filename, data, 0600); err != nil {
+			return errors.Wrapf(err, "writing %v", filename)
+		}
+	}
+	return nil
+}
+
+func writeTarball(archive string, files map[string][]byte) error {
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, p := range paths {
+		data := files[p]
+		if err := tw.WriteHeader(&tar.Header{Name: p, Mode: 0600, Size: int64(len(data)), ModTime: now}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(archive, buf.Bytes(), 0600)
+}
+
+// readArchive reads the resources from a directory or gzipped tarball written by writeArchive
+func readArchive(archive string) (*resources, error) {
+	var (
+		files map[string][]byte
+		err   error
+	)
+	if isTarball(archive) {
+		files, err = readTarball(archive)
+	} else {
+		files, err = readDir(archive)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading archive %v", archive)
+	}
+	return fromFiles(files)
+}
+
+func readDir(dir string) (map[string][]byte, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	return files, err
+}
+
+func readTarball(archive string) (map[string][]byte, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(header.Name)] = data
+	}
+}
@@ -0,0 +1,206 @@
+package glooctl_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/solo-io/gloo/internal/glooctl"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	filestorage "github.com/solo-io/gloo/pkg/storage/file"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("glooctl", func() {
+	var (
+		dir       string
+		configDir string
+		store     storage.Interface
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "glooctltest")
+		Must(err)
+		configDir = filepath.Join(dir, "_gloo_config")
+		store, err = filestorage.NewStorage(configDir, 0)
+		Must(err)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	run := func(args ...string) (string, error) {
+		cmd := NewRootCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs(append([]string{"--storage.type", "file", "--file.config.dir", configDir}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		Must(ioutil.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	Describe("upstreams", func() {
+		It("creates an upstream from a scaffold", func() {
+			scaffold, err := run("upstream", "scaffold", "aws", "my-lambdas")
+			Expect(err).NotTo(HaveOccurred())
+			out, err := run("upstream", "create", "-f", writeFile("us.yml", scaffold))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("upstream my-lambdas created"))
+
+			us, err := store.V1().Upstreams().Get("my-lambdas")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(us.Type).To(Equal("aws"))
+			Expect(us.Functions).To(HaveLen(1))
+		})
+		It("scaffolds every supported type", func() {
+			for _, t := range []string{"aws", "azure", "google", "kubernetes", "consul", "service"} {
+				scaffold, err := run("upstream", "scaffold", t)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = run("upstream", "create", "-f", writeFile(t+".yml", scaffold))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			out, err := run("upstream", "list")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("my-google-upstream"))
+			Expect(out).To(ContainSubstring("Pending"))
+		})
+		It("rejects an unknown upstream type for scaffolding", func() {
+			_, err := run("upstream", "scaffold", "nope")
+			Expect(err).To(HaveOccurred())
+		})
+		It("rejects specs with fields the plugin does not know", func() {
+			_, err := run("upstream", "create", "-f", writeFile("us.yml", `
+name: bad-aws
+type: aws
+spec:
+  region: us-east-1
+  secret_ref: my-secret
+  regoin: typo
+`))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("regoin"))
+			_, err = store.V1().Upstreams().Get("bad-aws")
+			Expect(err).To(HaveOccurred())
+		})
+		It("rejects specs the plugin cannot decode", func() {
+			_, err := run("upstream", "create", "-f", writeFile("us.yml", `
+name: bad-kube
+type: kubernetes
+spec:
+  service_port: not-a-number
+`))
+			Expect(err).To(HaveOccurred())
+		})
+		It("updates and deletes an upstream", func() {
+			_, err := run("upstream", "create", "-f", writeFile("us.yml", `
+name: svc
+type: service
+spec:
+  hosts:
+  - addr: localhost
+    port: 1234
+`))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = run("upstream", "update", "-f", writeFile("us2.yml", `
+name: svc
+type: service
+spec:
+  hosts:
+  - addr: localhost
+    port: 5678
+`))
+			Expect(err).NotTo(HaveOccurred())
+			out, err := run("upstream", "get", "svc", "-o", "yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("5678"))
+
+			_, err = run("upstream", "delete", "svc")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = store.V1().Upstreams().Get("svc")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("virtual hosts and routes", func() {
+		BeforeEach(func() {
+			_, err := run("virtualhost", "create", "-f", writeFile("vh.yml", `
+name: my-vhost
+domains:
+- example.com
+routes:
+- request_matcher:
+    path_prefix: /a
+  single_destination:
+    upstream:
+      name: a
+`))
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("adds routes at an index and removes them", func() {
+			route := writeFile("route.yml", `
+request_matcher:
+  path_exact: /b
+single_destination:
+  function:
+    upstream_name: b
+    function_name: fn
+`)
+			out, err := run("route", "add", "my-vhost", "-f", route, "--index", "0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("route added to virtual host my-vhost at index 0"))
+
+			vh, err := store.V1().VirtualHosts().Get("my-vhost")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vh.Routes).To(HaveLen(2))
+			Expect(vh.Routes[0].GetRequestMatcher().GetPathExact()).To(Equal("/b"))
+
+			out, err = run("route", "list", "my-vhost")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("b/fn"))
+
+			_, err = run("route", "remove", "my-vhost", "0")
+			Expect(err).NotTo(HaveOccurred())
+			vh, err = store.V1().VirtualHosts().Get("my-vhost")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vh.Routes).To(HaveLen(1))
+			Expect(vh.Routes[0].GetRequestMatcher().GetPathPrefix()).To(Equal("/a"))
+
+			out, err = run("route", "remove", "my-vhost", "5")
+			Expect(err).To(HaveOccurred())
+			Expect(out).NotTo(ContainSubstring("route 5 removed"))
+		})
+		It("rejects routes with invalid extensions", func() {
+			_, err := run("route", "add", "my-vhost", "-f", writeFile("route.yml", `
+request_matcher:
+  path_prefix: /c
+single_destination:
+  upstream:
+    name: c
+extensions:
+  max_retries: many
+`))
+			Expect(err).To(HaveOccurred())
+		})
+		It("shows the status of every config object", func() {
+			vh, err := store.V1().VirtualHosts().Get("my-vhost")
+			Expect(err).NotTo(HaveOccurred())
+			vh.Status = &v1.Status{State: v1.Status_Rejected, Reason: "upstream a not found"}
+			_, err = store.V1().VirtualHosts().Update(vh)
+			Expect(err).NotTo(HaveOccurred())
+
+			out, err := run("status")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("virtualhost"))
+			Expect(out).To(ContainSubstring("Rejected"))
+			Expect(out).To(ContainSubstring("upstream a not found"))
+		})
+	})
+})
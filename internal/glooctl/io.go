@@ -0,0 +1,113 @@
+package glooctl
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+// readInto reads a yaml or json object from the file, or stdin if the filename is -
+func readInto(filename string, stdin io.Reader, into proto.Message) error {
+	var (
+		data []byte
+		err  error
+	)
+	if filename == "-" {
+		data, err = ioutil.ReadAll(stdin)
+	} else {
+		data, err = ioutil.ReadFile(filename)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading %v", filename)
+	}
+	jsn, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %v", filename)
+	}
+	if err := protoutil.Unmarshal(jsn, into); err != nil {
+		return errors.Wrapf(err, "parsing %v", filename)
+	}
+	return nil
+}
+
+func printObject(w io.Writer, format string, pb proto.Message) error {
+	jsn, err := protoutil.Marshal(pb)
+	if err != nil {
+		return errors.Wrap(err, "marshalling output")
+	}
+	switch format {
+	case formatJSON:
+		_, err = fmt.Fprintln(w, string(jsn))
+		return err
+	case formatYAML, "":
+		data, err := yaml.JSONToYAML(jsn)
+		if err != nil {
+			return errors.Wrap(err, "converting output to yaml")
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	return errors.Errorf("unknown output format %v", format)
+}
+
+// printObjects prints each object as a separate yaml document or json object
+func printObjects(w io.Writer, format string, pbs []proto.Message) error {
+	for i, pb := range pbs {
+		if format != formatJSON && i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		if err := printObject(w, format, pb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func statusColumns(status *v1.Status) []string {
+	if status == nil {
+		return []string{"Pending", ""}
+	}
+	return []string{status.State.String(), status.Reason}
+}
+
+// objects written by the user keep the resource version they were read with,
+// otherwise the current version in storage is used
+func resourceVersion(metadata *v1.Metadata) string {
+	if metadata == nil {
+		return ""
+	}
+	return metadata.ResourceVersion
+}
+
+func withResourceVersion(metadata *v1.Metadata, version string) *v1.Metadata {
+	if metadata == nil {
+		metadata = &v1.Metadata{}
+	}
+	if metadata.ResourceVersion == "" {
+		metadata.ResourceVersion = version
+	}
+	return metadata
+}
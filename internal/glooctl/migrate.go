@@ -0,0 +1,351 @@
+package glooctl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/solo-io/gloo/pkg/bootstrap/artifactstorage"
+	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
+	"github.com/solo-io/gloo/pkg/bootstrap/secretstorage"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+// what to do when a resource being imported already exists
+const (
+	conflictFail      = "fail"
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+)
+
+type importOptions struct {
+	kinds         []string
+	skipGenerated bool
+	dryRun        bool
+	onConflict    string
+}
+
+func addKindsFlags(cmd *cobra.Command, opts *importOptions) {
+	cmd.Flags().StringSliceVar(&opts.kinds, "kinds", allKinds, "kinds of resources to copy. any of "+strings.Join(allKinds, ", "))
+	cmd.Flags().BoolVar(&opts.skipGenerated, "skip-generated", false, "drop upstreams and virtual hosts with a generated_by annotation, so discovery can recreate them")
+}
+
+func addImportFlags(cmd *cobra.Command, opts *importOptions) {
+	addKindsFlags(cmd, opts)
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "print what would be written without writing anything")
+	cmd.Flags().StringVar(&opts.onConflict, "on-conflict", conflictFail, fmt.Sprintf("what to do with resources that already exist: %v writes nothing if any exist, %v keeps the existing ones, %v replaces them", conflictFail, conflictSkip, conflictOverwrite))
+}
+
+func (o *importOptions) validate() error {
+	for _, kind := range o.kinds {
+		if !contains(allKinds, kind) {
+			return errors.Errorf("unknown kind %v. must be one of %v", kind, strings.Join(allKinds, ", "))
+		}
+	}
+	switch o.onConflict {
+	case conflictFail, conflictSkip, conflictOverwrite:
+		return nil
+	}
+	return errors.Errorf("unknown conflict policy %v. must be one of %v, %v or %v", o.onConflict, conflictFail, conflictSkip, conflictOverwrite)
+}
+
+func (o *importOptions) includes(kind string) bool {
+	return contains(o.kinds, kind)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// backends are the storage clients for the kinds of resources being copied. clients for other kinds are nil
+type backends struct {
+	config    storage.Interface
+	secrets   dependencies.SecretStorage
+	artifacts dependencies.FileStorage
+}
+
+func openBackends(opts bootstrap.Options, kinds *importOptions) (*backends, error) {
+	b := &backends{}
+	if kinds.includes(kindUpstreams) || kinds.includes(kindVirtualHosts) {
+		store, err := configstorage.Bootstrap(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create config store client")
+		}
+		if err := store.V1().Register(); err != nil && !storage.IsAlreadyExists(err) {
+			return nil, errors.Wrap(err, "failed to register storage")
+		}
+		b.config = store
+	}
+	if kinds.includes(kindSecrets) {
+		secrets, err := secretstorage.Bootstrap(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create secret storage client")
+		}
+		b.secrets = secrets
+	}
+	if kinds.includes(kindArtifacts) {
+		artifacts, err := artifactstorage.Bootstrap(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create artifact storage client")
+		}
+		b.artifacts = artifacts
+	}
+	return b, nil
+}
+
+// export reads every resource of the given kinds
+func (b *backends) export(kinds *importOptions) (*resources, error) {
+	r := &resources{}
+	var err error
+	if kinds.includes(kindUpstreams) {
+		if r.upstreams, err = b.config.V1().Upstreams().List(nil); err != nil {
+			return nil, errors.Wrap(err, "failed to list upstreams")
+		}
+	}
+	if kinds.includes(kindVirtualHosts) {
+		if r.virtualHosts, err = b.config.V1().VirtualHosts().List(nil); err != nil {
+			return nil, errors.Wrap(err, "failed to list virtual hosts")
+		}
+	}
+	if kinds.includes(kindSecrets) {
+		if r.secrets, err = b.secrets.List(); err != nil {
+			return nil, errors.Wrap(err, "failed to list secrets")
+		}
+	}
+	if kinds.includes(kindArtifacts) {
+		if r.artifacts, err = b.artifacts.List(); err != nil {
+			return nil, errors.Wrap(err, "failed to list artifacts")
+		}
+	}
+	return r.portable(kinds.skipGenerated), nil
+}
+
+// importStep writes one resource
+type importStep struct {
+	kind   string
+	ref    string
+	exists bool
+	// write creates the resource, or replaces the existing one
+	write func() error
+}
+
+// importSteps returns the steps that write the resources, dependencies first
+func (b *backends) importSteps(r *resources, kinds *importOptions) []importStep {
+	var steps []importStep
+	if kinds.includes(kindSecrets) {
+		for _, secret := range r.secrets {
+			secret := secret
+			existing, err := b.secrets.Get(secret.Ref)
+			exists := err == nil
+			steps = append(steps, importStep{kind: "secret", ref: secret.Ref, exists: exists, write: func() error {
+				if !exists {
+					_, err := b.secrets.Create(secret)
+					return err
+				}
+				secret.ResourceVersion = existing.ResourceVersion
+				_, err := b.secrets.Update(secret)
+				return err
+			}})
+		}
+	}
+	if kinds.includes(kindArtifacts) {
+		for _, file := range r.artifacts {
+			file := file
+			existing, err := b.artifacts.Get(file.Ref)
+			exists := err == nil
+			steps = append(steps, importStep{kind: "artifact", ref: file.Ref, exists: exists, write: func() error {
+				if !exists {
+					_, err := b.artifacts.Create(file)
+					return err
+				}
+				file.ResourceVersion = existing.ResourceVersion
+				_, err := b.artifacts.Update(file)
+				return err
+			}})
+		}
+	}
+	if kinds.includes(kindUpstreams) {
+		client := b.config.V1().Upstreams()
+		for _, us := range r.upstreams {
+			us := us
+			existing, err := client.Get(us.Name)
+			exists := err == nil
+			steps = append(steps, importStep{kind: "upstream", ref: us.Name, exists: exists, write: func() error {
+				if !exists {
+					_, err := client.Create(us)
+					return err
+				}
+				us.Metadata = withResourceVersion(us.Metadata, existing.GetMetadata().GetResourceVersion())
+				_, err := client.Update(us)
+				return err
+			}})
+		}
+	}
+	if kinds.includes(kindVirtualHosts) {
+		client := b.config.V1().VirtualHosts()
+		for _, vh := range r.virtualHosts {
+			vh := vh
+			existing, err := client.Get(vh.Name)
+			exists := err == nil
+			steps = append(steps, importStep{kind: "virtual host", ref: vh.Name, exists: exists, write: func() error {
+				if !exists {
+					_, err := client.Create(vh)
+					return err
+				}
+				vh.Metadata = withResourceVersion(vh.Metadata, existing.GetMetadata().GetResourceVersion())
+				_, err := client.Update(vh)
+				return err
+			}})
+		}
+	}
+	return steps
+}
+
+// importResources writes the resources, printing what is done with each.
+// with the fail policy, nothing is written if any of the resources already exists
+func (b *backends) importResources(w io.Writer, r *resources, opts *importOptions) error {
+	r = r.portable(opts.skipGenerated)
+	steps := b.importSteps(r, opts)
+	if opts.onConflict == conflictFail {
+		var existing []string
+		for _, step := range steps {
+			if step.exists {
+				existing = append(existing, step.kind+" "+step.ref)
+			}
+		}
+		if len(existing) > 0 {
+			return errors.Errorf("already exist: %v. use --on-conflict=%v or --on-conflict=%v to import anyway", strings.Join(existing, ", "), conflictSkip, conflictOverwrite)
+		}
+	}
+	for _, step := range steps {
+		action := "created"
+		if step.exists {
+			if opts.onConflict == conflictSkip {
+				fmt.Fprintf(w, "%v %v skipped: already exists\n", step.kind, step.ref)
+				continue
+			}
+			action = "overwritten"
+		}
+		if opts.dryRun {
+			fmt.Fprintf(w, "%v %v would be %v\n", step.kind, step.ref, action)
+			continue
+		}
+		if err := step.write(); err != nil {
+			return errors.Wrapf(err, "failed to import %v %v", step.kind, step.ref)
+		}
+		fmt.Fprintf(w, "%v %v %v\n", step.kind, step.ref, action)
+	}
+	return nil
+}
+
+func exportCmd(opts *options) *cobra.Command {
+	exportOpts := &importOptions{}
+	cmd := &cobra.Command{
+		Use:   "export PATH",
+		Short: "export upstreams, virtual hosts, secrets and artifacts to a directory, or a gzipped tarball if PATH ends with .tar.gz",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exportOpts.onConflict = conflictFail
+			if err := exportOpts.validate(); err != nil {
+				return err
+			}
+			from, err := openBackends(opts.Options, exportOpts)
+			if err != nil {
+				return err
+			}
+			r, err := from.export(exportOpts)
+			if err != nil {
+				return err
+			}
+			if err := writeArchive(args[0], r); err != nil {
+				return errors.Wrapf(err, "failed to write archive %v", args[0])
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "exported %v upstreams, %v virtual hosts, %v secrets and %v artifacts to %v\n",
+				len(r.upstreams), len(r.virtualHosts), len(r.secrets), len(r.artifacts), args[0])
+			return nil
+		},
+	}
+	addKindsFlags(cmd, exportOpts)
+	return cmd
+}
+
+func importCmd(opts *options) *cobra.Command {
+	importOpts := &importOptions{}
+	cmd := &cobra.Command{
+		Use:   "import PATH",
+		Short: "import upstreams, virtual hosts, secrets and artifacts from a directory or gzipped tarball written by export",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := importOpts.validate(); err != nil {
+				return err
+			}
+			r, err := readArchive(args[0])
+			if err != nil {
+				return err
+			}
+			to, err := openBackends(opts.Options, importOpts)
+			if err != nil {
+				return err
+			}
+			return to.importResources(cmd.OutOrStdout(), r, importOpts)
+		},
+	}
+	addImportFlags(cmd, importOpts)
+	return cmd
+}
+
+// migrate reads from the storage backends given by the global storage flags,
+// and writes to those given by the same flags prefixed with "to."
+func migrateCmd(opts *options) *cobra.Command {
+	toOpts := &bootstrap.Options{}
+	importOpts := &importOptions{}
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "copy upstreams, virtual hosts, secrets and artifacts to the storage backends given by the --to.* flags",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := importOpts.validate(); err != nil {
+				return err
+			}
+			from, err := openBackends(opts.Options, importOpts)
+			if err != nil {
+				return err
+			}
+			r, err := from.export(importOpts)
+			if err != nil {
+				return err
+			}
+			to, err := openBackends(*toOpts, importOpts)
+			if err != nil {
+				return err
+			}
+			return to.importResources(cmd.OutOrStdout(), r, importOpts)
+		},
+	}
+	addImportFlags(cmd, importOpts)
+	addPrefixedStorageFlags(cmd, toOpts, "to.")
+	return cmd
+}
+
+// addPrefixedStorageFlags adds the storage flags for a second set of storage backends, with the prefix
+func addPrefixedStorageFlags(cmd *cobra.Command, opts *bootstrap.Options, prefix string) {
+	unprefixed := &cobra.Command{}
+	addStorageFlags(unprefixed, opts)
+	unprefixed.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		prefixed := *f
+		prefixed.Name = prefix + f.Name
+		prefixed.Shorthand = ""
+		cmd.Flags().AddFlag(&prefixed)
+	})
+}
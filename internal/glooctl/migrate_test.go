@@ -0,0 +1,216 @@
+package glooctl_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/solo-io/gloo/internal/glooctl"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	filedeps "github.com/solo-io/gloo/pkg/storage/dependencies/file"
+	memorydeps "github.com/solo-io/gloo/pkg/storage/dependencies/memory"
+	filestorage "github.com/solo-io/gloo/pkg/storage/file"
+	"github.com/solo-io/gloo/pkg/storage/memory"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("export, import and migrate", func() {
+	var dir string
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "glooctlmigratetest")
+		Must(err)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	// file storage for config objects, secrets and artifacts under the root directory
+	type fileBackends struct {
+		flags     []string
+		config    storage.Interface
+		secrets   dependencies.SecretStorage
+		artifacts dependencies.FileStorage
+	}
+	newFileBackends := func(name string) *fileBackends {
+		root := filepath.Join(dir, name)
+		configDir, secretDir, filesDir := filepath.Join(root, "config"), filepath.Join(root, "secrets"), filepath.Join(root, "files")
+		Must(os.MkdirAll(secretDir, 0755))
+		Must(os.MkdirAll(filesDir, 0755))
+		config, err := filestorage.NewStorage(configDir, 0)
+		Must(err)
+		secrets, err := filedeps.NewSecretStorage(secretDir, 0)
+		Must(err)
+		artifacts, err := filedeps.NewFileStorage(filesDir, 0)
+		Must(err)
+		return &fileBackends{
+			flags: []string{
+				"--storage.type", "file", "--file.config.dir", configDir,
+				"--secrets.type", "file", "--file.secret.dir", secretDir,
+				"--files.type", "file", "--file.files.dir", filesDir,
+			},
+			config:    config,
+			secrets:   secrets,
+			artifacts: artifacts,
+		}
+	}
+	run := func(b *fileBackends, args ...string) (string, error) {
+		cmd := NewRootCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs(append(append([]string{}, b.flags...), args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	var from, to *fileBackends
+	BeforeEach(func() {
+		from = newFileBackends("from")
+		to = newFileBackends("to")
+		_, err := from.config.V1().Upstreams().Create(&v1.Upstream{Name: "petstore", Type: "service",
+			Metadata: &v1.Metadata{Annotations: map[string]string{"owner": "team-a"}}})
+		Must(err)
+		_, err = from.config.V1().Upstreams().Create(&v1.Upstream{Name: "discovered", Type: "service",
+			Metadata: &v1.Metadata{Annotations: map[string]string{"generated_by": "kubernetes-upstream-discovery"}}})
+		Must(err)
+		_, err = from.config.V1().VirtualHosts().Create(&v1.VirtualHost{Name: "default", Domains: []string{"*"}})
+		Must(err)
+		_, err = from.secrets.Create(&dependencies.Secret{Ref: "aws", Data: map[string]string{"access_key": "a"}})
+		Must(err)
+		_, err = from.artifacts.Create(&dependencies.File{Ref: "descriptors", Contents: []byte{0, 1, 255}})
+		Must(err)
+	})
+
+	expectImported := func(b *fileBackends) {
+		us, err := b.config.V1().Upstreams().Get("petstore")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(us.Metadata.Annotations).To(Equal(map[string]string{"owner": "team-a"}))
+		_, err = b.config.V1().VirtualHosts().Get("default")
+		Expect(err).NotTo(HaveOccurred())
+		secret, err := b.secrets.Get("aws")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Data).To(Equal(map[string]string{"access_key": "a"}))
+		file, err := b.artifacts.Get("descriptors")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Contents).To(Equal([]byte{0, 1, 255}))
+	}
+
+	for _, archiveName := range []string{"archive", "archive.tar.gz"} {
+		archiveName := archiveName
+		It("exports to and imports from "+archiveName, func() {
+			archive := filepath.Join(dir, archiveName)
+			_, err := run(from, "export", archive)
+			Expect(err).NotTo(HaveOccurred())
+			out, err := run(to, "import", archive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("upstream petstore created"))
+			expectImported(to)
+			_, err = to.config.V1().Upstreams().Get("discovered")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	}
+	for _, archiveName := range []string{"archive", "archive.tar.gz"} {
+		archiveName := archiveName
+		It("exports to and imports from "+archiveName+" secrets prefixed with a scheme", func() {
+			schemed := memorydeps.DefaultSecretStorage()
+			defer schemed.Delete("aws/creds")
+			_, err := schemed.Create(&dependencies.Secret{Ref: "aws/creds", Data: map[string]string{"secret_key": "s"}})
+			Must(err)
+
+			archive := filepath.Join(dir, archiveName)
+			_, err = run(from, "export", archive, "--secrets.schemes", "memory")
+			Expect(err).NotTo(HaveOccurred())
+			Must(schemed.Delete("aws/creds"))
+
+			_, err = run(to, "import", archive, "--secrets.schemes", "memory")
+			Expect(err).NotTo(HaveOccurred())
+			secret, err := schemed.Get("aws/creds")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]string{"secret_key": "s"}))
+			secret, err = to.secrets.Get("aws")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]string{"access_key": "a"}))
+		})
+	}
+	It("stores secrets prefixed with a scheme in a directory for the scheme", func() {
+		schemed := memorydeps.DefaultSecretStorage()
+		defer schemed.Delete("aws/creds")
+		_, err := schemed.Create(&dependencies.Secret{Ref: "aws/creds", Data: map[string]string{"secret_key": "s"}})
+		Must(err)
+
+		archive := filepath.Join(dir, "archive")
+		_, err = run(from, "export", archive, "--secrets.schemes", "memory")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = os.Stat(filepath.Join(archive, "secrets.memory", "aws", "creds.yml"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = os.Stat(filepath.Join(archive, "secrets", "aws.yml"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("drops generated objects if asked to", func() {
+		archive := filepath.Join(dir, "archive")
+		_, err := run(from, "export", archive, "--skip-generated")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = run(to, "import", archive)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = to.config.V1().Upstreams().Get("discovered")
+		Expect(err).To(HaveOccurred())
+	})
+	It("writes nothing in a dry run", func() {
+		archive := filepath.Join(dir, "archive")
+		_, err := run(from, "export", archive)
+		Expect(err).NotTo(HaveOccurred())
+		out, err := run(to, "import", archive, "--dry-run")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("upstream petstore would be created"))
+		_, err = to.config.V1().Upstreams().Get("petstore")
+		Expect(err).To(HaveOccurred())
+	})
+	It("applies the conflict policy to resources that already exist", func() {
+		archive := filepath.Join(dir, "archive")
+		_, err := run(from, "export", archive, "--kinds", "upstreams")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = to.config.V1().Upstreams().Create(&v1.Upstream{Name: "petstore", Type: "aws"})
+		Must(err)
+
+		_, err = run(to, "import", archive, "--kinds", "upstreams")
+		Expect(err).To(HaveOccurred())
+		_, err = to.config.V1().Upstreams().Get("discovered")
+		Expect(err).To(HaveOccurred())
+
+		out, err := run(to, "import", archive, "--kinds", "upstreams", "--on-conflict", "skip")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("upstream petstore skipped"))
+		us, err := to.config.V1().Upstreams().Get("petstore")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(us.Type).To(Equal("aws"))
+
+		_, err = run(to, "import", archive, "--kinds", "upstreams", "--on-conflict", "overwrite")
+		Expect(err).NotTo(HaveOccurred())
+		us, err = to.config.V1().Upstreams().Get("petstore")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(us.Type).To(Equal("service"))
+	})
+	It("migrates to the backends given by the --to flags", func() {
+		out, err := run(from, "migrate", "--to.storage.type", "memory", "--to.secrets.type", "memory", "--to.files.type", "memory")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("virtual host default created"))
+		defer func() {
+			memory.DefaultStorage().V1().Upstreams().Delete("petstore")
+			memory.DefaultStorage().V1().Upstreams().Delete("discovered")
+			memory.DefaultStorage().V1().VirtualHosts().Delete("default")
+			memorydeps.DefaultSecretStorage().Delete("aws")
+			memorydeps.DefaultFileStorage().Delete("descriptors")
+		}()
+		expectImported(&fileBackends{
+			config:    memory.DefaultStorage(),
+			secrets:   memorydeps.DefaultSecretStorage(),
+			artifacts: memorydeps.DefaultFileStorage(),
+		})
+	})
+})
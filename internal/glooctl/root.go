@@ -0,0 +1,88 @@
+// Package glooctl implements a command line tool for managing gloo config objects on any storage backend,
+// and for copying them, with secrets and artifacts, from one storage backend to another
+package glooctl
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
+	"github.com/solo-io/gloo/pkg/bootstrap/flags"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+type options struct {
+	bootstrap.Options
+	// output format for get and list commands
+	output string
+	// file to read a config object from
+	filename string
+}
+
+// NewRootCmd returns the glooctl command and all of its subcommands
+func NewRootCmd() *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:           "glooctl",
+		Short:         "manage gloo upstreams and virtual hosts on any storage backend",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	addStorageFlags(cmd, &opts.Options)
+
+	cmd.AddCommand(
+		upstreamCmd(opts),
+		virtualHostCmd(opts),
+		routeCmd(opts),
+		statusCmd(opts),
+		exportCmd(opts),
+		importCmd(opts),
+		migrateCmd(opts),
+	)
+	return cmd
+}
+
+// same storage options as the gloo components
+func addStorageFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	flags.AddConfigStorageOptionFlags(cmd, opts)
+	flags.AddSecretStorageOptionFlags(cmd, opts)
+	flags.AddFileStorageOptionFlags(cmd, opts)
+	flags.AddFileFlags(cmd, opts)
+	flags.AddKubernetesFlags(cmd, opts)
+	flags.AddConsulFlags(cmd, opts)
+	flags.AddEtcdFlags(cmd, opts)
+	flags.AddVaultFlags(cmd, opts)
+	flags.AddGitFlags(cmd, opts)
+	flags.AddCompositeFlags(cmd, opts)
+}
+
+func (o *options) store() (storage.Interface, error) {
+	store, err := configstorage.Bootstrap(o.Options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create config store client")
+	}
+	return store, nil
+}
+
+// store for commands that write, making sure the storage backend is set up first
+func (o *options) writableStore() (storage.Interface, error) {
+	store, err := o.store()
+	if err != nil {
+		return nil, err
+	}
+	if err := store.V1().Register(); err != nil && !storage.IsAlreadyExists(err) {
+		return nil, errors.Wrap(err, "failed to register storage")
+	}
+	return store, nil
+}
+
+func addOutputFlag(cmd *cobra.Command, opts *options) {
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "output format, one of yaml or json. prints a summary table if not set")
+}
+
+func addFilenameFlag(cmd *cobra.Command, opts *options, description string) {
+	cmd.Flags().StringVarP(&opts.filename, "filename", "f", "", description+". use - to read from stdin")
+	cmd.MarkFlagRequired("filename")
+}
@@ -0,0 +1,194 @@
+package glooctl
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+const (
+	// times the routes of a virtual host are read and changed again after the write conflicted with another update
+	maxConflictRetries = 5
+	conflictBackoff    = 100 * time.Millisecond
+)
+
+func routeCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "route",
+		Aliases: []string{"routes"},
+		Short:   "manage the routes of a virtual host",
+	}
+	cmd.AddCommand(
+		routeAddCmd(opts),
+		routeRemoveCmd(opts),
+		routeListCmd(opts),
+	)
+	return cmd
+}
+
+func routeAddCmd(opts *options) *cobra.Command {
+	var index int
+	cmd := &cobra.Command{
+		Use:   "add VIRTUAL_HOST",
+		Short: "add a route from a yaml or json file to a virtual host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var route v1.Route
+			if err := readInto(opts.filename, os.Stdin, &route); err != nil {
+				return err
+			}
+			if err := validateRoute(&route); err != nil {
+				return errors.Wrap(err, "invalid route")
+			}
+			var added int
+			err := updateRoutes(opts, args[0], func(routes []*v1.Route) ([]*v1.Route, error) {
+				added = index
+				if added < 0 || added > len(routes) {
+					// append by default
+					added = len(routes)
+				}
+				routes = append(routes, nil)
+				copy(routes[added+1:], routes[added:])
+				routes[added] = &route
+				return routes, nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "route added to virtual host %v at index %v\n", args[0], added)
+			return nil
+		},
+	}
+	addFilenameFlag(cmd, opts, "file containing the route to add")
+	cmd.Flags().IntVar(&index, "index", -1, "position to insert the route at, routes are matched in order. "+
+		"the route is appended if not set")
+	return cmd
+}
+
+func routeRemoveCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove VIRTUAL_HOST INDEX",
+		Short: "remove the route at the given index from a virtual host",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			index, err := strconv.Atoi(args[1])
+			if err != nil {
+				return errors.Wrapf(err, "invalid index %v", args[1])
+			}
+			err = updateRoutes(opts, args[0], func(routes []*v1.Route) ([]*v1.Route, error) {
+				if index < 0 || index >= len(routes) {
+					return nil, errors.Errorf("virtual host %v has no route at index %v", args[0], index)
+				}
+				return append(routes[:index], routes[index+1:]...), nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "route %v removed from virtual host %v\n", index, args[0])
+			return nil
+		},
+	}
+}
+
+func routeListCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list VIRTUAL_HOST",
+		Short: "list the routes of a virtual host in the order they are matched",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			virtualHost, err := store.V1().VirtualHosts().Get(args[0])
+			if err != nil {
+				return errors.Wrapf(err, "failed to get virtual host %v", args[0])
+			}
+			var rows [][]string
+			for i, route := range virtualHost.Routes {
+				rows = append(rows, []string{strconv.Itoa(i), describeMatcher(route), describeDestinations(route)})
+			}
+			return printTable(cmd.OutOrStdout(), []string{"INDEX", "MATCHER", "DESTINATION"}, rows)
+		},
+	}
+}
+
+// updateRoutes reads the virtual host, changes its routes and writes it back.
+// if the virtual host was changed in the meantime, the latest version is read and the update is retried
+func updateRoutes(opts *options, name string, update func([]*v1.Route) ([]*v1.Route, error)) error {
+	store, err := opts.writableStore()
+	if err != nil {
+		return err
+	}
+	for attempt := 0; ; attempt++ {
+		virtualHost, err := store.V1().VirtualHosts().Get(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get virtual host %v", name)
+		}
+		routes, err := update(virtualHost.Routes)
+		if err != nil {
+			return err
+		}
+		virtualHost.Routes = routes
+		_, err = store.V1().VirtualHosts().Update(virtualHost)
+		if err == nil {
+			return nil
+		}
+		if !storage.IsConflict(err) || attempt >= maxConflictRetries {
+			return errors.Wrapf(err, "failed to update virtual host %v", name)
+		}
+		time.Sleep(conflictBackoff * time.Duration(attempt+1))
+	}
+}
+
+func describeMatcher(route *v1.Route) string {
+	switch matcher := route.Matcher.(type) {
+	case *v1.Route_RequestMatcher:
+		m := matcher.RequestMatcher
+		var desc string
+		switch path := m.Path.(type) {
+		case *v1.RequestMatcher_PathPrefix:
+			desc = "prefix " + path.PathPrefix
+		case *v1.RequestMatcher_PathExact:
+			desc = "exact " + path.PathExact
+		case *v1.RequestMatcher_PathRegex:
+			desc = "regex " + path.PathRegex
+		}
+		if len(m.Verbs) > 0 {
+			desc = strings.Join(m.Verbs, ",") + " " + desc
+		}
+		return desc
+	case *v1.Route_EventMatcher:
+		return "event " + matcher.EventMatcher.EventType
+	}
+	return ""
+}
+
+func describeDestinations(route *v1.Route) string {
+	if route.SingleDestination != nil {
+		return describeDestination(route.SingleDestination)
+	}
+	var descs []string
+	for _, dest := range route.MultipleDestinations {
+		descs = append(descs, fmt.Sprintf("%v (weight %v)", describeDestination(dest.Destination), dest.Weight))
+	}
+	return strings.Join(descs, ", ")
+}
+
+func describeDestination(dest *v1.Destination) string {
+	switch d := dest.DestinationType.(type) {
+	case *v1.Destination_Upstream:
+		return d.Upstream.Name
+	case *v1.Destination_Function:
+		return d.Function.UpstreamName + "/" + d.Function.FunctionName
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package glooctl
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+
+	// the plugins whose specs glooctl scaffolds and validates register their schemas
+	_ "github.com/solo-io/gloo/pkg/coreplugins/route-extensions"
+	_ "github.com/solo-io/gloo/pkg/coreplugins/service"
+	_ "github.com/solo-io/gloo/pkg/plugins/aws"
+	_ "github.com/solo-io/gloo/pkg/plugins/azure"
+	_ "github.com/solo-io/gloo/pkg/plugins/consul"
+	_ "github.com/solo-io/gloo/pkg/plugins/google"
+	_ "github.com/solo-io/gloo/pkg/plugins/kubernetes"
+)
+
+// supportedUpstreamTypes returns the upstream types with a registered schema and an example spec
+func supportedUpstreamTypes() []string {
+	var names []string
+	for _, s := range schemas.OfKind(schemas.KindUpstreamSpec) {
+		if s.Example != nil {
+			names = append(names, s.Type)
+		}
+	}
+	return names
+}
+
+// scaffoldUpstream returns an upstream with an example spec for the type
+func scaffoldUpstream(name, upstreamType string) (*v1.Upstream, error) {
+	s, ok := schemas.Get(schemas.KindUpstreamSpec, upstreamType)
+	if !ok || s.Example == nil {
+		return nil, errors.Errorf("unknown upstream type %v, must be one of %v", upstreamType, supportedUpstreamTypes())
+	}
+	upstream := &v1.Upstream{
+		Name: name,
+		Type: upstreamType,
+		Spec: proto.Clone(s.Example).(v1.UpstreamSpec),
+	}
+	if fn, ok := schemas.FunctionSchema(upstreamType, ""); ok && fn.Example != nil {
+		upstream.Functions = []*v1.Function{{
+			Name: "my-function",
+			Spec: proto.Clone(fn.Example).(v1.FunctionSpec),
+		}}
+	}
+	return upstream, nil
+}
+
+// validateUpstream checks the spec of the upstream and its functions against the schemas of their plugins.
+// specs of types not known to glooctl are accepted as they are
+func validateUpstream(upstream *v1.Upstream) error {
+	if upstream.Name == "" {
+		return errors.New("upstream must have a name")
+	}
+	if _, ok := schemas.Get(schemas.KindUpstreamSpec, upstream.Type); ok && upstream.Spec == nil {
+		return errors.Errorf("invalid %v spec for upstream %v: spec is missing", upstream.Type, upstream.Name)
+	}
+	if _, ok := schemas.FunctionSchema(upstream.Type, upstream.GetServiceInfo().GetType()); ok {
+		for _, fn := range upstream.Functions {
+			if fn.Spec == nil {
+				return errors.Errorf("invalid spec for function %v on upstream %v: spec is missing", fn.Name, upstream.Name)
+			}
+		}
+	}
+	if err := schemas.ValidateUpstream(upstream); err != nil {
+		return errors.Wrapf(err, "invalid upstream %v", upstream.Name)
+	}
+	return nil
+}
+
+func validateVirtualHost(virtualHost *v1.VirtualHost) error {
+	if virtualHost.Name == "" {
+		return errors.New("virtual host must have a name")
+	}
+	for i, route := range virtualHost.Routes {
+		if err := validateRoute(route); err != nil {
+			return errors.Wrapf(err, "invalid route %v on virtual host %v", i, virtualHost.Name)
+		}
+	}
+	return nil
+}
+
+func validateRoute(route *v1.Route) error {
+	if route.Matcher == nil {
+		return errors.New("route must have a matcher")
+	}
+	if route.SingleDestination == nil && len(route.MultipleDestinations) == 0 {
+		return errors.New("route must specify either 'single_destination' or 'multiple_destinations'")
+	}
+	if err := schemas.ValidateRouteExtensions(route.Extensions); err != nil {
+		return errors.Wrap(err, "invalid extensions")
+	}
+	return nil
+}
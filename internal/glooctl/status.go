@@ -0,0 +1,44 @@
+package glooctl
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func statusCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "show whether gloo accepted each upstream and virtual host",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			upstreams, err := store.V1().Upstreams().List(nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to list upstreams")
+			}
+			virtualHosts, err := store.V1().VirtualHosts().List(nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to list virtual hosts")
+			}
+			var rows [][]string
+			for _, us := range upstreams {
+				rows = append(rows, append([]string{"upstream", us.Name}, statusColumns(us.Status)...))
+			}
+			for _, vh := range virtualHosts {
+				rows = append(rows, append([]string{"virtualhost", vh.Name}, statusColumns(vh.Status)...))
+			}
+			sort.SliceStable(rows, func(i, j int) bool {
+				if rows[i][0] != rows[j][0] {
+					return rows[i][0] < rows[j][0]
+				}
+				return rows[i][1] < rows[j][1]
+			})
+			return printTable(cmd.OutOrStdout(), []string{"KIND", "NAME", "STATE", "REASON"}, rows)
+		},
+	}
+}
@@ -0,0 +1,197 @@
+package glooctl
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+func upstreamCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "upstream",
+		Aliases: []string{"upstreams", "us"},
+		Short:   "manage upstreams",
+	}
+	cmd.AddCommand(
+		upstreamCreateCmd(opts),
+		upstreamGetCmd(opts),
+		upstreamListCmd(opts),
+		upstreamUpdateCmd(opts),
+		upstreamDeleteCmd(opts),
+		upstreamScaffoldCmd(opts),
+	)
+	return cmd
+}
+
+func upstreamCreateCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "create an upstream from a yaml or json file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var upstream v1.Upstream
+			if err := readInto(opts.filename, os.Stdin, &upstream); err != nil {
+				return err
+			}
+			if err := validateUpstream(&upstream); err != nil {
+				return err
+			}
+			store, err := opts.writableStore()
+			if err != nil {
+				return err
+			}
+			created, err := store.V1().Upstreams().Create(&upstream)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create upstream %v", upstream.Name)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "upstream %v created\n", created.Name)
+			return nil
+		},
+	}
+	addFilenameFlag(cmd, opts, "file containing the upstream to create")
+	return cmd
+}
+
+func upstreamGetCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get NAME",
+		Short: "print an upstream",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			upstream, err := store.V1().Upstreams().Get(args[0])
+			if err != nil {
+				return errors.Wrapf(err, "failed to get upstream %v", args[0])
+			}
+			if opts.output == "" {
+				return printUpstreamTable(cmd, []*v1.Upstream{upstream})
+			}
+			return printObject(cmd.OutOrStdout(), opts.output, upstream)
+		},
+	}
+	addOutputFlag(cmd, opts)
+	return cmd
+}
+
+func upstreamListCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list upstreams",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			upstreams, err := store.V1().Upstreams().List(nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to list upstreams")
+			}
+			sort.SliceStable(upstreams, func(i, j int) bool {
+				return upstreams[i].Name < upstreams[j].Name
+			})
+			if opts.output == "" {
+				return printUpstreamTable(cmd, upstreams)
+			}
+			var pbs []proto.Message
+			for _, us := range upstreams {
+				pbs = append(pbs, us)
+			}
+			return printObjects(cmd.OutOrStdout(), opts.output, pbs)
+		},
+	}
+	addOutputFlag(cmd, opts)
+	return cmd
+}
+
+func upstreamUpdateCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "replace an existing upstream with the one in a yaml or json file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var upstream v1.Upstream
+			if err := readInto(opts.filename, os.Stdin, &upstream); err != nil {
+				return err
+			}
+			if err := validateUpstream(&upstream); err != nil {
+				return err
+			}
+			store, err := opts.writableStore()
+			if err != nil {
+				return err
+			}
+			existing, err := store.V1().Upstreams().Get(upstream.Name)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get upstream %v", upstream.Name)
+			}
+			upstream.Metadata = withResourceVersion(upstream.Metadata, resourceVersion(existing.Metadata))
+			if _, err := store.V1().Upstreams().Update(&upstream); err != nil {
+				return errors.Wrapf(err, "failed to update upstream %v", upstream.Name)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "upstream %v updated\n", upstream.Name)
+			return nil
+		},
+	}
+	addFilenameFlag(cmd, opts, "file containing the new version of the upstream")
+	return cmd
+}
+
+func upstreamDeleteCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete NAME",
+		Short: "delete an upstream",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			if err := store.V1().Upstreams().Delete(args[0]); err != nil {
+				return errors.Wrapf(err, "failed to delete upstream %v", args[0])
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "upstream %v deleted\n", args[0])
+			return nil
+		},
+	}
+}
+
+func upstreamScaffoldCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scaffold TYPE [NAME]",
+		Short: "print an example upstream of the given type, to be edited and passed to create",
+		Long:  "print an example upstream of the given type. supported types: " + strings.Join(supportedUpstreamTypes(), ", "),
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := "my-" + args[0] + "-upstream"
+			if len(args) == 2 {
+				name = args[1]
+			}
+			upstream, err := scaffoldUpstream(name, args[0])
+			if err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), opts.output, upstream)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", formatYAML, "output format, one of yaml or json")
+	return cmd
+}
+
+func printUpstreamTable(cmd *cobra.Command, upstreams []*v1.Upstream) error {
+	var rows [][]string
+	for _, us := range upstreams {
+		rows = append(rows, append([]string{us.Name, us.Type}, statusColumns(us.Status)...))
+	}
+	return printTable(cmd.OutOrStdout(), []string{"NAME", "TYPE", "STATE", "REASON"}, rows)
+}
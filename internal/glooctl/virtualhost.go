@@ -0,0 +1,175 @@
+package glooctl
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+func virtualHostCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "virtualhost",
+		Aliases: []string{"virtualhosts", "vh"},
+		Short:   "manage virtual hosts",
+	}
+	cmd.AddCommand(
+		virtualHostCreateCmd(opts),
+		virtualHostGetCmd(opts),
+		virtualHostListCmd(opts),
+		virtualHostUpdateCmd(opts),
+		virtualHostDeleteCmd(opts),
+	)
+	return cmd
+}
+
+func virtualHostCreateCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a virtual host from a yaml or json file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var virtualHost v1.VirtualHost
+			if err := readInto(opts.filename, os.Stdin, &virtualHost); err != nil {
+				return err
+			}
+			if err := validateVirtualHost(&virtualHost); err != nil {
+				return err
+			}
+			store, err := opts.writableStore()
+			if err != nil {
+				return err
+			}
+			created, err := store.V1().VirtualHosts().Create(&virtualHost)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create virtual host %v", virtualHost.Name)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "virtual host %v created\n", created.Name)
+			return nil
+		},
+	}
+	addFilenameFlag(cmd, opts, "file containing the virtual host to create")
+	return cmd
+}
+
+func virtualHostGetCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get NAME",
+		Short: "print a virtual host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			virtualHost, err := store.V1().VirtualHosts().Get(args[0])
+			if err != nil {
+				return errors.Wrapf(err, "failed to get virtual host %v", args[0])
+			}
+			if opts.output == "" {
+				return printVirtualHostTable(cmd, []*v1.VirtualHost{virtualHost})
+			}
+			return printObject(cmd.OutOrStdout(), opts.output, virtualHost)
+		},
+	}
+	addOutputFlag(cmd, opts)
+	return cmd
+}
+
+func virtualHostListCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list virtual hosts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			virtualHosts, err := store.V1().VirtualHosts().List(nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to list virtual hosts")
+			}
+			sort.SliceStable(virtualHosts, func(i, j int) bool {
+				return virtualHosts[i].Name < virtualHosts[j].Name
+			})
+			if opts.output == "" {
+				return printVirtualHostTable(cmd, virtualHosts)
+			}
+			var pbs []proto.Message
+			for _, vh := range virtualHosts {
+				pbs = append(pbs, vh)
+			}
+			return printObjects(cmd.OutOrStdout(), opts.output, pbs)
+		},
+	}
+	addOutputFlag(cmd, opts)
+	return cmd
+}
+
+func virtualHostUpdateCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "replace an existing virtual host with the one in a yaml or json file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var virtualHost v1.VirtualHost
+			if err := readInto(opts.filename, os.Stdin, &virtualHost); err != nil {
+				return err
+			}
+			if err := validateVirtualHost(&virtualHost); err != nil {
+				return err
+			}
+			store, err := opts.writableStore()
+			if err != nil {
+				return err
+			}
+			existing, err := store.V1().VirtualHosts().Get(virtualHost.Name)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get virtual host %v", virtualHost.Name)
+			}
+			virtualHost.Metadata = withResourceVersion(virtualHost.Metadata, resourceVersion(existing.Metadata))
+			if _, err := store.V1().VirtualHosts().Update(&virtualHost); err != nil {
+				return errors.Wrapf(err, "failed to update virtual host %v", virtualHost.Name)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "virtual host %v updated\n", virtualHost.Name)
+			return nil
+		},
+	}
+	addFilenameFlag(cmd, opts, "file containing the new version of the virtual host")
+	return cmd
+}
+
+func virtualHostDeleteCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete NAME",
+		Short: "delete a virtual host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := opts.store()
+			if err != nil {
+				return err
+			}
+			if err := store.V1().VirtualHosts().Delete(args[0]); err != nil {
+				return errors.Wrapf(err, "failed to delete virtual host %v", args[0])
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "virtual host %v deleted\n", args[0])
+			return nil
+		},
+	}
+}
+
+func printVirtualHostTable(cmd *cobra.Command, virtualHosts []*v1.VirtualHost) error {
+	var rows [][]string
+	for _, vh := range virtualHosts {
+		row := []string{vh.Name, strings.Join(vh.Domains, ","), fmt.Sprintf("%d", len(vh.Routes))}
+		rows = append(rows, append(row, statusColumns(vh.Status)...))
+	}
+	return printTable(cmd.OutOrStdout(), []string{"NAME", "DOMAINS", "ROUTES", "STATE", "REASON"}, rows)
+}
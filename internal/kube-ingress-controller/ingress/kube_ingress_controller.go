@@ -118,16 +118,25 @@ func (c *IngressController) syncGlooResources() error {
 		return fmt.Errorf("failed to list actual configObjects: %v", err)
 	}
 	if err := c.syncUpstreams(desiredUpstreams, actualUpstreams); err != nil {
-		return fmt.Errorf("failed to sync actual with desired upstreams: %v", err)
+		if storage.IsNotLeader(err) {
+			// another replica is the leader, it writes the desired config objects
+			log.Debugf("not syncing ingresses: %v", err)
+			return nil
+		}
+		return errors.Wrap(err, "failed to sync actual with desired upstreams")
 	}
 	if err := c.syncVirtualHosts(desiredVirtualHosts, actualVirtualHosts); err != nil {
-		return fmt.Errorf("failed to sync actual with desired virtualHosts: %v", err)
+		if storage.IsNotLeader(err) {
+			log.Debugf("not syncing ingresses: %v", err)
+			return nil
+		}
+		return errors.Wrap(err, "failed to sync actual with desired virtualHosts")
 	}
 	return nil
 }
 
 func (c *IngressController) getActualResources() ([]*v1.Upstream, []*v1.VirtualHost, error) {
-	upstreams, err := c.configObjects.V1().Upstreams().List()
+	upstreams, err := c.configObjects.V1().Upstreams().List(nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get upstream crd list: %v", err)
 	}
@@ -138,7 +147,7 @@ func (c *IngressController) getActualResources() ([]*v1.Upstream, []*v1.VirtualH
 			ourUpstreams = append(ourUpstreams, us)
 		}
 	}
-	virtualHosts, err := c.configObjects.V1().VirtualHosts().List()
+	virtualHosts, err := c.configObjects.V1().VirtualHosts().List(nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get virtual host crd list: %v", err)
 	}
@@ -306,20 +315,20 @@ func (c *IngressController) syncUpstreams(desiredUpstreams, actualUpstreams []*v
 		// TODO: think about caring about already exists errors
 		// This workaround is necessary because the service discovery may be running and creating upstreams
 		if _, err := c.configObjects.V1().Upstreams().Create(us); err != nil && !storage.IsAlreadyExists(err) {
-			return fmt.Errorf("failed to create upstream crd %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to create upstream crd %s", us.Name)
 		}
 	}
 	for _, us := range upstreamsToUpdate {
 		log.Debugf("updating upstream %v", us.Name)
 		if _, err := c.configObjects.V1().Upstreams().Update(us); err != nil {
-			return fmt.Errorf("failed to update upstream crd %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to update upstream crd %s", us.Name)
 		}
 	}
 	// only remaining are no longer desired, delete em!
 	for _, us := range actualUpstreams {
 		log.Debugf("deleting upstream %v", us.Name)
 		if err := c.configObjects.V1().Upstreams().Delete(us.Name); err != nil {
-			return fmt.Errorf("failed to update upstream crd %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to update upstream crd %s", us.Name)
 		}
 	}
 	return nil
@@ -354,20 +363,20 @@ func (c *IngressController) syncVirtualHosts(desiredVirtualHosts, actualVirtualH
 	for _, virtualHost := range virtualHostsToCreate {
 		log.Printf("creating virtualhost %v", virtualHost.Name)
 		if _, err := c.configObjects.V1().VirtualHosts().Create(virtualHost); err != nil {
-			return fmt.Errorf("failed to create virtualHost crd %s: %v", virtualHost.Name, err)
+			return errors.Wrapf(err, "failed to create virtualHost crd %s", virtualHost.Name)
 		}
 	}
 	for _, virtualHost := range virtualHostsToUpdate {
 		log.Printf("updating virtualhost %v", virtualHost.Name)
 		if _, err := c.configObjects.V1().VirtualHosts().Update(virtualHost); err != nil {
-			return fmt.Errorf("failed to update upstream crd %s: %v", virtualHost.Name, err)
+			return errors.Wrapf(err, "failed to update upstream crd %s", virtualHost.Name)
 		}
 	}
 	// only remaining are no longer desired, delete em!
 	for _, virtualHost := range actualVirtualHosts {
 		log.Printf("deleting virtualhost %v", virtualHost.Name)
 		if err := c.configObjects.V1().VirtualHosts().Delete(virtualHost.Name); err != nil {
-			return fmt.Errorf("failed to update upstream crd %s: %v", virtualHost.Name, err)
+			return errors.Wrapf(err, "failed to update upstream crd %s", virtualHost.Name)
 		}
 	}
 	return nil
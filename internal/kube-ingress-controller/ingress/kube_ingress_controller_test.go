@@ -14,11 +14,11 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/log"
 	kubeplugin "github.com/solo-io/gloo/pkg/plugins/kubernetes"
 	"github.com/solo-io/gloo/pkg/storage"
 	"github.com/solo-io/gloo/pkg/storage/crd"
 	. "github.com/solo-io/gloo/test/helpers"
-	"github.com/solo-io/gloo/pkg/log"
 )
 
 var _ = Describe("KubeIngressController", func() {
@@ -101,10 +101,10 @@ var _ = Describe("KubeIngressController", func() {
 				}
 			})
 			It("ignores the ingress", func() {
-				upstreams, err := glooClient.V1().Upstreams().List()
+				upstreams, err := glooClient.V1().Upstreams().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(upstreams).To(HaveLen(0))
-				virtualHostList, err := glooClient.V1().VirtualHosts().List()
+				virtualHostList, err := glooClient.V1().VirtualHosts().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(virtualHostList).To(HaveLen(0))
 			})
@@ -173,7 +173,7 @@ var _ = Describe("KubeIngressController", func() {
 					Domains: []string{"*"},
 					Routes:  []*v1.Route{glooRoute},
 				}
-				virtualHostList, err := glooClient.V1().VirtualHosts().List()
+				virtualHostList, err := glooClient.V1().VirtualHosts().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(virtualHostList).To(HaveLen(1))
 				virtualHost := virtualHostList[0]
@@ -184,7 +184,7 @@ var _ = Describe("KubeIngressController", func() {
 			})
 			It("creates an upstream for the ingress backend", func() {
 				expectedUpstream := ingressCtl.newUpstreamFromBackend(namespace, *defaultBackend)
-				upstreamList, err := glooClient.V1().Upstreams().List()
+				upstreamList, err := glooClient.V1().Upstreams().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(upstreamList).To(HaveLen(1))
 				actualUpstream := upstreamList[0]
@@ -308,7 +308,7 @@ var _ = Describe("KubeIngressController", func() {
 						}
 					}
 				}
-				upstreams, err := glooClient.V1().Upstreams().List()
+				upstreams, err := glooClient.V1().Upstreams().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(upstreams).To(HaveLen(len(expectedUpstreams)))
 				for _, us := range upstreams {
@@ -362,7 +362,7 @@ var _ = Describe("KubeIngressController", func() {
 						expectedVirtualHosts[host] = vHost
 					}
 				}
-				virtuavirtualHostList, err := glooClient.V1().VirtualHosts().List()
+				virtuavirtualHostList, err := glooClient.V1().VirtualHosts().List(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(virtuavirtualHostList).To(HaveLen(len(expectedVirtualHosts)))
 				for _, virtualHost := range virtuavirtualHostList {
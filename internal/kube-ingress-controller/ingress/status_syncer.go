@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/leaderelection"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/kubecontroller"
 	kubev1 "k8s.io/api/core/v1"
@@ -30,6 +31,9 @@ type IngressSyncer struct {
 
 	client kubernetes.Interface
 
+	// only the leader updates ingresses
+	elector leaderelection.Elector
+
 	ingressLister v1beta1listers.IngressLister
 	serviceLister corev1.ServiceLister
 
@@ -44,7 +48,7 @@ func (c *IngressSyncer) Error() <-chan error {
 	return c.errors
 }
 
-func NewIngressSyncer(cfg *rest.Config, resyncDuration time.Duration, stopCh <-chan struct{}, globalIngress bool, ingressService string) (*IngressSyncer, error) {
+func NewIngressSyncer(cfg *rest.Config, resyncDuration time.Duration, stopCh <-chan struct{}, globalIngress bool, ingressService string, elector leaderelection.Elector) (*IngressSyncer, error) {
 	kubeClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kube clientset: %v", err)
@@ -58,6 +62,7 @@ func NewIngressSyncer(cfg *rest.Config, resyncDuration time.Duration, stopCh <-c
 		ingressService: ingressService,
 		globalIngress:  globalIngress,
 		client:         kubeClient,
+		elector:        elector,
 		ingressLister:  ingressInformer.Lister(),
 		serviceLister:  serviceInformer.Lister(),
 		cachedStatuses: make(map[string]kubev1.LoadBalancerStatus),
@@ -83,6 +88,9 @@ func (c *IngressSyncer) syncIngressStatus() {
 }
 
 func (c *IngressSyncer) sync() error {
+	if !c.elector.IsLeader() {
+		return nil
+	}
 	ingresses, err := c.ingressLister.List(labels.Everything())
 	if err != nil {
 		return errors.Wrap(err, "failed to list ingresses")
@@ -120,7 +120,7 @@ var _ = Describe("Consul UpstreamController Integration", func() {
 				case err := <-ctl.Error():
 					return nil, err
 				default:
-					us, err := store.V1().Upstreams().List()
+					us, err := store.V1().Upstreams().List(nil)
 					removeResourceVersion(us)
 					sort.SliceStable(us, func(i, j int) bool {
 						return us[i].Name < us[j].Name
@@ -99,7 +99,7 @@ var _ = Describe("Kube Service Discovery", func() {
 			})
 			It("creates an upstream for each port from the service definition", func() {
 				time.Sleep(time.Second * 3)
-				createdUpstreams, err := glooClient.V1().Upstreams().List()
+				createdUpstreams, err := glooClient.V1().Upstreams().List(nil)
 				// need to clear metadata for test
 				for _, us := range createdUpstreams {
 					us.Metadata = nil
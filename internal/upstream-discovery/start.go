@@ -6,15 +6,28 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/solo-io/gloo/internal/upstream-discovery/bootstrap"
 	"github.com/solo-io/gloo/internal/upstream-discovery/consul"
 	"github.com/solo-io/gloo/internal/upstream-discovery/copilot"
 	"github.com/solo-io/gloo/internal/upstream-discovery/kube"
+	"github.com/solo-io/gloo/pkg/metrics"
 	"github.com/solo-io/gloo/pkg/plugins/cloudfoundry"
 	"github.com/solo-io/gloo/pkg/storage"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+var discoveryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "upstream_discovery",
+	Name:      "errors_total",
+	Help:      "errors encountered by each upstream discovery",
+}, []string{"discovery"})
+
+func init() {
+	prometheus.MustRegister(discoveryErrors)
+}
+
 func Start(opts bootstrap.Options, store storage.Interface, stop <-chan struct{}) error {
 	if opts.UpstreamDiscoveryOptions.EnableDiscoveryForKubernetes {
 		cfg, err := clientcmd.BuildConfigFromFlags(opts.KubeOptions.MasterURL, opts.KubeOptions.KubeConfig)
@@ -60,6 +73,7 @@ func runController(name string, controller Controller, stop <-chan struct{}) {
 		for {
 			select {
 			case err := <-controller.Error():
+				discoveryErrors.WithLabelValues(name).Inc()
 				log.Printf("%s service discovery encountered error: %v", name, err)
 			case <-stop:
 				return
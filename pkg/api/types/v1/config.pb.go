@@ -15,11 +15,13 @@ It has these top-level messages:
 	Config
 	Metadata
 	Status
+	RouteError
 	Upstream
 	ServiceInfo
 	Function
 	VirtualHost
 	Route
+	TcpRoute
 	RequestMatcher
 	EventMatcher
 	WeightedDestination
@@ -22,6 +22,8 @@ type Metadata struct {
 	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	// Annotations allow clients to tag resources for special use cases. gloo ignores annotations but preserved them on read/write from/to storage.
 	Annotations map[string]string `protobuf:"bytes,3,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Labels are key-value pairs used to select resources. Storage clients can list and watch only the resources whose labels match a selector.
+	Labels map[string]string `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *Metadata) Reset()                    { *m = Metadata{} }
@@ -50,6 +52,13 @@ func (m *Metadata) GetAnnotations() map[string]string {
 	return nil
 }
 
+func (m *Metadata) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Metadata)(nil), "v1.Metadata")
 }
@@ -86,16 +95,24 @@ func (this *Metadata) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if this.Labels[i] != that1.Labels[i] {
+			return false
+		}
+	}
 	return true
 }
 
 func init() { proto.RegisterFile("metadata.proto", fileDescriptorMetadata) }
 
 var fileDescriptorMetadata = []byte{
-	// 228 bytes of a gzipped FileDescriptorProto
+	// 257 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcb, 0x4d, 0x2d, 0x49,
 	0x4c, 0x49, 0x2c, 0x49, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2a, 0x33, 0x94, 0x12,
-	0x49, 0xcf, 0x4f, 0xcf, 0x07, 0x73, 0xf5, 0x41, 0x2c, 0x88, 0x8c, 0xd2, 0x6b, 0x46, 0x2e, 0x0e,
+	0x49, 0xcf, 0x4f, 0xcf, 0x07, 0x73, 0xf5, 0x41, 0x2c, 0x88, 0x8c, 0xd2, 0x0d, 0x26, 0x2e, 0x0e,
 	0x5f, 0xa8, 0x62, 0x21, 0x07, 0x2e, 0x81, 0xa2, 0xd4, 0xe2, 0xfc, 0xd2, 0xa2, 0xe4, 0xd4, 0xf8,
 	0xb2, 0xd4, 0xa2, 0xe2, 0xcc, 0xfc, 0x3c, 0x09, 0x46, 0x05, 0x46, 0x0d, 0x4e, 0x27, 0xd1, 0x4f,
 	0xf7, 0xe4, 0x05, 0x4b, 0x52, 0x8b, 0x4b, 0x52, 0x32, 0xd3, 0xd2, 0xac, 0x94, 0x32, 0xd3, 0xf3,
@@ -103,9 +120,11 @@ var fileDescriptorMetadata = []byte{
 	0x12, 0x73, 0x53, 0x8b, 0x0b, 0x12, 0x93, 0x53, 0x25, 0x98, 0x40, 0x5a, 0x83, 0x10, 0x02, 0x42,
 	0xf6, 0x5c, 0xdc, 0x89, 0x79, 0x79, 0xf9, 0x25, 0x89, 0x25, 0x99, 0xf9, 0x79, 0xc5, 0x12, 0xcc,
 	0x0a, 0xcc, 0x1a, 0xdc, 0x46, 0xb2, 0x7a, 0x65, 0x86, 0x7a, 0x30, 0x27, 0xe8, 0x39, 0x22, 0xe4,
-	0x5d, 0xf3, 0x4a, 0x8a, 0x2a, 0x83, 0x90, 0x75, 0x48, 0xd9, 0x71, 0x09, 0xa0, 0x2b, 0x10, 0x12,
-	0xe0, 0x62, 0xce, 0x4e, 0xad, 0x84, 0xb8, 0x33, 0x08, 0xc4, 0x14, 0x12, 0xe1, 0x62, 0x2d, 0x4b,
-	0xcc, 0x29, 0x85, 0x39, 0x00, 0xc2, 0xb1, 0x62, 0xb2, 0x60, 0x74, 0x62, 0x59, 0xf1, 0x48, 0x8e,
-	0x31, 0x89, 0x0d, 0xec, 0x75, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x0f, 0x60, 0x10, 0xcd,
-	0x26, 0x01, 0x00, 0x00,
+	0x5d, 0xf3, 0x4a, 0x8a, 0x2a, 0x83, 0x90, 0x75, 0x08, 0x19, 0x70, 0xb1, 0xe5, 0x24, 0x26, 0xa5,
+	0xe6, 0x14, 0x4b, 0xb0, 0x80, 0xf5, 0x4a, 0xa0, 0xe8, 0xf5, 0x01, 0x4b, 0x41, 0xb4, 0x41, 0xd5,
+	0x49, 0xd9, 0x71, 0x09, 0xa0, 0x1b, 0x29, 0x24, 0xc0, 0xc5, 0x9c, 0x9d, 0x5a, 0x09, 0xf1, 0x59,
+	0x10, 0x88, 0x29, 0x24, 0xc2, 0xc5, 0x5a, 0x96, 0x98, 0x53, 0x0a, 0x73, 0x32, 0x84, 0x63, 0xc5,
+	0x64, 0xc1, 0x28, 0x65, 0xc9, 0xc5, 0x8d, 0x64, 0x2c, 0x29, 0x5a, 0x9d, 0x58, 0x56, 0x3c, 0x92,
+	0x63, 0x4c, 0x62, 0x03, 0x87, 0xb3, 0x31, 0x60, 0x00, 0x67, 0x36, 0x8c, 0xcd, 0x93, 0x01, 0x00,
+	0x00,
 }
@@ -0,0 +1,39 @@
+package v1
+
+import "strings"
+
+// refs to upstreams and secrets may be qualified with a namespace, as "namespace/name".
+// a ref without a namespace refers to an object in the namespace of the object that contains the ref
+const namespaceSeparator = "/"
+
+// ParseRef splits the ref into its namespace and name. the namespace is empty if the ref is not qualified
+func ParseRef(ref string) (namespace, name string) {
+	parts := strings.SplitN(ref, namespaceSeparator, 2)
+	if len(parts) != 2 {
+		return "", ref
+	}
+	return parts[0], parts[1]
+}
+
+// QualifyRef qualifies the ref with the namespace, unless the ref is already qualified or the namespace is empty
+func QualifyRef(ref, namespace string) string {
+	if namespace == "" || ref == "" || strings.Contains(ref, namespaceSeparator) {
+		return ref
+	}
+	return namespace + namespaceSeparator + ref
+}
+
+// ShortenRef removes the namespace from the ref if it is the default namespace,
+// so every ref to the same object is spelled the same way
+func ShortenRef(ref, defaultNamespace string) string {
+	namespace, name := ParseRef(ref)
+	if namespace == defaultNamespace {
+		return name
+	}
+	return ref
+}
+
+// QualifiedName returns the ref to the config object, its name qualified with its namespace
+func QualifiedName(cfgObject ConfigObject) string {
+	return QualifyRef(cfgObject.GetName(), cfgObject.GetMetadata().GetNamespace())
+}
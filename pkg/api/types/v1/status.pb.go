@@ -6,12 +6,16 @@ package v1
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _ "github.com/gogo/protobuf/types"
 import _ "github.com/gogo/protobuf/gogoproto"
 
+import time "time"
+
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 type Status_State int32
 
@@ -48,6 +52,15 @@ type Status struct {
 	State Status_State `protobuf:"varint,1,opt,name=state,proto3,enum=v1.Status_State" json:"state,omitempty"`
 	// Reason is a description of the error for Rejected resources. If the resource is pending or accepted, this field will be empty
 	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Warnings describe problems that did not cause the resource to be rejected,
+	// such as a function destination for a function the upstream does not list
+	Warnings []string `protobuf:"bytes,3,rep,name=warnings" json:"warnings,omitempty"`
+	// RouteErrors describe the errors for each invalid route of a virtual host
+	RouteErrors []*RouteError `protobuf:"bytes,4,rep,name=route_errors,json=routeErrors" json:"route_errors,omitempty"`
+	// ObservedResourceVersion is the resource version of the resource that was evaluated
+	ObservedResourceVersion string `protobuf:"bytes,5,opt,name=observed_resource_version,json=observedResourceVersion,proto3" json:"observed_resource_version,omitempty"`
+	// EvaluationTime is the time at which gloo evaluated the resource
+	EvaluationTime *time.Time `protobuf:"bytes,6,opt,name=evaluation_time,json=evaluationTime,stdtime" json:"evaluation_time,omitempty"`
 }
 
 func (m *Status) Reset()                    { *m = Status{} }
@@ -69,8 +82,64 @@ func (m *Status) GetReason() string {
 	return ""
 }
 
+func (m *Status) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *Status) GetRouteErrors() []*RouteError {
+	if m != nil {
+		return m.RouteErrors
+	}
+	return nil
+}
+
+func (m *Status) GetObservedResourceVersion() string {
+	if m != nil {
+		return m.ObservedResourceVersion
+	}
+	return ""
+}
+
+func (m *Status) GetEvaluationTime() *time.Time {
+	if m != nil {
+		return m.EvaluationTime
+	}
+	return nil
+}
+
+// RouteError is the error for a single route of a virtual host
+type RouteError struct {
+	// Index is the position of the route in the virtual host's list of routes
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Reason is a description of the error
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *RouteError) Reset()                    { *m = RouteError{} }
+func (m *RouteError) String() string            { return proto.CompactTextString(m) }
+func (*RouteError) ProtoMessage()               {}
+func (*RouteError) Descriptor() ([]byte, []int) { return fileDescriptorStatus, []int{1} }
+
+func (m *RouteError) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RouteError) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "v1.Status")
+	proto.RegisterType((*RouteError)(nil), "v1.RouteError")
 	proto.RegisterEnum("v1.Status_State", Status_State_name, Status_State_value)
 }
 func (this *Status) Equal(that interface{}) bool {
@@ -98,22 +167,86 @@ func (this *Status) Equal(that interface{}) bool {
 	if this.Reason != that1.Reason {
 		return false
 	}
+	if len(this.Warnings) != len(that1.Warnings) {
+		return false
+	}
+	for i := range this.Warnings {
+		if this.Warnings[i] != that1.Warnings[i] {
+			return false
+		}
+	}
+	if len(this.RouteErrors) != len(that1.RouteErrors) {
+		return false
+	}
+	for i := range this.RouteErrors {
+		if !this.RouteErrors[i].Equal(that1.RouteErrors[i]) {
+			return false
+		}
+	}
+	if this.ObservedResourceVersion != that1.ObservedResourceVersion {
+		return false
+	}
+	if that1.EvaluationTime == nil {
+		if this.EvaluationTime != nil {
+			return false
+		}
+	} else if !this.EvaluationTime.Equal(*that1.EvaluationTime) {
+		return false
+	}
+	return true
+}
+func (this *RouteError) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RouteError)
+	if !ok {
+		that2, ok := that.(RouteError)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if this.Reason != that1.Reason {
+		return false
+	}
 	return true
 }
 
 func init() { proto.RegisterFile("status.proto", fileDescriptorStatus) }
 
 var fileDescriptorStatus = []byte{
-	// 161 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0x2e, 0x49, 0x2c,
-	0x29, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2a, 0x33, 0x94, 0x12, 0x49, 0xcf,
-	0x4f, 0xcf, 0x07, 0x73, 0xf5, 0x41, 0x2c, 0x88, 0x8c, 0x52, 0x15, 0x17, 0x5b, 0x30, 0x58, 0xa5,
-	0x90, 0x1a, 0x17, 0x2b, 0x48, 0x4f, 0xaa, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0x9f, 0x91, 0x80, 0x5e,
-	0x99, 0xa1, 0x1e, 0x44, 0x0a, 0x4c, 0xa5, 0x06, 0x41, 0xa4, 0x85, 0xc4, 0xb8, 0xd8, 0x8a, 0x52,
-	0x13, 0x8b, 0xf3, 0xf3, 0x24, 0x98, 0x14, 0x18, 0x35, 0x38, 0x83, 0xa0, 0x3c, 0x25, 0x03, 0x2e,
-	0x56, 0xb0, 0x3a, 0x21, 0x6e, 0x2e, 0xf6, 0x80, 0xd4, 0xbc, 0x94, 0xcc, 0xbc, 0x74, 0x01, 0x06,
-	0x21, 0x1e, 0x2e, 0x0e, 0xc7, 0xe4, 0xe4, 0xd4, 0x82, 0x92, 0xd4, 0x14, 0x01, 0x46, 0x10, 0x2f,
-	0x28, 0x35, 0x2b, 0x35, 0x19, 0xc4, 0x63, 0x72, 0x62, 0x59, 0xf1, 0x48, 0x8e, 0x31, 0x89, 0x0d,
-	0xec, 0x10, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x39, 0x5b, 0x7b, 0xc0, 0xb2, 0x00, 0x00,
-	0x00,
+	// 340 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0xcf, 0x4e, 0xe3, 0x30,
+	0x10, 0xc6, 0x37, 0x69, 0x93, 0x6d, 0x27, 0xdd, 0x6e, 0x65, 0x55, 0xbb, 0x21, 0x07, 0x1a, 0xf5,
+	0x80, 0x72, 0x4a, 0x69, 0xb9, 0xf5, 0x46, 0x25, 0x0e, 0xdc, 0x90, 0x41, 0x5c, 0xa3, 0x34, 0x19,
+	0xa2, 0xa0, 0xd6, 0xae, 0x6c, 0x27, 0xf0, 0x18, 0x3c, 0x06, 0x8f, 0xc3, 0x1b, 0x20, 0xf1, 0x24,
+	0xc8, 0x76, 0xff, 0x9c, 0x38, 0x65, 0xbe, 0xef, 0x9b, 0xcc, 0x78, 0x7e, 0x30, 0x90, 0x2a, 0x57,
+	0x8d, 0x4c, 0x77, 0x82, 0x2b, 0x4e, 0xdc, 0x76, 0x1e, 0x4d, 0x2a, 0xce, 0xab, 0x0d, 0xce, 0x8c,
+	0xb3, 0x6e, 0x9e, 0x66, 0xaa, 0xde, 0xa2, 0x54, 0xf9, 0x76, 0x67, 0x9b, 0xa2, 0x71, 0xc5, 0x2b,
+	0x6e, 0xca, 0x99, 0xae, 0xac, 0x3b, 0xfd, 0x70, 0xc1, 0xbf, 0x37, 0xb3, 0xc8, 0x05, 0x78, 0x7a,
+	0x2a, 0x86, 0x4e, 0xec, 0x24, 0xc3, 0xc5, 0x28, 0x6d, 0xe7, 0xa9, 0x8d, 0xcc, 0x07, 0xa9, 0x8d,
+	0xc9, 0x3f, 0xf0, 0x05, 0xe6, 0x92, 0xb3, 0xd0, 0x8d, 0x9d, 0xa4, 0x4f, 0xf7, 0x8a, 0x44, 0xd0,
+	0x7b, 0xc9, 0x05, 0xab, 0x59, 0x25, 0xc3, 0x4e, 0xdc, 0x49, 0xfa, 0xf4, 0xa8, 0xc9, 0x1c, 0x06,
+	0x82, 0x37, 0x0a, 0x33, 0x14, 0x82, 0x0b, 0x19, 0x76, 0xe3, 0x4e, 0x12, 0x2c, 0x86, 0x7a, 0x05,
+	0xd5, 0xfe, 0x8d, 0xb6, 0x69, 0x20, 0x8e, 0xb5, 0x24, 0x4b, 0x38, 0xe3, 0x6b, 0x89, 0xa2, 0xc5,
+	0x32, 0x13, 0x28, 0x79, 0x23, 0x0a, 0xcc, 0x5a, 0x14, 0xb2, 0xe6, 0x2c, 0xf4, 0xcc, 0xe6, 0xff,
+	0x87, 0x06, 0xba, 0xcf, 0x1f, 0x6d, 0x4c, 0x6e, 0xe1, 0x2f, 0xb6, 0xf9, 0xa6, 0xc9, 0x55, 0xcd,
+	0x59, 0xa6, 0x49, 0x84, 0x7e, 0xec, 0x24, 0xc1, 0x22, 0x4a, 0x2d, 0xa6, 0xf4, 0x80, 0x29, 0x7d,
+	0x38, 0x60, 0x5a, 0x75, 0xdf, 0x3e, 0x27, 0x0e, 0x1d, 0x9e, 0x7e, 0xd4, 0xd1, 0xf4, 0x12, 0x3c,
+	0x73, 0x3d, 0x09, 0xe0, 0xf7, 0x1d, 0xb2, 0xb2, 0x66, 0xd5, 0xe8, 0x17, 0x19, 0x40, 0xef, 0xba,
+	0x28, 0x70, 0xa7, 0xb0, 0x1c, 0x39, 0x5a, 0x51, 0x7c, 0xc6, 0x42, 0x2b, 0x77, 0xba, 0x04, 0x38,
+	0xdd, 0x44, 0xc6, 0xe0, 0xd5, 0xac, 0xc4, 0x57, 0x43, 0xf5, 0x0f, 0xb5, 0xe2, 0x27, 0x86, 0xab,
+	0xee, 0xfb, 0xd7, 0xb9, 0xb3, 0xf6, 0xcd, 0xeb, 0xae, 0xbe, 0x07, 0x00, 0x3d, 0x92, 0x0c, 0x9e,
+	0xe6, 0x01, 0x00, 0x00,
 }
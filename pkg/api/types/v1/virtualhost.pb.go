@@ -39,6 +39,12 @@ type VirtualHost struct {
 	Status *Status `protobuf:"bytes,5,opt,name=status" json:"status,omitempty" testdiff:"ignore"`
 	// Metadata contains the resource metadata for the virtual host
 	Metadata *Metadata `protobuf:"bytes,6,opt,name=metadata" json:"metadata,omitempty"`
+	// TCP Routes define the list of routes for raw TCP traffic that live on this virtual host.
+	// Each TCP route is served by an Envoy TCP proxy listener on the route's port.
+	// If SSL Config is provided, TLS will be terminated by Envoy before connections are proxied to the upstream.
+	// Otherwise, connections are passed through to the upstream untouched.
+	// A virtual host which defines TCP routes but no HTTP routes will not be added to the HTTP listeners.
+	TcpRoutes []*TcpRoute `protobuf:"bytes,7,rep,name=tcp_routes,json=tcpRoutes" json:"tcp_routes,omitempty"`
 }
 
 func (m *VirtualHost) Reset()                    { *m = VirtualHost{} }
@@ -88,6 +94,13 @@ func (m *VirtualHost) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *VirtualHost) GetTcpRoutes() []*TcpRoute {
+	if m != nil {
+		return m.TcpRoutes
+	}
+	return nil
+}
+
 // *
 // Routes declare the entrypoints on virtual hosts and the upstreams or functions they route requests to
 type Route struct {
@@ -256,6 +269,59 @@ func _Route_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// *
+// TCP Routes forward raw TCP connections to upstreams. They can be used to expose non-HTTP services
+// (such as databases, caches, or TLS passthrough services) through Envoy
+type TcpRoute struct {
+	// Port is the port Envoy will listen on for connections to this route. TCP routes may share a port
+	// so long as each specifies a distinct set of sni_domains.
+	// The port cannot be the same as one of the HTTP listener ports (default :8080 and :8443)
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// SNI Domains will be matched against the server name indication of incoming TLS connections.
+	// If empty, the route will match all connections on its port that are not matched by another route.
+	SniDomains []string `protobuf:"bytes,2,rep,name=sni_domains,json=sniDomains" json:"sni_domains,omitempty"`
+	// A TCP route is only allowed to specify one of multiple_destinations or single_destination. Setting both will result in an error
+	// Only upstream destinations are supported for TCP routes.
+	// Multiple Destinations is used when a user wants a route to balance connections between multiple upstreams
+	// Balancing is done by probability, where weights are specified for each destination
+	MultipleDestinations []*WeightedDestination `protobuf:"bytes,3,rep,name=multiple_destinations,json=multipleDestinations" json:"multiple_destinations,omitempty"`
+	// A single destination is specified when a route only routes to a single upstream.
+	SingleDestination *Destination `protobuf:"bytes,4,opt,name=single_destination,json=singleDestination" json:"single_destination,omitempty"`
+}
+
+func (m *TcpRoute) Reset()                    { *m = TcpRoute{} }
+func (m *TcpRoute) String() string            { return proto.CompactTextString(m) }
+func (*TcpRoute) ProtoMessage()               {}
+func (*TcpRoute) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{2} }
+
+func (m *TcpRoute) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *TcpRoute) GetSniDomains() []string {
+	if m != nil {
+		return m.SniDomains
+	}
+	return nil
+}
+
+func (m *TcpRoute) GetMultipleDestinations() []*WeightedDestination {
+	if m != nil {
+		return m.MultipleDestinations
+	}
+	return nil
+}
+
+func (m *TcpRoute) GetSingleDestination() *Destination {
+	if m != nil {
+		return m.SingleDestination
+	}
+	return nil
+}
+
 // Request Matcher is a route matcher for traditional http requests
 // Request Matchers stand in juxtoposition to Event Matchers, which match "events" rather than HTTP Requests
 type RequestMatcher struct {
@@ -278,7 +344,7 @@ type RequestMatcher struct {
 func (m *RequestMatcher) Reset()                    { *m = RequestMatcher{} }
 func (m *RequestMatcher) String() string            { return proto.CompactTextString(m) }
 func (*RequestMatcher) ProtoMessage()               {}
-func (*RequestMatcher) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{2} }
+func (*RequestMatcher) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{3} }
 
 type isRequestMatcher_Path interface {
 	isRequestMatcher_Path()
@@ -439,7 +505,7 @@ type EventMatcher struct {
 func (m *EventMatcher) Reset()                    { *m = EventMatcher{} }
 func (m *EventMatcher) String() string            { return proto.CompactTextString(m) }
 func (*EventMatcher) ProtoMessage()               {}
-func (*EventMatcher) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{3} }
+func (*EventMatcher) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{4} }
 
 func (m *EventMatcher) GetEventType() string {
 	if m != nil {
@@ -460,7 +526,7 @@ type WeightedDestination struct {
 func (m *WeightedDestination) Reset()                    { *m = WeightedDestination{} }
 func (m *WeightedDestination) String() string            { return proto.CompactTextString(m) }
 func (*WeightedDestination) ProtoMessage()               {}
-func (*WeightedDestination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{4} }
+func (*WeightedDestination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{5} }
 
 func (m *WeightedDestination) GetWeight() uint32 {
 	if m != nil {
@@ -480,7 +546,7 @@ type Destination struct {
 func (m *Destination) Reset()                    { *m = Destination{} }
 func (m *Destination) String() string            { return proto.CompactTextString(m) }
 func (*Destination) ProtoMessage()               {}
-func (*Destination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{5} }
+func (*Destination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{6} }
 
 type isDestination_DestinationType interface {
 	isDestination_DestinationType()
@@ -603,7 +669,7 @@ type FunctionDestination struct {
 func (m *FunctionDestination) Reset()                    { *m = FunctionDestination{} }
 func (m *FunctionDestination) String() string            { return proto.CompactTextString(m) }
 func (*FunctionDestination) ProtoMessage()               {}
-func (*FunctionDestination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{6} }
+func (*FunctionDestination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{7} }
 
 func (m *FunctionDestination) GetUpstreamName() string {
 	if m != nil {
@@ -628,7 +694,7 @@ type UpstreamDestination struct {
 func (m *UpstreamDestination) Reset()                    { *m = UpstreamDestination{} }
 func (m *UpstreamDestination) String() string            { return proto.CompactTextString(m) }
 func (*UpstreamDestination) ProtoMessage()               {}
-func (*UpstreamDestination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{7} }
+func (*UpstreamDestination) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{8} }
 
 func (m *UpstreamDestination) GetName() string {
 	if m != nil {
@@ -650,7 +716,7 @@ type SSLConfig struct {
 func (m *SSLConfig) Reset()                    { *m = SSLConfig{} }
 func (m *SSLConfig) String() string            { return proto.CompactTextString(m) }
 func (*SSLConfig) ProtoMessage()               {}
-func (*SSLConfig) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{8} }
+func (*SSLConfig) Descriptor() ([]byte, []int) { return fileDescriptorVirtualhost, []int{9} }
 
 func (m *SSLConfig) GetSecretRef() string {
 	if m != nil {
@@ -662,6 +728,7 @@ func (m *SSLConfig) GetSecretRef() string {
 func init() {
 	proto.RegisterType((*VirtualHost)(nil), "v1.VirtualHost")
 	proto.RegisterType((*Route)(nil), "v1.Route")
+	proto.RegisterType((*TcpRoute)(nil), "v1.TcpRoute")
 	proto.RegisterType((*RequestMatcher)(nil), "v1.RequestMatcher")
 	proto.RegisterType((*EventMatcher)(nil), "v1.EventMatcher")
 	proto.RegisterType((*WeightedDestination)(nil), "v1.WeightedDestination")
@@ -717,6 +784,14 @@ func (this *VirtualHost) Equal(that interface{}) bool {
 	if !this.Metadata.Equal(that1.Metadata) {
 		return false
 	}
+	if len(this.TcpRoutes) != len(that1.TcpRoutes) {
+		return false
+	}
+	for i := range this.TcpRoutes {
+		if !this.TcpRoutes[i].Equal(that1.TcpRoutes[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *Route) Equal(that interface{}) bool {
@@ -814,6 +889,49 @@ func (this *Route_EventMatcher) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *TcpRoute) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TcpRoute)
+	if !ok {
+		that2, ok := that.(TcpRoute)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Port != that1.Port {
+		return false
+	}
+	if len(this.SniDomains) != len(that1.SniDomains) {
+		return false
+	}
+	for i := range this.SniDomains {
+		if this.SniDomains[i] != that1.SniDomains[i] {
+			return false
+		}
+	}
+	if len(this.MultipleDestinations) != len(that1.MultipleDestinations) {
+		return false
+	}
+	for i := range this.MultipleDestinations {
+		if !this.MultipleDestinations[i].Equal(that1.MultipleDestinations[i]) {
+			return false
+		}
+	}
+	if !this.SingleDestination.Equal(that1.SingleDestination) {
+		return false
+	}
+	return true
+}
 func (this *RequestMatcher) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -1148,55 +1266,58 @@ func (this *SSLConfig) Equal(that interface{}) bool {
 func init() { proto.RegisterFile("virtualhost.proto", fileDescriptorVirtualhost) }
 
 var fileDescriptorVirtualhost = []byte{
-	// 791 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xe3, 0x36,
-	0x10, 0x8e, 0x6c, 0xc7, 0x59, 0x8d, 0x64, 0xaf, 0xc3, 0x64, 0xbb, 0x82, 0xd1, 0xd6, 0x5e, 0x05,
-	0x05, 0xdc, 0xa2, 0x75, 0xb0, 0x29, 0x8a, 0x74, 0x03, 0x74, 0x0f, 0x6e, 0xb3, 0xf0, 0x61, 0xb7,
-	0x48, 0x99, 0xfe, 0x1c, 0x05, 0xc6, 0x1e, 0xc9, 0x42, 0x6d, 0x49, 0x21, 0x29, 0xc7, 0x7e, 0x8a,
-	0x02, 0x7d, 0x8a, 0x3e, 0x4e, 0x9f, 0x20, 0x87, 0xbe, 0x41, 0x7b, 0xea, 0xb1, 0x20, 0x29, 0xd9,
-	0x4a, 0xea, 0xcb, 0xde, 0x38, 0x1f, 0xbf, 0x6f, 0x66, 0xf8, 0x71, 0x48, 0x38, 0x5c, 0xc6, 0x5c,
-	0xe6, 0x6c, 0x3e, 0x4b, 0x85, 0x1c, 0x66, 0x3c, 0x95, 0x29, 0xa9, 0x2d, 0x5f, 0x76, 0x3f, 0x8c,
-	0xd2, 0x34, 0x9a, 0xe3, 0xa9, 0x46, 0x6e, 0xf2, 0xf0, 0x54, 0x48, 0x9e, 0x4f, 0x0a, 0x46, 0xf7,
-	0x38, 0x4a, 0xa3, 0x54, 0x2f, 0x4f, 0xd5, 0xaa, 0x40, 0x5d, 0x21, 0x99, 0xcc, 0x45, 0x11, 0xb5,
-	0x17, 0x28, 0xd9, 0x94, 0x49, 0x66, 0x62, 0xff, 0x6f, 0x0b, 0x9c, 0x9f, 0x4d, 0xad, 0x71, 0x2a,
-	0x24, 0x21, 0xd0, 0x48, 0xd8, 0x02, 0x3d, 0xab, 0x6f, 0x0d, 0x6c, 0xaa, 0xd7, 0xc4, 0x83, 0x83,
-	0x69, 0xba, 0x60, 0x71, 0x22, 0xbc, 0x5a, 0xbf, 0x3e, 0xb0, 0x69, 0x19, 0x92, 0x17, 0xd0, 0xe4,
-	0x69, 0x2e, 0x51, 0x78, 0xf5, 0x7e, 0x7d, 0xe0, 0x9c, 0xd9, 0xc3, 0xe5, 0xcb, 0x21, 0x55, 0x08,
-	0x2d, 0x36, 0xc8, 0xe7, 0x00, 0x42, 0xcc, 0x83, 0x49, 0x9a, 0x84, 0x71, 0xe4, 0x35, 0xfa, 0xd6,
-	0xc0, 0x39, 0x6b, 0x29, 0xda, 0xf5, 0xf5, 0xdb, 0x6f, 0x35, 0x48, 0x6d, 0x21, 0xe6, 0x66, 0x49,
-	0x5e, 0x41, 0xd3, 0xb4, 0xeb, 0xed, 0x6b, 0x26, 0x68, 0xa6, 0x46, 0x46, 0xcf, 0xfe, 0xb9, 0xef,
-	0x1d, 0x4a, 0x14, 0x72, 0x1a, 0x87, 0xe1, 0x85, 0x1f, 0x47, 0x49, 0xca, 0xd1, 0xa7, 0x85, 0x80,
-	0x0c, 0xe0, 0x49, 0x79, 0x36, 0xaf, 0xa9, 0xc5, 0xae, 0x12, 0xbf, 0x2b, 0x30, 0xba, 0xd9, 0xf5,
-	0xff, 0xad, 0xc1, 0xbe, 0x6e, 0x92, 0x7c, 0x03, 0x4f, 0x39, 0xde, 0xe6, 0x28, 0x64, 0xb0, 0x60,
-	0x72, 0x32, 0x43, 0xae, 0x0f, 0xee, 0x9c, 0x11, 0x7d, 0x10, 0xb3, 0xf5, 0xce, 0xec, 0x8c, 0xf7,
-	0x68, 0x9b, 0x3f, 0x40, 0xc8, 0x39, 0xb4, 0x70, 0x89, 0xc9, 0x56, 0x5c, 0xd3, 0xe2, 0x8e, 0x12,
-	0x5f, 0xaa, 0x8d, 0xad, 0xd4, 0xc5, 0x4a, 0x4c, 0xde, 0xc2, 0xb3, 0x45, 0x3e, 0x97, 0x71, 0x36,
-	0xc7, 0x60, 0x8a, 0x42, 0xc6, 0x09, 0x93, 0x71, 0x9a, 0x94, 0x36, 0x3e, 0x57, 0x09, 0x7e, 0xc1,
-	0x38, 0x9a, 0x49, 0x9c, 0x7e, 0xb7, 0xdd, 0xa7, 0xc7, 0xa5, 0xaa, 0x02, 0x0a, 0xf2, 0x1a, 0x88,
-	0x88, 0x93, 0xe8, 0x61, 0xae, 0xc2, 0xea, 0xa7, 0x2a, 0x55, 0x35, 0xc5, 0xa1, 0xa1, 0x56, 0x20,
-	0xf2, 0x09, 0xb4, 0x33, 0x8e, 0x61, 0xbc, 0x0a, 0x38, 0xde, 0xf1, 0x58, 0xa2, 0x36, 0xdf, 0xa6,
-	0x2d, 0x83, 0x52, 0x03, 0x92, 0x73, 0x00, 0x5c, 0x49, 0x4c, 0x84, 0xee, 0xd4, 0x58, 0xfc, 0x7c,
-	0x68, 0x26, 0x72, 0x58, 0x4e, 0xe4, 0xf0, 0x5a, 0x4f, 0x24, 0xad, 0x50, 0x47, 0x36, 0x1c, 0x14,
-	0x06, 0xf9, 0xbf, 0xd7, 0xa1, 0xfd, 0xd0, 0x56, 0xf2, 0x02, 0x9c, 0x8c, 0xc9, 0x59, 0x60, 0x8a,
-	0x99, 0xc1, 0x1b, 0xef, 0x51, 0x50, 0xe0, 0x95, 0xc6, 0x48, 0x0f, 0x74, 0x14, 0x70, 0x8c, 0x70,
-	0xa5, 0x4d, 0x56, 0x0c, 0x5b, 0x61, 0x54, 0x41, 0x1b, 0x02, 0xae, 0xd8, 0x44, 0x7a, 0xf5, 0x2a,
-	0xe1, 0x52, 0x41, 0xe4, 0x15, 0x1c, 0xcc, 0x90, 0x4d, 0x91, 0x0b, 0xaf, 0xa1, 0x2d, 0xee, 0xfd,
-	0xff, 0x82, 0x87, 0x63, 0xc3, 0xb8, 0x4c, 0x24, 0x5f, 0xd3, 0x92, 0x4f, 0xde, 0x80, 0x7b, 0x9b,
-	0x23, 0x5f, 0x07, 0x19, 0xe3, 0x6c, 0xa1, 0x06, 0x53, 0xe9, 0x4f, 0x76, 0xe8, 0x7f, 0x50, 0xb4,
-	0x2b, 0xcd, 0x32, 0x39, 0x9c, 0xdb, 0x2d, 0x42, 0x8e, 0x61, 0x7f, 0x89, 0xfc, 0x46, 0x39, 0xa7,
-	0xde, 0x90, 0x09, 0xba, 0x17, 0xe0, 0x56, 0xcb, 0x92, 0x0e, 0xd4, 0x7f, 0xc5, 0x75, 0xf1, 0xfc,
-	0xd4, 0x52, 0xeb, 0xd8, 0x3c, 0x47, 0x73, 0x6e, 0x6a, 0x82, 0x8b, 0xda, 0xd7, 0x56, 0xf7, 0x35,
-	0x74, 0x1e, 0x97, 0x7c, 0x1f, 0xfd, 0xa8, 0x09, 0x0d, 0xe5, 0x90, 0xff, 0x05, 0xb8, 0xd5, 0x69,
-	0x25, 0x1f, 0x01, 0x98, 0xb1, 0x96, 0xeb, 0xac, 0xfc, 0x09, 0x6c, 0x8d, 0xfc, 0xb8, 0xce, 0xd0,
-	0x0f, 0xe1, 0x68, 0xc7, 0x6c, 0x92, 0x73, 0x70, 0xaa, 0xe3, 0x67, 0xed, 0x1c, 0xbf, 0x51, 0xe3,
-	0xcf, 0xfb, 0x9e, 0x45, 0xab, 0x4c, 0xf2, 0x01, 0x34, 0xef, 0x74, 0x3e, 0xdd, 0x61, 0x8b, 0x16,
-	0x91, 0xff, 0x9b, 0x05, 0x4e, 0xb5, 0xc0, 0x57, 0xf0, 0x24, 0xcc, 0x93, 0x49, 0x25, 0xbb, 0x7e,
-	0x27, 0x6f, 0x0a, 0xac, 0x42, 0x1d, 0xef, 0xd1, 0x0d, 0x55, 0xc9, 0xf2, 0x4c, 0x48, 0x8e, 0x6c,
-	0x51, 0xbc, 0x4f, 0x2d, 0xfb, 0xa9, 0xc0, 0x1e, 0xc9, 0x4a, 0xea, 0x88, 0x40, 0xa7, 0xd2, 0xa4,
-	0xb6, 0xc2, 0x0f, 0xe0, 0x68, 0x47, 0x35, 0x72, 0x02, 0xad, 0x52, 0x16, 0x54, 0x3e, 0x4f, 0xb7,
-	0x04, 0xbf, 0x57, 0x9f, 0xe8, 0x09, 0xb4, 0xca, 0x96, 0x0c, 0xc9, 0x5c, 0x87, 0x5b, 0x82, 0x8a,
-	0xe4, 0x7f, 0x0a, 0x47, 0x3b, 0xfa, 0xda, 0xf5, 0x29, 0xfb, 0x9f, 0x81, 0xbd, 0xf9, 0x41, 0xd5,
-	0x8d, 0x09, 0x9c, 0x70, 0x94, 0x01, 0xc7, 0xb0, 0xbc, 0x31, 0x83, 0x50, 0x0c, 0x47, 0x8d, 0x3f,
-	0xfe, 0xfa, 0xd8, 0xba, 0x69, 0xea, 0x37, 0xfa, 0xe5, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xc4,
-	0x18, 0x64, 0x78, 0x5c, 0x06, 0x00, 0x00,
+	// 845 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x55, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x36, 0x29, 0x59, 0x36, 0x87, 0x94, 0x63, 0xaf, 0x9d, 0x86, 0x30, 0xda, 0x4a, 0xa1, 0x51,
+	0x40, 0xfd, 0x93, 0x11, 0x17, 0x85, 0x1b, 0x03, 0xcd, 0x41, 0x8d, 0x03, 0x1d, 0x92, 0x22, 0x5d,
+	0xa7, 0xed, 0x91, 0x58, 0x53, 0x43, 0x8a, 0xa8, 0x44, 0xd2, 0xbb, 0x4b, 0xc5, 0x7a, 0x8a, 0x02,
+	0x7d, 0x80, 0x9e, 0xfb, 0x32, 0x05, 0xfa, 0x04, 0x39, 0xf4, 0x11, 0x7a, 0xea, 0xb1, 0xd8, 0x5d,
+	0xd2, 0xa2, 0x5c, 0x5d, 0x7a, 0xeb, 0x6d, 0xe6, 0xdb, 0xef, 0x9b, 0x9d, 0x9d, 0x99, 0xdd, 0x85,
+	0x83, 0x45, 0xca, 0x65, 0xc9, 0x66, 0xd3, 0x5c, 0xc8, 0x61, 0xc1, 0x73, 0x99, 0x13, 0x7b, 0xf1,
+	0xe4, 0xf8, 0xfd, 0x24, 0xcf, 0x93, 0x19, 0x9e, 0x6a, 0xe4, 0xba, 0x8c, 0x4f, 0x85, 0xe4, 0x65,
+	0x54, 0x31, 0x8e, 0x8f, 0x92, 0x3c, 0xc9, 0xb5, 0x79, 0xaa, 0xac, 0x0a, 0xf5, 0x84, 0x64, 0xb2,
+	0x14, 0x95, 0xb7, 0x37, 0x47, 0xc9, 0x26, 0x4c, 0x32, 0xe3, 0x07, 0xbf, 0xda, 0xe0, 0xfe, 0x60,
+	0xf6, 0x1a, 0xe7, 0x42, 0x12, 0x02, 0xed, 0x8c, 0xcd, 0xd1, 0xb7, 0xfa, 0xd6, 0xc0, 0xa1, 0xda,
+	0x26, 0x3e, 0xec, 0x4c, 0xf2, 0x39, 0x4b, 0x33, 0xe1, 0xdb, 0xfd, 0xd6, 0xc0, 0xa1, 0xb5, 0x4b,
+	0x1e, 0x43, 0x87, 0xe7, 0xa5, 0x44, 0xe1, 0xb7, 0xfa, 0xad, 0x81, 0x7b, 0xe6, 0x0c, 0x17, 0x4f,
+	0x86, 0x54, 0x21, 0xb4, 0x5a, 0x20, 0x9f, 0x01, 0x08, 0x31, 0x0b, 0xa3, 0x3c, 0x8b, 0xd3, 0xc4,
+	0x6f, 0xf7, 0xad, 0x81, 0x7b, 0xd6, 0x55, 0xb4, 0xab, 0xab, 0x97, 0xdf, 0x68, 0x90, 0x3a, 0x42,
+	0xcc, 0x8c, 0x49, 0x9e, 0x42, 0xc7, 0xa4, 0xeb, 0x6f, 0x6b, 0x26, 0x68, 0xa6, 0x46, 0x46, 0x0f,
+	0xff, 0x7a, 0xd7, 0x3b, 0x90, 0x28, 0xe4, 0x24, 0x8d, 0xe3, 0x8b, 0x20, 0x4d, 0xb2, 0x9c, 0x63,
+	0x40, 0x2b, 0x01, 0x19, 0xc0, 0x6e, 0x7d, 0x36, 0xbf, 0xa3, 0xc5, 0x9e, 0x12, 0xbf, 0xaa, 0x30,
+	0x7a, 0xb7, 0x4a, 0x3e, 0x05, 0x90, 0x51, 0x11, 0x56, 0x99, 0xef, 0xf4, 0x5b, 0x35, 0xf7, 0x4d,
+	0x54, 0x98, 0xe4, 0x1d, 0x59, 0x59, 0x22, 0xf8, 0xdb, 0x86, 0x6d, 0x6d, 0x92, 0xaf, 0xe1, 0x01,
+	0xc7, 0x9b, 0x12, 0x85, 0x0c, 0xe7, 0x4c, 0x46, 0x53, 0xe4, 0xba, 0x4a, 0xee, 0x19, 0xd1, 0xa7,
+	0x36, 0x4b, 0xaf, 0xcc, 0xca, 0x78, 0x8b, 0xee, 0xf1, 0x35, 0x84, 0x9c, 0x43, 0x17, 0x17, 0x98,
+	0xad, 0xc4, 0xb6, 0x16, 0xef, 0x2b, 0xf1, 0xa5, 0x5a, 0x58, 0x49, 0x3d, 0x6c, 0xf8, 0xe4, 0x25,
+	0x3c, 0x9c, 0x97, 0x33, 0x99, 0x16, 0x33, 0x0c, 0x27, 0x28, 0x64, 0x9a, 0x31, 0x99, 0xe6, 0x59,
+	0x5d, 0xf3, 0x47, 0x2a, 0xc0, 0x8f, 0x98, 0x26, 0x53, 0x89, 0x93, 0xe7, 0xab, 0x75, 0x7a, 0x54,
+	0xab, 0x1a, 0xa0, 0x20, 0xcf, 0x80, 0x88, 0x34, 0x4b, 0xd6, 0x63, 0x55, 0x7d, 0x79, 0xa0, 0x42,
+	0x35, 0x43, 0x1c, 0x18, 0x6a, 0x03, 0x22, 0x1f, 0xc1, 0x5e, 0xc1, 0x31, 0x4e, 0x6f, 0x43, 0x8e,
+	0x6f, 0x79, 0x2a, 0x51, 0x77, 0xca, 0xa1, 0x5d, 0x83, 0x52, 0x03, 0x92, 0x73, 0x00, 0xbc, 0x95,
+	0x98, 0x09, 0x9d, 0xa9, 0xe9, 0xc7, 0xa3, 0xa1, 0x19, 0xdf, 0x61, 0x3d, 0xbe, 0xc3, 0x2b, 0x3d,
+	0xbe, 0xb4, 0x41, 0x1d, 0x39, 0xb0, 0x53, 0x15, 0x28, 0xf8, 0xdd, 0x82, 0xdd, 0xba, 0x25, 0x6a,
+	0x30, 0x8b, 0x9c, 0x4b, 0x5d, 0xf2, 0x2e, 0xd5, 0x36, 0xe9, 0x81, 0x2b, 0xb2, 0x34, 0x5c, 0x1f,
+	0x4e, 0x10, 0x59, 0xfa, 0xdc, 0x20, 0xff, 0xaf, 0xd2, 0x05, 0xbf, 0xb4, 0x60, 0x6f, 0x7d, 0x4c,
+	0xc8, 0x63, 0x70, 0x0b, 0x26, 0xa7, 0xa1, 0x29, 0x9e, 0xb9, 0x75, 0xe3, 0x2d, 0x0a, 0x0a, 0x7c,
+	0xad, 0x31, 0xd2, 0x03, 0xed, 0x85, 0x1c, 0x13, 0xbc, 0xf5, 0xed, 0x8a, 0xe1, 0x28, 0x8c, 0x2a,
+	0xe8, 0x8e, 0x80, 0xb7, 0x2c, 0x92, 0x7e, 0xab, 0x49, 0xb8, 0x54, 0x10, 0x79, 0x0a, 0x3b, 0x53,
+	0x64, 0x13, 0xe4, 0xc2, 0x6f, 0xeb, 0x73, 0xf7, 0xfe, 0x3d, 0xb0, 0xc3, 0xb1, 0x61, 0x5c, 0x66,
+	0x92, 0x2f, 0x69, 0xcd, 0x27, 0x2f, 0xc0, 0xbb, 0x29, 0x91, 0x2f, 0xc3, 0x82, 0x71, 0x36, 0x57,
+	0xb7, 0x52, 0xe9, 0x4f, 0x36, 0xe8, 0xbf, 0x53, 0xb4, 0xd7, 0x9a, 0x65, 0x62, 0xb8, 0x37, 0x2b,
+	0x84, 0x1c, 0xc1, 0xf6, 0x02, 0xf9, 0xb5, 0x9a, 0x04, 0xd5, 0x23, 0xe3, 0x1c, 0x5f, 0x80, 0xd7,
+	0xdc, 0x96, 0xec, 0x43, 0xeb, 0x27, 0x5c, 0x56, 0x6f, 0x8f, 0x32, 0xb5, 0x8e, 0xcd, 0x4a, 0x34,
+	0xe7, 0xa6, 0xc6, 0xb9, 0xb0, 0xbf, 0xb2, 0x8e, 0x9f, 0xc1, 0xfe, 0xfd, 0x2d, 0xff, 0x8b, 0x7e,
+	0xd4, 0x81, 0xb6, 0xaa, 0x50, 0xf0, 0x39, 0x78, 0xcd, 0xdb, 0x47, 0x3e, 0x00, 0x30, 0xd7, 0x54,
+	0x2e, 0x8b, 0xfa, 0x19, 0x74, 0x34, 0xf2, 0x66, 0x59, 0x60, 0x10, 0xc3, 0xe1, 0x86, 0x81, 0x21,
+	0xe7, 0xe0, 0x36, 0x67, 0xc2, 0xda, 0x38, 0x13, 0xa3, 0xf6, 0x1f, 0xef, 0x7a, 0x16, 0x6d, 0x32,
+	0xc9, 0x7b, 0xd0, 0x79, 0xab, 0xe3, 0xe9, 0x0c, 0xbb, 0xb4, 0xf2, 0x82, 0x9f, 0x2d, 0x70, 0x9b,
+	0x1b, 0x7c, 0x09, 0xbb, 0x71, 0x99, 0x45, 0x8d, 0xe8, 0x7a, 0x78, 0x5f, 0x54, 0x58, 0x83, 0x3a,
+	0xde, 0xa2, 0x77, 0x54, 0x25, 0x2b, 0x0b, 0x21, 0x39, 0xb2, 0xb9, 0x6f, 0xaf, 0x64, 0xdf, 0x57,
+	0xd8, 0x3d, 0x59, 0x4d, 0x1d, 0x11, 0xd8, 0x6f, 0x24, 0xa9, 0x4b, 0x11, 0x84, 0x70, 0xb8, 0x61,
+	0x37, 0x72, 0x02, 0xdd, 0x5a, 0x16, 0x36, 0x7e, 0x0e, 0xaf, 0x06, 0xbf, 0x55, 0x3f, 0xc8, 0x09,
+	0x74, 0xeb, 0x94, 0x0c, 0xc9, 0xb4, 0xc3, 0xab, 0x41, 0x45, 0x0a, 0x3e, 0x86, 0xc3, 0x0d, 0x79,
+	0x6d, 0xfa, 0x91, 0x82, 0x4f, 0xc0, 0xb9, 0xfb, 0x3e, 0x54, 0xc7, 0x04, 0x46, 0x1c, 0x65, 0xc8,
+	0x31, 0xae, 0x3b, 0x66, 0x10, 0x8a, 0xf1, 0xa8, 0xfd, 0xdb, 0x9f, 0x1f, 0x5a, 0xd7, 0x1d, 0xfd,
+	0xe6, 0x7c, 0xf1, 0xcf, 0x00, 0xf9, 0xa1, 0x13, 0xb9, 0x59, 0x07, 0x00, 0x00,
 }
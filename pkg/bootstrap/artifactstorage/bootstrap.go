@@ -6,8 +6,10 @@ import (
 	"github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/storage/dependencies"
 	consulfiles "github.com/solo-io/gloo/pkg/storage/dependencies/consul"
+	etcdfiles "github.com/solo-io/gloo/pkg/storage/dependencies/etcd"
 	filestorage "github.com/solo-io/gloo/pkg/storage/dependencies/file"
 	"github.com/solo-io/gloo/pkg/storage/dependencies/kube"
+	memoryfiles "github.com/solo-io/gloo/pkg/storage/dependencies/memory"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -40,6 +42,18 @@ func Bootstrap(opts bootstrap.Options) (dependencies.FileStorage, error) {
 			return nil, errors.Wrapf(err, "failed to start consul KV-based file storage client with config %#v", opts.ConsulOptions)
 		}
 		return store, nil
+	case bootstrap.WatcherTypeEtcd:
+		cfg, err := opts.EtcdOptions.ToEtcdConfig()
+		if err != nil {
+			return nil, err
+		}
+		store, err := etcdfiles.NewFileStorage(cfg, opts.EtcdOptions.RootPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to start etcd based file storage client with endpoints %v", opts.EtcdOptions.Endpoints)
+		}
+		return store, nil
+	case bootstrap.WatcherTypeMemory:
+		return memoryfiles.DefaultFileStorage(), nil
 	}
 	return nil, errors.Errorf("unknown or unspecified file storage client type: %v", opts.FileStorageOptions.Type)
 }
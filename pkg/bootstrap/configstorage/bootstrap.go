@@ -3,14 +3,29 @@ package configstorage
 import (
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/composite"
 	"github.com/solo-io/gloo/pkg/storage/consul"
 	"github.com/solo-io/gloo/pkg/storage/crd"
+	"github.com/solo-io/gloo/pkg/storage/etcd"
 	"github.com/solo-io/gloo/pkg/storage/file"
+	"github.com/solo-io/gloo/pkg/storage/git"
+	"github.com/solo-io/gloo/pkg/storage/memory"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// Bootstrap returns the config storage given by the options. upstreams and virtual hosts
+// are checked against the schemas plugins register for their specs before they are written
 func Bootstrap(opts bootstrap.Options) (storage.Interface, error) {
+	store, err := bootstrapBackend(opts)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.Storage(store), nil
+}
+
+func bootstrapBackend(opts bootstrap.Options) (storage.Interface, error) {
 	switch opts.ConfigStorageOptions.Type {
 	case bootstrap.WatcherTypeFile:
 		dir := opts.FileOptions.ConfigDir
@@ -27,7 +42,7 @@ func Bootstrap(opts bootstrap.Options) (storage.Interface, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "building kube restclient")
 		}
-		cfgWatcher, err := crd.NewStorage(cfg, opts.KubeOptions.Namespace, opts.ConfigStorageOptions.SyncFrequency)
+		cfgWatcher, err := crd.NewStorage(cfg, opts.KubeOptions.Namespace, opts.ConfigStorageOptions.SyncFrequency, opts.KubeOptions.WatchNamespaces...)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to start kube config watcher with config %#v", opts.KubeOptions)
 		}
@@ -39,6 +54,51 @@ func Bootstrap(opts bootstrap.Options) (storage.Interface, error) {
 			return nil, errors.Wrapf(err, "failed to start consul config watcher with config %#v", opts.ConsulOptions)
 		}
 		return cfgWatcher, nil
+	case bootstrap.WatcherTypeEtcd:
+		cfg, err := opts.EtcdOptions.ToEtcdConfig()
+		if err != nil {
+			return nil, err
+		}
+		cfgWatcher, err := etcd.NewStorage(cfg, opts.EtcdOptions.RootPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to start etcd config watcher with endpoints %v", opts.EtcdOptions.Endpoints)
+		}
+		return cfgWatcher, nil
+	case bootstrap.WatcherTypeMemory:
+		return memory.DefaultStorage(), nil
+	case bootstrap.WatcherTypeGit:
+		gitOpts := opts.GitOptions
+		if gitOpts.Repo == "" {
+			return nil, errors.New("must provide the repository for git config watcher")
+		}
+		cfgWatcher, err := git.NewStorage(gitOpts.Repo, gitOpts.Ref, gitOpts.Dir, gitOpts.StatusBranch, opts.ConfigStorageOptions.SyncFrequency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to start git config watcher for repository %v", gitOpts.Repo)
+		}
+		return cfgWatcher, nil
+	case bootstrap.WatcherTypeComposite:
+		compositeOpts := opts.CompositeOptions
+		if len(compositeOpts.Backends) == 0 {
+			return nil, errors.New("must provide the backends for composite config watcher")
+		}
+		var backends []composite.Backend
+		for _, backendType := range compositeOpts.Backends {
+			if backendType == bootstrap.WatcherTypeComposite {
+				return nil, errors.New("composite config watcher cannot be a backend of itself")
+			}
+			backendOpts := opts
+			backendOpts.ConfigStorageOptions.Type = backendType
+			backend, err := bootstrapBackend(backendOpts)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to start %v backend of composite config watcher", backendType)
+			}
+			backends = append(backends, composite.Backend{Name: backendType, Storage: backend})
+		}
+		cfgWatcher, err := composite.NewStorage(backends, compositeOpts.UpstreamsTarget, compositeOpts.VirtualHostsTarget)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start composite config watcher")
+		}
+		return cfgWatcher, nil
 	}
 	return nil, errors.Errorf("unknown or unspecified config watcher type: %v", opts.ConfigStorageOptions.Type)
 }
@@ -0,0 +1,12 @@
+package flags
+
+import (
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddCompositeFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringSliceVar(&opts.CompositeOptions.Backends, "composite.backends", nil, "config storage types read by composite storage, in order of precedence. a config object hides those of the same kind and name in later backends")
+	cmd.PersistentFlags().StringVar(&opts.CompositeOptions.UpstreamsTarget, "composite.upstreams-target", "", "config storage type new upstreams are created in, when using composite storage. defaults to the first backend")
+	cmd.PersistentFlags().StringVar(&opts.CompositeOptions.VirtualHostsTarget, "composite.virtualhosts-target", "", "config storage type new virtual hosts are created in, when using composite storage. defaults to the first backend")
+}
@@ -0,0 +1,19 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddEtcdFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringVar(&opts.EtcdOptions.RootPath, "etcd.root", "gloo", "prefix for all keys stored in etcd by gloo, when using etcd for storage")
+	cmd.PersistentFlags().StringSliceVar(&opts.EtcdOptions.Endpoints, "etcd.endpoints", []string{"127.0.0.1:2379"}, "comma-separated client URLs of the etcd cluster members to connect to when using etcd for storage")
+	cmd.PersistentFlags().DurationVar(&opts.EtcdOptions.DialTimeout, "etcd.dial-timeout", 5*time.Second, "how long to wait to connect to an etcd endpoint")
+	cmd.PersistentFlags().StringVar(&opts.EtcdOptions.Username, "etcd.username", "", "username for authenticating to etcd")
+	cmd.PersistentFlags().StringVar(&opts.EtcdOptions.Password, "etcd.password", "", "password for authenticating to etcd")
+	cmd.PersistentFlags().StringVar(&opts.EtcdOptions.CertFile, "etcd.cert-file", "", "client certificate for connecting to etcd over tls")
+	cmd.PersistentFlags().StringVar(&opts.EtcdOptions.KeyFile, "etcd.key-file", "", "client key for connecting to etcd over tls")
+	cmd.PersistentFlags().StringVar(&opts.EtcdOptions.CAFile, "etcd.ca-file", "", "CA bundle used to verify the etcd server certificate")
+}
@@ -0,0 +1,13 @@
+package flags
+
+import (
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddGitFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringVar(&opts.GitOptions.Repo, "git.repo", "", "path of the local git repository to read config objects from, when using git for storage. may be a bare repository")
+	cmd.PersistentFlags().StringVar(&opts.GitOptions.Ref, "git.ref", "master", "branch, tag or commit of the git repository to read config objects from")
+	cmd.PersistentFlags().StringVar(&opts.GitOptions.Dir, "git.dir", "", "directory of the git repository containing the upstreams and virtualhosts directories. defaults to the root of the repository")
+	cmd.PersistentFlags().StringVar(&opts.GitOptions.StatusBranch, "git.status-branch", "gloo-status", "branch of the git repository the statuses of config objects are committed to. if empty, statuses are not written")
+}
@@ -0,0 +1,19 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddLeaderElectionFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringVar(&opts.LeaderElection.Type, "leader-election.type", "", fmt.Sprintf("backend for electing the replica that writes when running more than one. "+
+		"leave empty to disable leader election. supported: [%s]", strings.Join(bootstrap.SupportedLeaderElectionTypes, " | ")))
+	cmd.PersistentFlags().DurationVar(&opts.LeaderElection.LeaseDuration, "leader-election.lease-duration", 15*time.Second, "how long the leader keeps leadership without renewing it")
+	cmd.PersistentFlags().DurationVar(&opts.LeaderElection.RenewDeadline, "leader-election.renew-deadline", 10*time.Second, "how long the leader tries to renew its lease before giving up leadership (kube only)")
+	cmd.PersistentFlags().DurationVar(&opts.LeaderElection.RetryPeriod, "leader-election.retry-period", 2*time.Second, "how often other replicas try to acquire leadership")
+	cmd.PersistentFlags().StringVar(&opts.LeaderElection.LockFile, "leader-election.lock-file", "", "lock file to elect the leader with (file only). defaults to a file named after the component in the temp directory")
+}
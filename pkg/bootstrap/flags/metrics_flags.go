@@ -0,0 +1,10 @@
+package flags
+
+import (
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+func AddMetricsFlags(cmd *cobra.Command, opts *bootstrap.Options) {
+	cmd.PersistentFlags().StringVar(&opts.MetricsOptions.BindAddress, "metrics.address", ":9090", "address to serve prometheus metrics on. set to empty to disable metrics")
+}
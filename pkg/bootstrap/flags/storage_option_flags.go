@@ -17,6 +17,7 @@ func AddConfigStorageOptionFlags(cmd *cobra.Command, opts *bootstrap.Options) {
 func AddSecretStorageOptionFlags(cmd *cobra.Command, opts *bootstrap.Options) {
 	cmd.PersistentFlags().StringVar(&opts.SecretStorageOptions.Type, "secrets.type", bootstrap.WatcherTypeFile, fmt.Sprintf("storage backend for secrets. supported: [%s]", strings.Join(bootstrap.SupportedSwTypes, " | ")))
 	cmd.PersistentFlags().DurationVar(&opts.SecretStorageOptions.SyncFrequency, "secrets.refreshrate", time.Second, "refresh rate for polling secrets")
+	cmd.PersistentFlags().StringSliceVar(&opts.SecretSchemes, "secrets.schemes", nil, fmt.Sprintf("additional storage backends for secrets, read for secret refs prefixed with their type as a scheme, e.g. vault://path. supported: [%s]", strings.Join(bootstrap.SupportedSwTypes, " | ")))
 }
 
 func AddFileStorageOptionFlags(cmd *cobra.Command, opts *bootstrap.Options) {
@@ -0,0 +1,41 @@
+package leaderelection
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/solo-io/gloo/pkg/leaderelection"
+)
+
+// Bootstrap creates the elector for the replicas of the named component
+func Bootstrap(opts bootstrap.Options, name string) (leaderelection.Elector, error) {
+	electionOpts := opts.LeaderElection
+	identity := leaderelection.Identity()
+	switch electionOpts.Type {
+	case "":
+		return leaderelection.AlwaysLeader(), nil
+	case bootstrap.WatcherTypeKube:
+		cfg, err := clientcmd.BuildConfigFromFlags(opts.KubeOptions.MasterURL, opts.KubeOptions.KubeConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "building kube restclient")
+		}
+		return leaderelection.NewKubeElector(cfg, opts.KubeOptions.Namespace, name, identity,
+			electionOpts.LeaseDuration, electionOpts.RenewDeadline, electionOpts.RetryPeriod)
+	case bootstrap.WatcherTypeConsul:
+		key := path.Join(opts.ConsulOptions.RootPath, "leader", name)
+		return leaderelection.NewConsulElector(opts.ConsulOptions.ToConsulConfig(), key, identity,
+			electionOpts.LeaseDuration, electionOpts.RetryPeriod)
+	case bootstrap.WatcherTypeFile:
+		lockFile := electionOpts.LockFile
+		if lockFile == "" {
+			lockFile = filepath.Join(os.TempDir(), name+".lock")
+		}
+		return leaderelection.NewFileElector(lockFile, identity, electionOpts.RetryPeriod), nil
+	}
+	return nil, errors.Errorf("unknown leader election type: %v", electionOpts.Type)
+}
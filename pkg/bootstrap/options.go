@@ -3,7 +3,10 @@ package bootstrap
 import (
 	"time"
 
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/transport"
 	"github.com/hashicorp/consul/api"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -11,6 +14,13 @@ const (
 	WatcherTypeConsul = "consul"
 	WatcherTypeFile   = "file"
 	WatcherTypeVault  = "vault"
+	WatcherTypeEtcd   = "etcd"
+	// config objects read from a git repository. gloo does not write them
+	WatcherTypeGit = "git"
+	// in-memory storage is shared by every component in the process and lost when it exits
+	WatcherTypeMemory = "memory"
+	// config objects read from several of the other config storage types
+	WatcherTypeComposite = "composite"
 )
 
 var (
@@ -18,16 +28,29 @@ var (
 		WatcherTypeFile,
 		WatcherTypeKube,
 		WatcherTypeConsul,
+		WatcherTypeEtcd,
+		WatcherTypeMemory,
+		WatcherTypeGit,
+		WatcherTypeComposite,
 	}
 	SupportedFwTypes = []string{
 		WatcherTypeConsul,
 		WatcherTypeFile,
 		WatcherTypeKube,
+		WatcherTypeEtcd,
+		WatcherTypeMemory,
+	}
+	SupportedLeaderElectionTypes = []string{
+		WatcherTypeKube,
+		WatcherTypeConsul,
+		WatcherTypeFile,
 	}
 	SupportedSwTypes = []string{
 		WatcherTypeVault,
 		WatcherTypeKube,
 		WatcherTypeFile,
+		WatcherTypeEtcd,
+		WatcherTypeMemory,
 	}
 )
 
@@ -35,12 +58,21 @@ type Options struct {
 	// these 3 get copied around. fun, i know
 	KubeOptions          KubeOptions
 	ConsulOptions        ConsulOptions
+	EtcdOptions          EtcdOptions
+	GitOptions           GitOptions
+	CompositeOptions     CompositeOptions
 	ConfigStorageOptions StorageOptions
 	CoPilotOptions       CoPilotOptions
 	SecretStorageOptions StorageOptions
 	FileStorageOptions   StorageOptions
 	FileOptions          FileOptions
 	VaultOptions         VaultOptions
+	MetricsOptions       MetricsOptions
+	LeaderElection       LeaderElectionOptions
+
+	// SecretSchemes are the secret storage types that secret refs prefixed with the type as a scheme,
+	// e.g. "vault://path", are read from. refs without a scheme are read from SecretStorageOptions.Type
+	SecretSchemes []string
 }
 
 type StorageOptions struct {
@@ -52,6 +84,8 @@ type KubeOptions struct {
 	KubeConfig string
 	MasterURL  string
 	Namespace  string // where to watch for storage
+	// other namespaces to read config objects and secrets from. refs to objects in them are qualified with the namespace
+	WatchNamespaces []string
 }
 
 type ConsulOptions struct {
@@ -117,6 +151,71 @@ func (o ConsulOptions) ToConsulConfig() *api.Config {
 	return cfg
 }
 
+type EtcdOptions struct {
+	// Endpoints are the client URLs of the etcd cluster members
+	Endpoints []string
+
+	// DialTimeout is how long to wait to connect to an endpoint
+	DialTimeout time.Duration
+
+	// Username and Password are used to authenticate to etcd, if set
+	Username string
+	Password string
+
+	// CertFile, KeyFile and CAFile configure TLS, if set
+	CertFile string
+	KeyFile  string
+	CAFile   string
+
+	// RootPath is used as the prefix for all keys stored
+	// in etcd by gloo
+	RootPath string
+}
+
+func (o EtcdOptions) ToEtcdConfig() (clientv3.Config, error) {
+	cfg := clientv3.Config{
+		Endpoints:   o.Endpoints,
+		DialTimeout: o.DialTimeout,
+		Username:    o.Username,
+		Password:    o.Password,
+	}
+	if o.CertFile == "" && o.KeyFile == "" && o.CAFile == "" {
+		return cfg, nil
+	}
+	// without a client certificate, the CA is only used to authenticate the server
+	tlsConfig, err := (&transport.TLSInfo{
+		CertFile:      o.CertFile,
+		KeyFile:       o.KeyFile,
+		TrustedCAFile: o.CAFile,
+	}).ClientConfig()
+	if err != nil {
+		return clientv3.Config{}, errors.Wrap(err, "loading etcd tls config")
+	}
+	cfg.TLS = tlsConfig
+	return cfg, nil
+}
+
+type GitOptions struct {
+	// Repo is the path of a local git repository, which may be bare
+	Repo string
+	// Ref is the branch, tag or commit config objects are read from
+	Ref string
+	// Dir is the directory of the repository containing the upstreams and virtualhosts directories
+	Dir string
+	// StatusBranch is the branch the statuses of config objects are committed to.
+	// empty disables writing statuses
+	StatusBranch string
+}
+
+type CompositeOptions struct {
+	// Backends are the config storage types read by composite storage, in order of precedence
+	Backends []string
+	// UpstreamsTarget is the backend new upstreams are created in. defaults to the first backend
+	UpstreamsTarget string
+	// VirtualHostsTarget is the backend new virtual hosts are created in. defaults to the first backend
+	VirtualHostsTarget string
+}
+
 type VaultOptions struct {
 	VaultAddr      string
 	VaultToken     string
@@ -131,6 +230,24 @@ type FileOptions struct {
 	FilesDir  string
 }
 
+type MetricsOptions struct {
+	// address to serve prometheus metrics on. empty disables metrics
+	BindAddress string
+}
+
+type LeaderElectionOptions struct {
+	// backend holding the leader lock. empty disables leader election; every replica writes
+	Type string
+	// how long a leader keeps leadership without renewing it
+	LeaseDuration time.Duration
+	// how long the leader keeps trying to renew before giving up leadership (kube only)
+	RenewDeadline time.Duration
+	// how often replicas that are not the leader try to acquire leadership
+	RetryPeriod time.Duration
+	// path of the lock file (file only)
+	LockFile string
+}
+
 type XdsOptions struct {
 	Port int
 }
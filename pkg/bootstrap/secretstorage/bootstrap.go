@@ -9,13 +9,41 @@ import (
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	"github.com/solo-io/gloo/pkg/storage/dependencies/etcd"
 	"github.com/solo-io/gloo/pkg/storage/dependencies/file"
 	"github.com/solo-io/gloo/pkg/storage/dependencies/kube"
+	"github.com/solo-io/gloo/pkg/storage/dependencies/memory"
+	"github.com/solo-io/gloo/pkg/storage/dependencies/schemes"
 	"github.com/solo-io/gloo/pkg/storage/dependencies/vault"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 func Bootstrap(opts bootstrap.Options) (dependencies.SecretStorage, error) {
+	defaultStorage, err := bootstrapBackend(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.SecretSchemes) == 0 {
+		return defaultStorage, nil
+	}
+	backends := make(map[string]dependencies.SecretStorage)
+	for _, scheme := range opts.SecretSchemes {
+		if scheme == opts.SecretStorageOptions.Type {
+			backends[scheme] = defaultStorage
+			continue
+		}
+		backendOpts := opts
+		backendOpts.SecretStorageOptions.Type = scheme
+		backend, err := bootstrapBackend(backendOpts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create secret storage for scheme %v", scheme)
+		}
+		backends[scheme] = backend
+	}
+	return schemes.NewSecretStorage(defaultStorage, backends)
+}
+
+func bootstrapBackend(opts bootstrap.Options) (dependencies.SecretStorage, error) {
 	switch opts.SecretStorageOptions.Type {
 	case bootstrap.WatcherTypeFile:
 		return file.NewSecretStorage(opts.FileOptions.SecretDir, opts.SecretStorageOptions.SyncFrequency)
@@ -24,7 +52,7 @@ func Bootstrap(opts bootstrap.Options) (dependencies.SecretStorage, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "building kube restclient")
 		}
-		return kube.NewSecretStorage(cfg, opts.KubeOptions.Namespace, opts.SecretStorageOptions.SyncFrequency)
+		return kube.NewSecretStorage(cfg, opts.KubeOptions.Namespace, opts.SecretStorageOptions.SyncFrequency, opts.KubeOptions.WatchNamespaces...)
 	case bootstrap.WatcherTypeVault:
 		cfg := api.DefaultConfig()
 		cfg.MaxRetries = opts.VaultOptions.Retries
@@ -51,6 +79,18 @@ func Bootstrap(opts bootstrap.Options) (dependencies.SecretStorage, error) {
 		}
 		vaultClient.SetToken(token)
 		return vault.NewSecretStorage(vaultClient, opts.VaultOptions.RootPath, opts.SecretStorageOptions.SyncFrequency), nil
+	case bootstrap.WatcherTypeEtcd:
+		cfg, err := opts.EtcdOptions.ToEtcdConfig()
+		if err != nil {
+			return nil, err
+		}
+		store, err := etcd.NewSecretStorage(cfg, opts.EtcdOptions.RootPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to start etcd based secret storage client with endpoints %v", opts.EtcdOptions.Endpoints)
+		}
+		return store, nil
+	case bootstrap.WatcherTypeMemory:
+		return memory.DefaultSecretStorage(), nil
 	}
 	return nil, errors.Errorf("unknown or unspecified secret watcher type: %v", opts.SecretStorageOptions.Type)
 }
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
+
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/gloo/pkg/storage"
@@ -30,7 +32,12 @@ func (c *UpstreamSyncer) SyncDesiredState() error {
 		return fmt.Errorf("failed to list actual upstreams: %v", err)
 	}
 	if err := c.syncUpstreams(desiredUpstreams, actualUpstreams); err != nil {
-		return fmt.Errorf("failed to sync actual with desired upstreams: %v", err)
+		if storage.IsNotLeader(err) {
+			// another replica is the leader, it writes the desired upstreams
+			log.Debugf("not syncing upstreams: %v", err)
+			return nil
+		}
+		return errors.Wrap(err, "failed to sync actual with desired upstreams")
 	}
 	return nil
 }
@@ -48,7 +55,7 @@ func (c *UpstreamSyncer) setOwnerAnnotation(uss []*v1.Upstream) {
 }
 
 func (c *UpstreamSyncer) getActualUpstreams() ([]*v1.Upstream, error) {
-	upstreams, err := c.GlooStorage.V1().Upstreams().List()
+	upstreams, err := c.GlooStorage.V1().Upstreams().List(nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upstream crd list: %v", err)
 	}
@@ -94,7 +101,7 @@ func (c *UpstreamSyncer) syncUpstreams(desiredUpstreams, actualUpstreams []*v1.U
 		// This workaround is necessary because the ingress controller may be running and creating upstreams
 		if _, err := c.GlooStorage.V1().Upstreams().Create(us); err != nil && !storage.IsAlreadyExists(err) {
 			log.Debugf("creating upstream %v", us.Name)
-			return fmt.Errorf("failed to create upstream crd %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to create upstream crd %s", us.Name)
 		}
 	}
 	for _, us := range upstreamsToUpdate {
@@ -102,7 +109,7 @@ func (c *UpstreamSyncer) syncUpstreams(desiredUpstreams, actualUpstreams []*v1.U
 		// preserve functions that may have already been discovered
 		currentUpstream, err := c.GlooStorage.V1().Upstreams().Get(us.Name)
 		if err != nil {
-			return fmt.Errorf("failed to get existing upstream %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to get existing upstream %s", us.Name)
 		}
 		// all we want to do is update the spec and merge the annotations
 		currentUpstream.Spec = us.Spec
@@ -111,14 +118,14 @@ func (c *UpstreamSyncer) syncUpstreams(desiredUpstreams, actualUpstreams []*v1.U
 		}
 		currentUpstream.Metadata.Annotations = mergeAnnotations(currentUpstream.Metadata.Annotations, us.Metadata.Annotations)
 		if _, err := c.GlooStorage.V1().Upstreams().Update(currentUpstream); err != nil {
-			return fmt.Errorf("failed to update upstream %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to update upstream %s", us.Name)
 		}
 	}
 	// only remaining are no longer desired, delete em!
 	for _, us := range actualUpstreams {
 		log.Debugf("deleting upstream %v", us.Name)
 		if err := c.GlooStorage.V1().Upstreams().Delete(us.Name); err != nil {
-			return fmt.Errorf("failed to update upstream crd %s: %v", us.Name, err)
+			return errors.Wrapf(err, "failed to update upstream crd %s", us.Name)
 		}
 	}
 	return nil
@@ -59,7 +59,7 @@ var _ = Describe("DesiredState", func() {
 		err := syncer.SyncDesiredState()
 		Expect(err).NotTo(HaveOccurred())
 
-		createdUpstreams, err := glooClient.V1().Upstreams().List()
+		createdUpstreams, err := glooClient.V1().Upstreams().List(nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(createdUpstreams).To(HaveLen(1))
 
@@ -76,7 +76,7 @@ var _ = Describe("DesiredState", func() {
 		err = syncer.SyncDesiredState()
 		Expect(err).NotTo(HaveOccurred())
 
-		createdUpstreams, err := glooClient.V1().Upstreams().List()
+		createdUpstreams, err := glooClient.V1().Upstreams().List(nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(createdUpstreams).To(HaveLen(1))
 	})
@@ -89,7 +89,7 @@ var _ = Describe("DesiredState", func() {
 		err = syncer.SyncDesiredState()
 		Expect(err).NotTo(HaveOccurred())
 
-		createdUpstreams, err := glooClient.V1().Upstreams().List()
+		createdUpstreams, err := glooClient.V1().Upstreams().List(nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(createdUpstreams).To(BeEmpty())
 	})
@@ -113,7 +113,7 @@ var _ = Describe("DesiredState", func() {
 		err = syncer.SyncDesiredState()
 		Expect(err).NotTo(HaveOccurred())
 
-		createdUpstreams, err := glooClient.V1().Upstreams().List()
+		createdUpstreams, err := glooClient.V1().Upstreams().List(nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(createdUpstreams).To(HaveLen(1))
 
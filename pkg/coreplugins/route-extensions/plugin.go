@@ -22,15 +22,16 @@ const (
 	pluginStage = plugins.InAuth
 )
 
-type Plugin struct {
-	corsFilterNeeded bool
-}
+type Plugin struct{}
+
+// contributed by each virtual host with a route that has a cors policy
+type corsFilterNeeded struct{}
 
 func (p *Plugin) GetDependencies(_ *v1.Config) *plugins.Dependencies {
 	return nil
 }
 
-func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
+func (p *Plugin) ProcessRoute(params *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
 	if in.Extensions == nil {
 		return nil
 	}
@@ -81,7 +82,7 @@ func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *e
 		}
 	}
 	if spec.Cors != nil {
-		p.corsFilterNeeded = true
+		params.Context.Contribute(corsFilterNeeded{}, true)
 		routeAction.Route.Cors = &envoyroute.CorsPolicy{
 			AllowOrigin:      spec.Cors.AllowOrigin,
 			AllowHeaders:     spec.Cors.AllowHeaders,
@@ -98,9 +99,7 @@ func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *e
 }
 
 func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
-	defer func() { p.corsFilterNeeded = false }()
-
-	if p.corsFilterNeeded {
+	if len(params.Context.Contributions(corsFilterNeeded{})) > 0 {
 		return []plugins.StagedFilter{{
 			HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage,
 		}}
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	. "github.com/solo-io/gloo/pkg/coreplugins/route-extensions"
+	"github.com/solo-io/gloo/pkg/plugins"
 	. "github.com/solo-io/gloo/test/helpers"
 )
 
@@ -17,7 +18,8 @@ var _ = Describe("Plugin", func() {
 			out := &envoyroute.Route{
 				Action: &envoyroute.Route_Route{},
 			}
-			err := plug.ProcessRoute(nil, route, out)
+			params := &plugins.RoutePluginParams{Context: plugins.NewContext(nil)}
+			err := plug.ProcessRoute(params, route, out)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(out.GetRoute()).NotTo(BeNil())
 			Expect(out.GetRoute().Cors).NotTo(BeNil())
@@ -26,4 +28,21 @@ var _ = Describe("Plugin", func() {
 			Expect(out.GetRoute().Cors.MaxAge).To(Equal("86400"))
 		})
 	})
+	Describe("HttpFilters", func() {
+		It("adds the cors filter only to translations with a cors policy", func() {
+			plug := &Plugin{}
+			ctx := plugins.NewContext(nil)
+			out := &envoyroute.Route{
+				Action: &envoyroute.Route_Route{},
+			}
+			err := plug.ProcessRoute(&plugins.RoutePluginParams{Context: ctx}, NewTestRouteWithCORS(), out)
+			Expect(err).NotTo(HaveOccurred())
+			filters := plug.HttpFilters(&plugins.FilterPluginParams{Context: ctx})
+			Expect(filters).To(HaveLen(1))
+			Expect(filters[0].HttpFilter.Name).To(Equal("envoy.cors"))
+
+			filters = plug.HttpFilters(&plugins.FilterPluginParams{Context: plugins.NewContext(nil)})
+			Expect(filters).To(BeEmpty())
+		})
+	})
 })
@@ -4,9 +4,25 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/types"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindRouteExtensions,
+		Type:        "route-extensions",
+		Version:     "v1",
+		Description: "headers, retries, timeouts, host rewrites and CORS policies of routes",
+		New:         func() interface{} { return &RouteExtensionSpec{} },
+		Example:     EncodeRouteExtensionSpec(RouteExtensionSpec{MaxRetries: 2, Timeout: time.Minute}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeRouteExtensions(spec)
+			return err
+		},
+	})
+}
+
 type RouteExtensionSpec struct {
 	AddRequestHeaders     []HeaderValue `json:"add_request_headers,omitempty"`
 	AddResponseHeaders    []HeaderValue `json:"add_response_headers,omitempty"`
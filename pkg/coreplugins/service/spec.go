@@ -1,11 +1,28 @@
 package service
 
 import (
+	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindUpstreamSpec,
+		Type:        UpstreamTypeService,
+		Version:     "v1",
+		Description: "the addresses of the hosts the upstream routes to",
+		New:         func() interface{} { return &UpstreamSpec{} },
+		Example:     EncodeUpstreamSpec(UpstreamSpec{Hosts: []Host{{Addr: "127.0.0.1", Port: 8080}}}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeUpstreamSpec(spec)
+			return err
+		},
+	})
+}
+
 type UpstreamSpec struct {
 	Hosts []Host `json:"hosts"`
 }
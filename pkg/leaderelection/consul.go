@@ -0,0 +1,73 @@
+package leaderelection
+
+import (
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+type consulElector struct {
+	state
+	client      *api.Client
+	key         string
+	identity    string
+	sessionTTL  time.Duration
+	retryPeriod time.Duration
+}
+
+// NewConsulElector elects the replica holding the lock on key.
+// the lock is bound to a consul session, which is invalidated if the leader does not renew it within sessionTTL
+func NewConsulElector(cfg *api.Config, key, identity string, sessionTTL, retryPeriod time.Duration) (Elector, error) {
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating consul client")
+	}
+	return &consulElector{
+		client:      client,
+		key:         key,
+		identity:    identity,
+		sessionTTL:  sessionTTL,
+		retryPeriod: retryPeriod,
+	}, nil
+}
+
+func (e *consulElector) Run(stop <-chan struct{}) error {
+	lock, err := e.client.LockOpts(&api.LockOptions{
+		Key:         e.key,
+		Value:       []byte(e.identity),
+		SessionName: e.identity,
+		SessionTTL:  e.sessionTTL.String(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "creating consul lock %v", e.key)
+	}
+	for {
+		// blocks until the lock is acquired or stop is closed
+		lost, err := lock.Lock(stop)
+		if err != nil {
+			log.Warnf("failed to acquire consul lock %v: %v", e.key, err)
+			select {
+			case <-time.After(e.retryPeriod):
+				continue
+			case <-stop:
+				return nil
+			}
+		}
+		if lost == nil {
+			return nil
+		}
+		log.Printf("%v acquired leader lock %v", e.identity, e.key)
+		e.setLeader(true)
+		select {
+		case <-lost:
+			log.Warnf("%v lost leader lock %v", e.identity, e.key)
+			e.setLeader(false)
+		case <-stop:
+			e.setLeader(false)
+			return lock.Unlock()
+		}
+	}
+}
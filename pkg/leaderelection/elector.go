@@ -0,0 +1,90 @@
+package leaderelection
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pborman/uuid"
+)
+
+// Elector campaigns for leadership among the replicas of a component.
+// only the leader should write; the other replicas keep watching so they are ready to take over
+type Elector interface {
+	// Run campaigns for leadership until stop is closed
+	Run(stop <-chan struct{}) error
+	// IsLeader returns true while this replica holds leadership
+	IsLeader() bool
+	// Elected returns a channel that receives each time this replica becomes the leader.
+	// every call returns a new channel
+	Elected() <-chan struct{}
+}
+
+// Identity identifies this replica in the lock held by the leader
+func Identity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%v_%v", hostname, uuid.New())
+}
+
+// state tracks leadership for the electors
+type state struct {
+	lock     sync.RWMutex
+	leader   bool
+	watchers []chan struct{}
+}
+
+func (s *state) IsLeader() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.leader
+}
+
+func (s *state) Elected() <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	elected := make(chan struct{}, 1)
+	s.watchers = append(s.watchers, elected)
+	if s.leader {
+		elected <- struct{}{}
+	}
+	return elected
+}
+
+func (s *state) setLeader(leader bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.leader == leader {
+		return
+	}
+	s.leader = leader
+	if !leader {
+		leadershipChanges.WithLabelValues("lost").Inc()
+		return
+	}
+	leadershipChanges.WithLabelValues("elected").Inc()
+	for _, elected := range s.watchers {
+		// the watcher only needs to know it has been elected since it last checked
+		select {
+		case elected <- struct{}{}:
+		default:
+		}
+	}
+}
+
+type alwaysLeader struct {
+	state
+}
+
+// AlwaysLeader is used when leader election is disabled; it elects itself as soon as it runs
+func AlwaysLeader() Elector {
+	return &alwaysLeader{}
+}
+
+func (e *alwaysLeader) Run(stop <-chan struct{}) error {
+	e.setLeader(true)
+	<-stop
+	return nil
+}
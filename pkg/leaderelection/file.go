@@ -0,0 +1,56 @@
+package leaderelection
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+type fileElector struct {
+	state
+	path        string
+	identity    string
+	retryPeriod time.Duration
+}
+
+// NewFileElector elects the replica holding an exclusive lock on the file at path.
+// it is meant for replicas running on the same machine, such as during local development
+func NewFileElector(path, identity string, retryPeriod time.Duration) Elector {
+	return &fileElector{path: path, identity: identity, retryPeriod: retryPeriod}
+}
+
+func (e *fileElector) Run(stop <-chan struct{}) error {
+	f, err := os.OpenFile(e.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "opening lock file %v", e.path)
+	}
+	defer f.Close()
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if err != syscall.EWOULDBLOCK {
+			return errors.Wrapf(err, "locking %v", e.path)
+		}
+		select {
+		case <-time.After(e.retryPeriod):
+		case <-stop:
+			return nil
+		}
+	}
+	// record the holder for whoever looks at the file
+	if err := f.Truncate(0); err == nil {
+		f.WriteAt([]byte(e.identity+"\n"), 0)
+	}
+	log.Printf("%v acquired leader lock %v", e.identity, e.path)
+	e.setLeader(true)
+	// the lock is held until it is released or the process exits
+	<-stop
+	e.setLeader(false)
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,151 @@
+package leaderelection
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+// kubeElector campaigns for the lease recorded on a ConfigMap the same way as client-go's leader elector,
+// whose Run cannot be stopped in the client-go version we build against
+type kubeElector struct {
+	state
+	lock          resourcelock.Interface
+	identity      string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+
+	// the last lease seen on the lock, and when it was first seen by this replica.
+	// leases expire by the local clock, so replicas need not agree on the time
+	observedRecord resourcelock.LeaderElectionRecord
+	observedTime   time.Time
+}
+
+// NewKubeElector elects the replica holding the lease on the named lock in namespace.
+// the lease is recorded on a ConfigMap and must be renewed by the leader before leaseDuration passes
+func NewKubeElector(cfg *rest.Config, namespace, name, identity string, leaseDuration, renewDeadline, retryPeriod time.Duration) (Elector, error) {
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating kube clientset")
+	}
+	if leaseDuration <= renewDeadline {
+		return nil, errors.Errorf("lease duration %v must be greater than the renew deadline %v", leaseDuration, renewDeadline)
+	}
+	return &kubeElector{
+		lock: &resourcelock.ConfigMapLock{
+			ConfigMapMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Client:        kubeClient.CoreV1(),
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity:      identity,
+				EventRecorder: logRecorder{},
+			},
+		},
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		renewDeadline: renewDeadline,
+		retryPeriod:   retryPeriod,
+	}, nil
+}
+
+func (e *kubeElector) Run(stop <-chan struct{}) error {
+	defer e.setLeader(false)
+	for {
+		// campaign until the lease is acquired
+		for !e.tryAcquireOrRenew() {
+			select {
+			case <-time.After(wait.Jitter(e.retryPeriod, 1.2)):
+			case <-stop:
+				return nil
+			}
+		}
+		log.Printf("%v acquired leader lease %v", e.identity, e.lock.Describe())
+		e.setLeader(true)
+
+		// renew until no renewal succeeds within the renew deadline, then campaign again.
+		// once stop is closed, the lease expires on its own as this replica stops renewing it
+		renewed := time.Now()
+		for time.Since(renewed) < e.renewDeadline {
+			select {
+			case <-time.After(e.retryPeriod):
+			case <-stop:
+				return nil
+			}
+			if e.tryAcquireOrRenew() {
+				renewed = time.Now()
+			}
+		}
+		log.Warnf("%v lost leader lease %v", e.identity, e.lock.Describe())
+		e.setLeader(false)
+	}
+}
+
+// tryAcquireOrRenew records this replica as the holder of the lease, unless another replica holds a lease
+// that has not expired. it returns true if this replica holds the lease
+func (e *kubeElector) tryAcquireOrRenew() bool {
+	now := metav1.Now()
+	record := resourcelock.LeaderElectionRecord{
+		HolderIdentity:       e.identity,
+		LeaseDurationSeconds: int(e.leaseDuration / time.Second),
+		AcquireTime:          now,
+		RenewTime:            now,
+	}
+
+	old, err := e.lock.Get()
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Warnf("failed to get leader lease %v: %v", e.lock.Describe(), err)
+			return false
+		}
+		if err := e.lock.Create(record); err != nil {
+			log.Warnf("failed to create leader lease %v: %v", e.lock.Describe(), err)
+			return false
+		}
+		e.observe(record)
+		return true
+	}
+
+	if !reflect.DeepEqual(e.observedRecord, *old) {
+		e.observe(*old)
+	}
+	if old.HolderIdentity != "" && old.HolderIdentity != e.identity && e.observedTime.Add(e.leaseDuration).After(time.Now()) {
+		return false
+	}
+	if old.HolderIdentity == e.identity {
+		record.AcquireTime = old.AcquireTime
+		record.LeaderTransitions = old.LeaderTransitions
+	} else {
+		record.LeaderTransitions = old.LeaderTransitions + 1
+	}
+	if err := e.lock.Update(record); err != nil {
+		log.Warnf("failed to update leader lease %v: %v", e.lock.Describe(), err)
+		return false
+	}
+	e.observe(record)
+	return true
+}
+
+func (e *kubeElector) observe(record resourcelock.LeaderElectionRecord) {
+	if record.HolderIdentity != e.observedRecord.HolderIdentity && record.HolderIdentity != "" {
+		log.Printf("leader for %v is %v", e.lock.Describe(), record.HolderIdentity)
+	}
+	e.observedRecord = record
+	e.observedTime = time.Now()
+}
+
+// logRecorder logs the events the leader elector would record on the lock
+type logRecorder struct{}
+
+func (logRecorder) Eventf(obj runtime.Object, eventType, reason, message string, args ...interface{}) {
+	log.Debugf("leader election: "+message, args...)
+}
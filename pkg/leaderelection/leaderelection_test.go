@@ -0,0 +1,78 @@
+package leaderelection_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	. "github.com/solo-io/gloo/pkg/leaderelection"
+	"github.com/solo-io/gloo/pkg/storage"
+	filestorage "github.com/solo-io/gloo/pkg/storage/file"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("LeaderElection", func() {
+	var dir string
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "leaderelectiontest")
+		Must(err)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	Describe("file elector", func() {
+		It("elects one replica and fails over when it stops", func() {
+			lockFile := filepath.Join(dir, "test.lock")
+			first := NewFileElector(lockFile, "first", 10*time.Millisecond)
+			second := NewFileElector(lockFile, "second", 10*time.Millisecond)
+			firstElected := first.Elected()
+			secondElected := second.Elected()
+
+			stopFirst := make(chan struct{})
+			stopSecond := make(chan struct{})
+			defer close(stopSecond)
+			go first.Run(stopFirst)
+			Eventually(firstElected).Should(Receive())
+			go second.Run(stopSecond)
+			Consistently(secondElected, 100*time.Millisecond).ShouldNot(Receive())
+			Expect(second.IsLeader()).To(BeFalse())
+
+			close(stopFirst)
+			Eventually(secondElected).Should(Receive())
+			Expect(second.IsLeader()).To(BeTrue())
+			Expect(first.IsLeader()).To(BeFalse())
+		})
+	})
+	Describe("Storage", func() {
+		It("refuses writes until elected, but serves reads", func() {
+			store, err := filestorage.NewStorage(dir, time.Second)
+			Must(err)
+			Must(store.V1().Register())
+			_, err = store.V1().Upstreams().Create(&v1.Upstream{Name: "existing", Type: "test"})
+			Must(err)
+
+			elector := NewFileElector(filepath.Join(dir, "test.lock"), "test", 10*time.Millisecond)
+			guarded := Storage(store, elector)
+			_, err = guarded.V1().Upstreams().Create(&v1.Upstream{Name: "new", Type: "test"})
+			Expect(storage.IsNotLeader(err)).To(BeTrue())
+			err = guarded.V1().Upstreams().Delete("existing")
+			Expect(storage.IsNotLeader(err)).To(BeTrue())
+			upstreams, err := guarded.V1().Upstreams().List(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upstreams).To(HaveLen(1))
+
+			stop := make(chan struct{})
+			defer close(stop)
+			go elector.Run(stop)
+			Eventually(elector.IsLeader).Should(BeTrue())
+			_, err = guarded.V1().Upstreams().Create(&v1.Upstream{Name: "new", Type: "test"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
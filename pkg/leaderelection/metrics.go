@@ -0,0 +1,18 @@
+package leaderelection
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/solo-io/gloo/pkg/metrics"
+)
+
+var leadershipChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "leader_election",
+	Name:      "transitions_total",
+	Help:      "times this replica was elected leader or lost leadership",
+}, []string{"transition"})
+
+func init() {
+	prometheus.MustRegister(leadershipChanges)
+}
@@ -0,0 +1,122 @@
+package leaderelection
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+var errNotLeader = storage.NewNotLeaderErr(errors.New("writes are refused until this replica is elected leader"))
+
+// Storage wraps store so that writes fail with a not leader error unless elector holds leadership.
+// reads and watches are passed through, so the caches of replicas that are not the leader stay warm
+func Storage(store storage.Interface, elector Elector) storage.Interface {
+	return &leaderStorage{store: store, elector: elector}
+}
+
+type leaderStorage struct {
+	store   storage.Interface
+	elector Elector
+}
+
+func (s *leaderStorage) V1() storage.V1 {
+	return &leaderV1{V1: s.store.V1(), elector: s.elector}
+}
+
+type leaderV1 struct {
+	storage.V1
+	elector Elector
+}
+
+func (v *leaderV1) Upstreams() storage.Upstreams {
+	return &leaderUpstreams{Upstreams: v.V1.Upstreams(), elector: v.elector}
+}
+
+func (v *leaderV1) VirtualHosts() storage.VirtualHosts {
+	return &leaderVirtualHosts{VirtualHosts: v.V1.VirtualHosts(), elector: v.elector}
+}
+
+type leaderUpstreams struct {
+	storage.Upstreams
+	elector Elector
+}
+
+func (c *leaderUpstreams) Create(item *v1.Upstream) (*v1.Upstream, error) {
+	if !c.elector.IsLeader() {
+		return nil, errNotLeader
+	}
+	return c.Upstreams.Create(item)
+}
+
+func (c *leaderUpstreams) Update(item *v1.Upstream) (*v1.Upstream, error) {
+	if !c.elector.IsLeader() {
+		return nil, errNotLeader
+	}
+	return c.Upstreams.Update(item)
+}
+
+func (c *leaderUpstreams) Delete(name string) error {
+	if !c.elector.IsLeader() {
+		return errNotLeader
+	}
+	return c.Upstreams.Delete(name)
+}
+
+type leaderVirtualHosts struct {
+	storage.VirtualHosts
+	elector Elector
+}
+
+func (c *leaderVirtualHosts) Create(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	if !c.elector.IsLeader() {
+		return nil, errNotLeader
+	}
+	return c.VirtualHosts.Create(item)
+}
+
+func (c *leaderVirtualHosts) Update(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	if !c.elector.IsLeader() {
+		return nil, errNotLeader
+	}
+	return c.VirtualHosts.Update(item)
+}
+
+func (c *leaderVirtualHosts) Delete(name string) error {
+	if !c.elector.IsLeader() {
+		return errNotLeader
+	}
+	return c.VirtualHosts.Delete(name)
+}
+
+// SecretStorage wraps secrets so that writes fail with a not leader error unless elector holds leadership
+func SecretStorage(secrets dependencies.SecretStorage, elector Elector) dependencies.SecretStorage {
+	return &leaderSecrets{SecretStorage: secrets, elector: elector}
+}
+
+type leaderSecrets struct {
+	dependencies.SecretStorage
+	elector Elector
+}
+
+func (c *leaderSecrets) Create(item *dependencies.Secret) (*dependencies.Secret, error) {
+	if !c.elector.IsLeader() {
+		return nil, errNotLeader
+	}
+	return c.SecretStorage.Create(item)
+}
+
+func (c *leaderSecrets) Update(item *dependencies.Secret) (*dependencies.Secret, error) {
+	if !c.elector.IsLeader() {
+		return nil, errNotLeader
+	}
+	return c.SecretStorage.Update(item)
+}
+
+func (c *leaderSecrets) Delete(name string) error {
+	if !c.elector.IsLeader() {
+		return errNotLeader
+	}
+	return c.SecretStorage.Delete(name)
+}
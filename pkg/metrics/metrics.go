@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+// Namespace prefixes the name of every metric exported by gloo
+const Namespace = "gloo"
+
+// Path is where the metrics are served
+const Path = "/metrics"
+
+// Serve exposes the metrics in the default prometheus registry on addr until stop is closed
+func Serve(addr string, stop <-chan struct{}) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %v", addr)
+	}
+	m := http.NewServeMux()
+	m.Handle(Path, promhttp.Handler())
+	server := &http.Server{Handler: m}
+	go func() {
+		log.Printf("serving metrics on %v%v", lis.Addr(), Path)
+		if err := server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.Warnf("metrics server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-stop
+		server.Shutdown(context.Background())
+	}()
+	return nil
+}
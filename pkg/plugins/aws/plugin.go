@@ -22,9 +22,10 @@ func init() {
 	plugins.Register(&Plugin{}, nil)
 }
 
-type Plugin struct {
-	isNeeded bool
-}
+type Plugin struct{}
+
+// filterNeeded is contributed by each aws upstream that is processed without errors
+type filterNeeded struct{}
 
 const (
 	// define Upstream type name
@@ -60,19 +61,17 @@ func (p *Plugin) GetDependencies(cfg *v1.Config) *plugins.Dependencies {
 			// TODO: consider logging error here
 			continue
 		}
-		deps.SecretRefs = append(deps.SecretRefs, awsUpstream.SecretRef)
+		// a secret ref without a namespace refers to a secret in the namespace of the upstream
+		deps.SecretRefs = append(deps.SecretRefs, v1.QualifyRef(awsUpstream.SecretRef, upstream.GetMetadata().GetNamespace()))
 	}
 	return deps
 }
 
 func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
-
-	defer func() { p.isNeeded = false }()
-
-	if p.isNeeded {
-		return []plugins.StagedFilter{{HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage}}
+	if len(params.Context.Contributions(filterNeeded{})) == 0 {
+		return nil
 	}
-	return nil
+	return []plugins.StagedFilter{{HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage}}
 }
 
 func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
@@ -100,8 +99,6 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 	if in.Type != UpstreamTypeAws {
 		return nil
 	}
-	p.isNeeded = true
-
 	out.Type = envoyapi.Cluster_LOGICAL_DNS
 	// need to make sure we use ipv4 only dns
 	out.DnsLookupFamily = envoyapi.Cluster_V4_ONLY
@@ -119,9 +116,10 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 		Sni: awsUpstream.GetLambdaHostname(),
 	}
 
-	awsSecrets, ok := params.Secrets[awsUpstream.SecretRef]
+	secretRef := v1.QualifyRef(awsUpstream.SecretRef, in.GetMetadata().GetNamespace())
+	awsSecrets, ok := params.Secrets[secretRef]
 	if !ok {
-		return errors.Errorf("aws secrets for ref %v not found", awsUpstream.SecretRef)
+		return errors.Errorf("aws secrets for ref %v not found", secretRef)
 	}
 
 	var secretErrs error
@@ -154,7 +152,11 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 		},
 	}
 
-	return secretErrs
+	if secretErrs != nil {
+		return secretErrs
+	}
+	params.Context.Contribute(filterNeeded{}, true)
+	return nil
 }
 
 func (p *Plugin) ParseFunctionSpec(params *plugins.FunctionPluginParams, in v1.FunctionSpec) (*types.Struct, error) {
@@ -88,16 +88,18 @@ var _ = Describe("Plugin", func() {
 			var (
 				err error
 				p   Plugin
+				ctx *plugins.Context
 				out *envoyapi.Cluster
 			)
 			BeforeEach(func() {
 				p = Plugin{}
+				ctx = plugins.NewContext(nil)
 				upstream := &v1.Upstream{
 					Type: UpstreamTypeAws,
 					Spec: upstreamSpec("us-east-1", "aws-secret"),
 				}
 				out = &envoyapi.Cluster{}
-				params := &plugins.UpstreamPluginParams{Secrets: map[string]*dependencies.Secret{
+				params := &plugins.UpstreamPluginParams{Context: ctx, Secrets: map[string]*dependencies.Secret{
 					"aws-secret": {Ref: "aws-secret", Data: map[string]string{AwsAccessKey: "apple", AwsSecretKey: "ball"}},
 				}}
 				err = p.ProcessUpstream(params, upstream, out)
@@ -115,6 +117,26 @@ var _ = Describe("Plugin", func() {
 			It("should have region in the output host", func() {
 				Expect(out.Hosts[0].GetSocketAddress()).To(ContainSubstring("us-east-1"))
 			})
+
+			It("should return the lambda filter", func() {
+				filters := p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})
+				Expect(filters).To(HaveLen(1))
+				Expect(filters[0].HttpFilter.Name).To(Equal("io.solo.lambda"))
+			})
+		})
+
+		Context("When no aws upstream was processed without errors", func() {
+			It("should return no filters", func() {
+				p := Plugin{}
+				ctx := plugins.NewContext(nil)
+				upstream := &v1.Upstream{
+					Type: UpstreamTypeAws,
+					Spec: upstreamSpec("us-east-1", "aws-secret"),
+				}
+				params := &plugins.UpstreamPluginParams{Context: ctx}
+				Expect(p.ProcessUpstream(params, upstream, &envoyapi.Cluster{})).To(HaveOccurred())
+				Expect(p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})).To(BeEmpty())
+			})
 		})
 	})
 
@@ -16,6 +16,11 @@ const (
 	ExecutionStyleAsync = "async"
 )
 
+// RouteExtension is the part of the route extensions read by the aws plugin
+type RouteExtension struct {
+	ExecutionStyle string `json:"execution_style,omitempty"`
+}
+
 func GetExecutionStyle(routeExtensions *types.Struct) (string, error) {
 	if routeExtensions != nil {
 		if style, ok := routeExtensions.Fields[RoutePluginKeyExecutionStyle]; ok {
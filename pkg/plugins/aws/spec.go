@@ -6,9 +6,48 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindUpstreamSpec,
+		Type:        UpstreamTypeAws,
+		Version:     "v1",
+		Description: "the AWS region of the lambda functions, and the secret with the credentials to invoke them with",
+		New:         func() interface{} { return &UpstreamSpec{} },
+		Example:     EncodeUpstreamSpec(UpstreamSpec{Region: "us-east-1", SecretRef: "my-aws-secret"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeUpstreamSpec(spec)
+			return err
+		},
+	})
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindFunctionSpec,
+		Type:        UpstreamTypeAws,
+		Version:     "v1",
+		Description: "the name and qualifier of a lambda function",
+		New:         func() interface{} { return &FunctionSpec{} },
+		Example:     EncodeFunctionSpec(FunctionSpec{FunctionName: "my-function", Qualifier: "$LATEST"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeFunctionSpec(spec)
+			return err
+		},
+	})
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindRouteExtensions,
+		Type:        UpstreamTypeAws,
+		Version:     "v1",
+		Description: "whether routes to lambda functions invoke them synchronously or asynchronously",
+		New:         func() interface{} { return &RouteExtension{} },
+		Decode: func(spec *types.Struct) error {
+			_, err := GetExecutionStyle(spec)
+			return err
+		},
+	})
+}
+
 var (
 	ValidRegions = map[string]bool{
 		"us-east-2":      true,
@@ -18,12 +18,29 @@ import (
 )
 
 func init() {
-	plugins.Register(&Plugin{apiKeys: make(map[string]string)}, nil)
+	plugins.Register(&Plugin{}, nil)
 }
 
-type Plugin struct {
-	isNeeded bool
-	apiKeys  map[string]string
+type Plugin struct{}
+
+// translationState is kept in the context of each translation
+type translationState struct {
+	// the api keys of each upstream, read from its secret when the upstream is processed
+	apiKeys map[string]map[string]string
+}
+
+type stateKey struct{}
+
+// filterNeeded is contributed by each azure upstream that is processed without errors
+type filterNeeded struct{}
+
+func getState(ctx *plugins.Context) *translationState {
+	state, ok := ctx.Value(stateKey{}).(*translationState)
+	if !ok {
+		state = &translationState{apiKeys: make(map[string]map[string]string)}
+		ctx.SetValue(stateKey{}, state)
+	}
+	return state
 }
 
 const (
@@ -55,21 +72,17 @@ func (p *Plugin) GetDependencies(cfg *v1.Config) *plugins.Dependencies {
 			// TODO: consider logging error here
 			continue
 		}
-		deps.SecretRefs = append(deps.SecretRefs, azureUpstream.SecretRef)
+		// a secret ref without a namespace refers to a secret in the namespace of the upstream
+		deps.SecretRefs = append(deps.SecretRefs, v1.QualifyRef(azureUpstream.SecretRef, upstream.GetMetadata().GetNamespace()))
 	}
 	return deps
 }
 
-func (p *Plugin) HttpFilters(_ *plugins.FilterPluginParams) []plugins.StagedFilter {
-	defer func() {
-		p.isNeeded = false
-		p.apiKeys = make(map[string]string)
-	}()
-
-	if p.isNeeded {
-		return []plugins.StagedFilter{{HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage}}
+func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
+	if len(params.Context.Contributions(filterNeeded{})) == 0 {
+		return nil
 	}
-	return nil
+	return []plugins.StagedFilter{{HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage}}
 }
 
 func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
@@ -80,8 +93,6 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 	if in.Type != UpstreamTypeAzure {
 		return nil
 	}
-	p.isNeeded = true
-
 	out.Type = envoyapi.Cluster_LOGICAL_DNS
 
 	// TODO(talnordan): Use an e2e test to check whether the IPv4 restriction is needed.
@@ -102,12 +113,13 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 	}
 
 	if azureUpstream.SecretRef != "" {
-		azureSecrets, ok := params.Secrets[azureUpstream.SecretRef]
+		secretRef := v1.QualifyRef(azureUpstream.SecretRef, in.GetMetadata().GetNamespace())
+		azureSecrets, ok := params.Secrets[secretRef]
 		if !ok {
-			return errors.Errorf("azure secrets for ref %v not found", azureUpstream.SecretRef)
+			return errors.Errorf("azure secrets for ref %v not found", secretRef)
 		}
 
-		p.apiKeys = azureSecrets.Data
+		getState(params.Context).apiKeys[in.Name] = azureSecrets.Data
 	}
 
 	if out.Metadata == nil {
@@ -120,6 +132,7 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 		},
 	}
 
+	params.Context.Contribute(filterNeeded{}, true)
 	return nil
 }
 
@@ -133,7 +146,7 @@ func (p *Plugin) ParseFunctionSpec(params *plugins.FunctionPluginParams, in v1.F
 		return nil, errors.Wrap(err, "invalid Azure Functions spec")
 	}
 
-	path, err := getPath(functionSpec, p.apiKeys)
+	path, err := getPath(functionSpec, getState(params.Context).apiKeys[params.UpstreamName])
 	if err != nil {
 		return nil, err
 	}
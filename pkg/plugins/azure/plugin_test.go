@@ -35,30 +35,34 @@ var _ = Describe("Plugin dependencies", func() {
 })
 
 var _ = Describe("Plugin HTTP filters", func() {
-	Context("when needed", func() {
-		It("should create a filter and reset plugin", func() {
-			p := &Plugin{
-				isNeeded: true,
-				apiKeys:  map[string]string{"a": "b"},
-			}
-			filters := p.HttpFilters(nil)
+	var (
+		p   *Plugin
+		ctx *plugins.Context
+	)
+	BeforeEach(func() {
+		p = &Plugin{}
+		ctx = plugins.NewContext(&v1.Config{
+			Upstreams: []*v1.Upstream{upstream("azure1", "my-appwhos", "azure-secret1")},
+		})
+	})
+	Context("when an azure upstream was processed", func() {
+		It("should create a filter", func() {
+			params := &plugins.UpstreamPluginParams{Context: ctx, Secrets: map[string]*dependencies.Secret{
+				"azure-secret1": {Ref: "azure-secret1", Data: map[string]string{"_master": "key1"}},
+			}}
+			Expect(p.ProcessUpstream(params, ctx.Config.Upstreams[0], &envoyapi.Cluster{})).To(Succeed())
+			filters := p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})
 			Expect(filters).To(HaveLen(1))
 			Expect(filters[0].HttpFilter.Name).To(Equal("io.solo.azure_functions"))
 			Expect(filters[0].Stage).To(Equal(plugins.OutAuth))
-			Expect(p.isNeeded).To(Equal(false))
-			Expect(p.apiKeys).To(HaveLen(0))
 		})
 	})
-	Context("when not needed", func() {
-		It("should reset plugin without creating a filter", func() {
-			p := &Plugin{
-				isNeeded: false,
-				apiKeys:  map[string]string{"a": "b"},
-			}
-			filters := p.HttpFilters(nil)
+	Context("when every azure upstream was rejected", func() {
+		It("should not create a filter", func() {
+			params := &plugins.UpstreamPluginParams{Context: ctx}
+			Expect(p.ProcessUpstream(params, ctx.Config.Upstreams[0], &envoyapi.Cluster{})).NotTo(Succeed())
+			filters := p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})
 			Expect(filters).To(HaveLen(0))
-			Expect(p.isNeeded).To(Equal(false))
-			Expect(p.apiKeys).To(HaveLen(0))
 		})
 	})
 })
@@ -81,8 +85,9 @@ var _ = Describe("Processing upstream", func() {
 				Spec: upstreamSpec("my-appwhos", "azure-secret1"),
 			}
 			out := &envoyapi.Cluster{}
-			params := &plugins.UpstreamPluginParams{}
 			p := Plugin{}
+			ctx := plugins.NewContext(nil)
+			params := &plugins.UpstreamPluginParams{Context: ctx}
 			err := p.ProcessUpstream(params, upstream, out)
 			Expect(err).To(HaveOccurred())
 		})
@@ -90,17 +95,19 @@ var _ = Describe("Processing upstream", func() {
 	Context("with valid upstream spec", func() {
 		var (
 			err error
-			p   Plugin
+			ctx *plugins.Context
 			out *envoyapi.Cluster
 		)
 		BeforeEach(func() {
-			p = Plugin{apiKeys: make(map[string]string)}
+			p := Plugin{}
+			ctx = plugins.NewContext(nil)
 			upstream := &v1.Upstream{
+				Name: "azure1",
 				Type: UpstreamTypeAzure,
 				Spec: upstreamSpec("my-appwhos", "azure-secret1"),
 			}
 			out = &envoyapi.Cluster{}
-			params := &plugins.UpstreamPluginParams{Secrets: map[string]*dependencies.Secret{
+			params := &plugins.UpstreamPluginParams{Context: ctx, Secrets: map[string]*dependencies.Secret{
 				"azure-secret1": {
 					Ref:  "azure-secret1",
 					Data: map[string]string{"_master": "key1", "foo": "key1", "bar": "key2"},
@@ -125,25 +132,26 @@ var _ = Describe("Processing upstream", func() {
 			Expect(metadata.Fields).Should(HaveLen(1))
 			Expect(get(metadata, "host")).To(Equal("my-appwhos.azurewebsites.net"))
 		})
-		It("should add the api key map to the plugin", func() {
-			Expect(p.isNeeded).To(Equal(true))
-			Expect(p.apiKeys).To(Equal(map[string]string{"_master": "key1", "foo": "key1", "bar": "key2"}))
+		It("should add the api key map of the upstream to the context", func() {
+			Expect(getState(ctx).apiKeys["azure1"]).To(Equal(map[string]string{"_master": "key1", "foo": "key1", "bar": "key2"}))
 		})
 	})
 	Context("with valid upstream spec without secrets", func() {
 		var (
 			err error
-			p   Plugin
+			ctx *plugins.Context
 			out *envoyapi.Cluster
 		)
 		BeforeEach(func() {
-			p = Plugin{apiKeys: make(map[string]string)}
+			p := Plugin{}
+			ctx = plugins.NewContext(nil)
 			upstream := &v1.Upstream{
+				Name: "azure1",
 				Type: UpstreamTypeAzure,
 				Spec: upstreamSpec("my-appwhos", ""),
 			}
 			out = &envoyapi.Cluster{}
-			params := &plugins.UpstreamPluginParams{Secrets: map[string]*dependencies.Secret{
+			params := &plugins.UpstreamPluginParams{Context: ctx, Secrets: map[string]*dependencies.Secret{
 				"some-irrelevant-secret1": {
 					Ref:  "some-irrelevant-secret1",
 					Data: map[string]string{"_master": "key1", "foo": "key1", "bar": "key2"},
@@ -168,9 +176,8 @@ var _ = Describe("Processing upstream", func() {
 			Expect(metadata.Fields).Should(HaveLen(1))
 			Expect(get(metadata, "host")).To(Equal("my-appwhos.azurewebsites.net"))
 		})
-		It("should leave the api key map empty in the plugin", func() {
-			Expect(p.isNeeded).To(Equal(true))
-			Expect(p.apiKeys).To(Equal(make(map[string]string)))
+		It("should not add an api key map for the upstream to the context", func() {
+			Expect(getState(ctx).apiKeys).NotTo(HaveKey("azure1"))
 		})
 	})
 })
@@ -187,33 +194,36 @@ var _ = Describe("Processing function", func() {
 	})
 	Context("with invalid function spec", func() {
 		It("should error", func() {
-			p := Plugin{
-				isNeeded: true,
-				apiKeys:  map[string]string{"foo": "key1"},
+			p := Plugin{}
+			param := &plugins.FunctionPluginParams{
+				Context:      contextWithApiKeys("azure1", map[string]string{"foo": "key1"}),
+				UpstreamName: "azure1",
+				UpstreamType: UpstreamTypeAzure,
 			}
-			param := &plugins.FunctionPluginParams{UpstreamType: UpstreamTypeAzure}
 			_, err := p.ParseFunctionSpec(param, functionSpec("foo", "invalid"))
 			Expect(err).To(HaveOccurred())
 		})
 	})
 	Context("with missing key", func() {
 		It("should error", func() {
-			p := Plugin{
-				isNeeded: true,
-				apiKeys:  map[string]string{"foo": "key1"},
+			p := Plugin{}
+			param := &plugins.FunctionPluginParams{
+				Context:      contextWithApiKeys("azure1", map[string]string{"foo": "key1"}),
+				UpstreamName: "azure1",
+				UpstreamType: UpstreamTypeAzure,
 			}
-			param := &plugins.FunctionPluginParams{UpstreamType: UpstreamTypeAzure}
 			_, err := p.ParseFunctionSpec(param, functionSpec("bar", "function"))
 			Expect(err).To(HaveOccurred())
 		})
 	})
 	Context("with valid function spec", func() {
 		It("should return path", func() {
-			p := Plugin{
-				isNeeded: true,
-				apiKeys:  map[string]string{"foo": "key1"},
+			p := Plugin{}
+			param := &plugins.FunctionPluginParams{
+				Context:      contextWithApiKeys("azure1", map[string]string{"foo": "key1"}),
+				UpstreamName: "azure1",
+				UpstreamType: UpstreamTypeAzure,
 			}
-			param := &plugins.FunctionPluginParams{UpstreamType: UpstreamTypeAzure}
 			out, err := p.ParseFunctionSpec(param, functionSpec("foo", "function"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(get(out, "path")).To(Equal("/api/foo?code=key1"))
@@ -500,6 +510,12 @@ var _ = Describe("Path ", func() {
 	})
 })
 
+func contextWithApiKeys(upstreamName string, apiKeys map[string]string) *plugins.Context {
+	ctx := plugins.NewContext(nil)
+	getState(ctx).apiKeys[upstreamName] = apiKeys
+	return ctx
+}
+
 func upstreamSpec(functionAppName string, secretRef string) v1.UpstreamSpec {
 	return &types.Struct{
 		Fields: map[string]*types.Value{
@@ -7,11 +7,39 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 
 	multierror "github.com/hashicorp/go-multierror"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindUpstreamSpec,
+		Type:        UpstreamTypeAzure,
+		Version:     "v1",
+		Description: "the Azure function app, and the secret with its keys",
+		New:         func() interface{} { return &UpstreamSpec{} },
+		Example:     EncodeUpstreamSpec(UpstreamSpec{FunctionAppName: "my-function-app", SecretRef: "my-azure-secret"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeUpstreamSpec(spec)
+			return err
+		},
+	})
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindFunctionSpec,
+		Type:        UpstreamTypeAzure,
+		Version:     "v1",
+		Description: "the name and authentication level of an Azure function",
+		New:         func() interface{} { return &FunctionSpec{} },
+		Example:     EncodeFunctionSpec(FunctionSpec{FunctionName: "my-function", AuthLevel: "anonymous"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeFunctionSpec(spec)
+			return err
+		},
+	})
+}
+
 type UpstreamSpec struct {
 	FunctionAppName string `json:"function_app_name"`
 	SecretRef       string `json:"secret_ref"`
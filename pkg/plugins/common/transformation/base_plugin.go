@@ -24,7 +24,7 @@ import (
 //go:generate protoc -I=./envoy/ -I=${GOPATH}/src/github.com/gogo/protobuf/ --gogo_out=. envoy/transformation_filter.proto
 
 const (
-	filterName          = "io.solo.transformation"
+	FilterName          = "io.solo.transformation"
 	metadataRequestKey  = "request-transformation"
 	metadataResponseKey = "response-transformation"
 
@@ -34,34 +34,68 @@ const (
 
 type GetTransformationFunction func(destination *v1.Destination_Function) (*TransformationTemplate, error)
 
+// Plugin adds transformations to routes, and the transformation filter that applies them.
+// the plugins that use it share the transformations of a translation, and so the filter, through the context
 type Plugin interface {
 	ActivateFilterForCluster(out *envoyapi.Cluster)
-	AddRequestTransformationsToRoute(getTemplate GetTransformationFunction, in *v1.Route, out *envoyroute.Route) error
-	AddResponseTransformationsToRoute(in *v1.Route, out *envoyroute.Route) error
-	GetTransformationFilter() *plugins.StagedFilter
+	AddRequestTransformationsToRoute(ctx *plugins.Context, getTemplate GetTransformationFunction, in *v1.Route, out *envoyroute.Route) error
+	AddResponseTransformationsToRoute(ctx *plugins.Context, in *v1.Route, out *envoyroute.Route) error
+	// returns the filter for the transformations added during the translation,
+	// or nil if there are none or another plugin has already been given the filter
+	GetTransformationFilter(ctx *plugins.Context) *plugins.StagedFilter
 }
 
 func NewTransformationPlugin() Plugin {
-	return &transformationPlugin{
-		cachedTransformations: make(map[string]*Transformation),
+	return &transformationPlugin{}
+}
+
+type transformationPlugin struct{}
+
+// translationState is kept in the context of each translation
+type translationState struct {
+	filterReturned bool
+}
+
+type stateKey struct{}
+
+func getState(ctx *plugins.Context) *translationState {
+	state, ok := ctx.Value(stateKey{}).(*translationState)
+	if !ok {
+		state = &translationState{}
+		ctx.SetValue(stateKey{}, state)
 	}
+	return state
+}
+
+// a transformation a route refers to by its hash, contributed by the route's virtual host
+type routeTransformation struct {
+	hash           string
+	transformation *Transformation
 }
 
-type transformationPlugin struct {
-	cachedTransformations map[string]*Transformation
+type transformationKey struct{}
+
+// the transformations of every route in the translation, by hash
+func transformations(ctx *plugins.Context) map[string]*Transformation {
+	transformations := make(map[string]*Transformation)
+	for _, contribution := range ctx.Contributions(transformationKey{}) {
+		t := contribution.(routeTransformation)
+		transformations[t.hash] = t.transformation
+	}
+	return transformations
 }
 
 func (p *transformationPlugin) ActivateFilterForCluster(out *envoyapi.Cluster) {
 	if out.Metadata == nil {
 		out.Metadata = &envoycore.Metadata{}
 	}
-	common.InitFilterMetadata(filterName, out.Metadata)
-	out.Metadata.FilterMetadata[filterName] = &types.Struct{
+	common.InitFilterMetadata(FilterName, out.Metadata)
+	out.Metadata.FilterMetadata[FilterName] = &types.Struct{
 		Fields: make(map[string]*types.Value),
 	}
 }
 
-func (p *transformationPlugin) AddRequestTransformationsToRoute(getTemplate GetTransformationFunction, in *v1.Route, out *envoyroute.Route) error {
+func (p *transformationPlugin) AddRequestTransformationsToRoute(ctx *plugins.Context, getTemplate GetTransformationFunction, in *v1.Route, out *envoyroute.Route) error {
 	var extractors map[string]*Extraction
 	// if no parameters specified, the only extraction will be a json body
 	if in.Extensions != nil {
@@ -76,7 +110,7 @@ func (p *transformationPlugin) AddRequestTransformationsToRoute(getTemplate GetT
 	}
 
 	// calculate the templates for all these transformations
-	if err := p.setTransformationsForRoute(getTemplate, in, extractors, out); err != nil {
+	if err := p.setTransformationsForRoute(ctx, getTemplate, in, extractors, out); err != nil {
 		return errors.Wrap(err, "resolving request transformations for route")
 	}
 
@@ -118,7 +152,7 @@ func createRequestExtractors(params *Parameters) (map[string]*Extraction, error)
 
 // TODO: clean up the response transformation
 // params should live on the source (upstream/function)
-func (p *transformationPlugin) AddResponseTransformationsToRoute(in *v1.Route, out *envoyroute.Route) error {
+func (p *transformationPlugin) AddResponseTransformationsToRoute(ctx *plugins.Context, in *v1.Route, out *envoyroute.Route) error {
 	if in.Extensions == nil {
 		return nil
 	}
@@ -141,7 +175,7 @@ func (p *transformationPlugin) AddResponseTransformationsToRoute(in *v1.Route, o
 	}
 
 	// calculate the templates for all these transformations
-	if err := p.setResponseTransformationForRoute(*extension.ResponseTemplate, extractors, out); err != nil {
+	if err := p.setResponseTransformationForRoute(ctx, *extension.ResponseTemplate, extractors, out); err != nil {
 		return errors.Wrap(err, "resolving request transformations for route")
 	}
 
@@ -218,17 +252,17 @@ func buildRegexString(rxp *regexp.Regexp, paramString string) string {
 // if single destination, just one transformation
 // if multi destination, one transformation for each functional
 // that specifies a transformation spec
-func (p *transformationPlugin) setTransformationsForRoute(getTemplate GetTransformationFunction, in *v1.Route, extractors map[string]*Extraction, out *envoyroute.Route) error {
+func (p *transformationPlugin) setTransformationsForRoute(ctx *plugins.Context, getTemplate GetTransformationFunction, in *v1.Route, extractors map[string]*Extraction, out *envoyroute.Route) error {
 	switch {
 	case in.MultipleDestinations != nil:
 		for _, dest := range in.MultipleDestinations {
-			err := p.setTransformationForRoute(getTemplate, dest.Destination, extractors, out)
+			err := p.setTransformationForRoute(ctx, getTemplate, dest.Destination, extractors, out)
 			if err != nil {
 				return errors.Wrap(err, "setting transformation for route")
 			}
 		}
 	case in.SingleDestination != nil:
-		err := p.setTransformationForRoute(getTemplate, in.SingleDestination, extractors, out)
+		err := p.setTransformationForRoute(ctx, getTemplate, in.SingleDestination, extractors, out)
 		if err != nil {
 			return errors.Wrap(err, "setting transformation for route")
 		}
@@ -236,7 +270,7 @@ func (p *transformationPlugin) setTransformationsForRoute(getTemplate GetTransfo
 	return nil
 }
 
-func (p *transformationPlugin) setTransformationForRoute(getTemplateForDestination GetTransformationFunction, dest *v1.Destination, extractors map[string]*Extraction, out *envoyroute.Route) error {
+func (p *transformationPlugin) setTransformationForRoute(ctx *plugins.Context, getTemplateForDestination GetTransformationFunction, dest *v1.Destination, extractors map[string]*Extraction, out *envoyroute.Route) error {
 	fnDestination, ok := dest.DestinationType.(*v1.Destination_Function)
 	if !ok {
 		// not a functional route, nothing to do
@@ -265,14 +299,14 @@ func (p *transformationPlugin) setTransformationForRoute(getTemplateForDestinati
 
 	hash := fmt.Sprintf("%v", intHash)
 
-	// cache the transformation, the filter config needs to contain all of them
-	p.cachedTransformations[hash] = &t
+	// the filter config needs to contain all of them
+	ctx.Contribute(transformationKey{}, routeTransformation{hash: hash, transformation: &t})
 
 	// set the filter metadata on the route
 	if out.Metadata == nil {
 		out.Metadata = &envoycore.Metadata{}
 	}
-	filterMetadata := common.InitFilterMetadataField(filterName, metadataRequestKey, out.Metadata)
+	filterMetadata := common.InitFilterMetadataField(FilterName, metadataRequestKey, out.Metadata)
 	if filterMetadata.Kind == nil {
 		filterMetadata.Kind = &types.Value_StructValue{}
 	}
@@ -315,7 +349,7 @@ func (p *transformationPlugin) setTransformationForRoute(getTemplateForDestinati
 	return nil
 }
 
-func (p *transformationPlugin) setResponseTransformationForRoute(template Template, extractors map[string]*Extraction, out *envoyroute.Route) error {
+func (p *transformationPlugin) setResponseTransformationForRoute(ctx *plugins.Context, template Template, extractors map[string]*Extraction, out *envoyroute.Route) error {
 	// create templates
 	// right now it's just a no-op, user writes inja directly
 	headerTemplates := make(map[string]*InjaTemplate)
@@ -353,30 +387,29 @@ func (p *transformationPlugin) setResponseTransformationForRoute(template Templa
 
 	hash := fmt.Sprintf("%v", intHash)
 
-	// cache the transformation, the filter config needs to contain all of them
-	p.cachedTransformations[hash] = &t
+	// the filter config needs to contain all of them
+	ctx.Contribute(transformationKey{}, routeTransformation{hash: hash, transformation: &t})
 
 	// set the filter metadata on the route
 	if out.Metadata == nil {
 		out.Metadata = &envoycore.Metadata{}
 	}
-	filterMetadata := common.InitFilterMetadataField(filterName, metadataResponseKey, out.Metadata)
+	filterMetadata := common.InitFilterMetadataField(FilterName, metadataResponseKey, out.Metadata)
 	filterMetadata.Kind = &types.Value_StringValue{StringValue: hash}
 
 	return nil
 }
 
-func (p *transformationPlugin) GetTransformationFilter() *plugins.StagedFilter {
-	if len(p.cachedTransformations) == 0 {
+func (p *transformationPlugin) GetTransformationFilter(ctx *plugins.Context) *plugins.StagedFilter {
+	state := getState(ctx)
+	transformations := transformations(ctx)
+	if len(transformations) == 0 || state.filterReturned {
 		return nil
 	}
-	defer func() {
-		// clear cache
-		p.cachedTransformations = make(map[string]*Transformation)
-	}()
+	state.filterReturned = true
 
 	filterConfig, err := util.MessageToStruct(&Transformations{
-		Transformations: p.cachedTransformations,
+		Transformations: transformations,
 	})
 	if err != nil {
 		log.Warnf("error in transformation plugin: %v", err)
@@ -386,7 +419,7 @@ func (p *transformationPlugin) GetTransformationFilter() *plugins.StagedFilter {
 
 	return &plugins.StagedFilter{
 		HttpFilter: &envoyhttp.HttpFilter{
-			Name:   filterName,
+			Name:   FilterName,
 			Config: filterConfig,
 		}, Stage: pluginStage,
 	}
@@ -4,9 +4,25 @@ import (
 	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindUpstreamSpec,
+		Type:        UpstreamTypeConsul,
+		Version:     "v1",
+		Description: "the consul service, and optionally the tags, whose instances the upstream routes to",
+		New:         func() interface{} { return &UpstreamSpec{} },
+		Example:     EncodeUpstreamSpec(UpstreamSpec{ServiceName: "my-service"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeUpstreamSpec(spec)
+			return err
+		},
+	})
+}
+
 type UpstreamSpec struct {
 	ServiceName string   `json:"service_name"`
 	ServiceTags []string `json:"service_tags"`
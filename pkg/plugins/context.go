@@ -0,0 +1,128 @@
+package plugins
+
+import (
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// Context is the state of a single translation. The translator creates a new one for every translation
+// and passes it to every phase, so plugins keep what they learn while processing upstreams and routes here
+// rather than in their own fields. Plugins can then be shared by translations that run concurrently
+type Context struct {
+	// the config being translated
+	Config *v1.Config
+
+	// set on the context of a single upstream or virtual host, see ForResource
+	parent *Context
+
+	lock          sync.Mutex
+	values        map[interface{}]interface{}
+	contributions []Contribution
+	errs          error
+}
+
+// Contribution is a value a plugin derived from a single upstream or virtual host, such as a filter it needs
+type Contribution struct {
+	Key   interface{}
+	Value interface{}
+}
+
+func NewContext(cfg *v1.Config) *Context {
+	return &Context{
+		Config: cfg,
+		values: make(map[interface{}]interface{}),
+	}
+}
+
+// ForResource returns the context an upstream or virtual host is translated in. it shares the values of
+// the translation, and keeps the contributions made while translating the resource apart from the others,
+// so the translator can keep them with the resource and replay them when the resource is reused
+func (c *Context) ForResource() *Context {
+	return &Context{
+		Config: c.Config,
+		parent: c.translation(),
+	}
+}
+
+func (c *Context) translation() *Context {
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
+// Value returns the value stored for the key during this translation, or nil
+func (c *Context) Value(key interface{}) interface{} {
+	t := c.translation()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.values[key]
+}
+
+// SetValue stores the value for the key for the rest of this translation.
+// as with context.Context, plugins should use keys of an unexported type so they do not collide.
+// values are not kept with the upstream or virtual host being translated, so anything the
+// finalize phase needs from the process phase must be contributed instead
+func (c *Context) SetValue(key, value interface{}) {
+	t := c.translation()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.values[key] = value
+}
+
+// Contribute records a value derived from the upstream or virtual host being translated.
+// the contributions of resources that are translated without errors are visible to the rest of the translation
+func (c *Context) Contribute(key, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.contributions = append(c.contributions, Contribution{Key: key, Value: value})
+}
+
+// Contributions returns the values contributed for the key so far, in the order they were contributed
+func (c *Context) Contributions(key interface{}) []interface{} {
+	var values []interface{}
+	if c.parent != nil {
+		values = c.parent.Contributions(key)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, contribution := range c.contributions {
+		if contribution.Key == key {
+			values = append(values, contribution.Value)
+		}
+	}
+	return values
+}
+
+// Recorded returns the contributions made in this context
+func (c *Context) Recorded() []Contribution {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]Contribution(nil), c.contributions...)
+}
+
+// Replay adds contributions recorded in the context of a resource to this context
+func (c *Context) Replay(contributions []Contribution) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.contributions = append(c.contributions, contributions...)
+}
+
+// Fail records an error that fails the whole translation, for phases that cannot return errors such as HttpFilters
+func (c *Context) Fail(err error) {
+	t := c.translation()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.errs = multierror.Append(t.errs, err)
+}
+
+// Err returns the errors recorded with Fail during this translation, or nil
+func (c *Context) Err() error {
+	t := c.translation()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.errs
+}
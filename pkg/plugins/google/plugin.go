@@ -19,9 +19,10 @@ func init() {
 	plugins.Register(&Plugin{}, nil)
 }
 
-type Plugin struct {
-	isNeeded bool
-}
+type Plugin struct{}
+
+// filterNeeded is contributed by each google upstream that is processed without errors
+type filterNeeded struct{}
 
 const (
 	// define Upstream type name
@@ -44,12 +45,10 @@ func (p *Plugin) GetDependencies(cfg *v1.Config) *plugins.Dependencies {
 }
 
 func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
-	defer func() { p.isNeeded = false }()
-
-	if p.isNeeded {
-		return []plugins.StagedFilter{{HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage}}
+	if len(params.Context.Contributions(filterNeeded{})) == 0 {
+		return nil
 	}
-	return nil
+	return []plugins.StagedFilter{{HttpFilter: &envoyhttp.HttpFilter{Name: filterName}, Stage: pluginStage}}
 }
 
 func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
@@ -60,8 +59,6 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 	if in.Type != UpstreamTypeGoogle {
 		return nil
 	}
-	p.isNeeded = true
-
 	out.Type = envoyapi.Cluster_LOGICAL_DNS
 	// need to make sure we use ipv4 only dns
 	out.DnsLookupFamily = envoyapi.Cluster_V4_ONLY
@@ -89,6 +86,7 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 		},
 	}
 
+	params.Context.Contribute(filterNeeded{}, true)
 	return nil
 }
 
@@ -25,6 +25,8 @@ var _ = Describe("Plugin", func() {
 		Context("with valid upstream spec", func() {
 			var (
 				err error
+				p   Plugin
+				ctx *plugins.Context
 				out *envoyapi.Cluster
 			)
 
@@ -34,8 +36,9 @@ var _ = Describe("Plugin", func() {
 					Spec: upstreamSpec("us-east1", "project-x"),
 				}
 				out = &envoyapi.Cluster{}
-				p := Plugin{}
-				err = p.ProcessUpstream(nil, upstream, out)
+				p = Plugin{}
+				ctx = plugins.NewContext(nil)
+				err = p.ProcessUpstream(&plugins.UpstreamPluginParams{Context: ctx}, upstream, out)
 			})
 
 			It("should not error", func() {
@@ -50,6 +53,19 @@ var _ = Describe("Plugin", func() {
 			It("should have region and project in the output host", func() {
 				Expect(out.Hosts[0].GetSocketAddress().Address).To(Equal("us-east1-project-x.cloudfunctions.net"))
 			})
+
+			It("should return the google functions filter", func() {
+				filters := p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})
+				Expect(filters).To(HaveLen(1))
+				Expect(filters[0].HttpFilter.Name).To(Equal("io.solo.gcloudfunc"))
+			})
+		})
+
+		Context("with no google upstream", func() {
+			It("should return no filters", func() {
+				p := Plugin{}
+				Expect(p.HttpFilters(&plugins.FilterPluginParams{Context: plugins.NewContext(nil)})).To(BeEmpty())
+			})
 		})
 	})
 
@@ -7,9 +7,37 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindUpstreamSpec,
+		Type:        UpstreamTypeGoogle,
+		Version:     "v1",
+		Description: "the region and project of the Google Cloud functions",
+		New:         func() interface{} { return &UpstreamSpec{} },
+		Example:     EncodeUpstreamSpec(UpstreamSpec{Region: "us-central1", ProjectId: "my-project"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeUpstreamSpec(spec)
+			return err
+		},
+	})
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindFunctionSpec,
+		Type:        UpstreamTypeGoogle,
+		Version:     "v1",
+		Description: "the trigger URL of a Google Cloud function",
+		New:         func() interface{} { return &FunctionSpec{} },
+		Example:     EncodeFunctionSpec(FunctionSpec{URL: "https://us-central1-my-project.cloudfunctions.net/my-function"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeFunctionSpec(spec)
+			return err
+		},
+	})
+}
+
 var (
 	ValidRegions = map[string]bool{
 		"northamerica-northeast1": true,
@@ -35,6 +63,14 @@ type UpstreamSpec struct {
 	ProjectId string `json:"project_id"`
 }
 
+func EncodeUpstreamSpec(spec UpstreamSpec) *types.Struct {
+	v1Spec, err := protoutil.MarshalStruct(spec)
+	if err != nil {
+		panic(err)
+	}
+	return v1Spec
+}
+
 func DecodeUpstreamSpec(generic v1.UpstreamSpec) (*UpstreamSpec, error) {
 	s := new(UpstreamSpec)
 	if err := protoutil.UnmarshalStruct(generic, s); err != nil {
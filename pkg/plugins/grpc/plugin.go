@@ -3,6 +3,7 @@ package grpc
 import (
 	"crypto/sha1"
 	"fmt"
+	"sort"
 
 	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoycore "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
@@ -33,16 +34,32 @@ type ServiceAndDescriptors struct {
 
 func NewPlugin() *Plugin {
 	return &Plugin{
-		upstreamServices: make(map[string]ServiceAndDescriptors),
-		transformation:   transformation.NewTransformationPlugin(),
+		transformation: transformation.NewTransformationPlugin(),
 	}
 }
 
 type Plugin struct {
-	// map service names to their descriptors
-	// keep track of which service belongs to which upstream
-	upstreamServices map[string]ServiceAndDescriptors
-	transformation   transformation.Plugin
+	transformation transformation.Plugin
+}
+
+// the services of each grpc upstream and their descriptors, by upstream name
+type upstreamServices map[string]ServiceAndDescriptors
+
+// the service of a grpc upstream, contributed by the upstream
+type upstreamService struct {
+	upstreamName string
+	service      ServiceAndDescriptors
+}
+
+type upstreamServiceKey struct{}
+
+func getUpstreamServices(ctx *plugins.Context) upstreamServices {
+	services := make(upstreamServices)
+	for _, contribution := range ctx.Contributions(upstreamServiceKey{}) {
+		s := contribution.(upstreamService)
+		services[s.upstreamName] = s.service
+	}
+	return services
 }
 
 const (
@@ -109,8 +126,8 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 		// need the package name as well, required by the transcoder filter
 		fullServiceName := genFullServiceName(in.Name, packageName, serviceName)
 		// keep track of which service belongs to which upstream
-		p.upstreamServices[in.Name] = ServiceAndDescriptors{
-			Descriptors: descriptors, FullServiceName: fullServiceName}
+		params.Context.Contribute(upstreamServiceKey{}, upstreamService{upstreamName: in.Name, service: ServiceAndDescriptors{
+			Descriptors: descriptors, FullServiceName: fullServiceName}})
 	}
 
 	addWellKnownProtos(descriptors)
@@ -144,7 +161,7 @@ func getPath(matcher *v1.RequestMatcher) string {
 	panic("invalid matcher")
 }
 
-func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
+func (p *Plugin) ProcessRoute(params *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
 	if in.Extensions == nil {
 		matcher, ok := in.Matcher.(*v1.Route_RequestMatcher)
 		if ok {
@@ -155,12 +172,16 @@ func (p *Plugin) ProcessRoute(_ *plugins.RoutePluginParams, in *v1.Route, out *e
 			})
 		}
 	}
-	return p.transformation.AddRequestTransformationsToRoute(p.templateForFunction, in, out)
+	services := getUpstreamServices(params.Context)
+	getTemplate := func(dest *v1.Destination_Function) (*transformation.TransformationTemplate, error) {
+		return templateForFunction(services, dest)
+	}
+	return p.transformation.AddRequestTransformationsToRoute(params.Context, getTemplate, in, out)
 }
 
-func (p *Plugin) templateForFunction(dest *v1.Destination_Function) (*transformation.TransformationTemplate, error) {
+func templateForFunction(services upstreamServices, dest *v1.Destination_Function) (*transformation.TransformationTemplate, error) {
 	upstreamName := dest.Function.UpstreamName
-	serviceAndDescriptor, ok := p.upstreamServices[upstreamName]
+	serviceAndDescriptor, ok := services[upstreamName]
 	if !ok {
 		// the upstream is not a grpc desintation
 		return nil, nil
@@ -258,24 +279,22 @@ func httpPath(upstreamName, serviceName, methodName string) string {
 	return "/" + fmt.Sprintf("%x", h.Sum(nil))[:8] + "/" + upstreamName + "/" + serviceName + "/" + methodName
 }
 
-func (p *Plugin) HttpFilters(_ *plugins.FilterPluginParams) []plugins.StagedFilter {
-	defer func() {
-		// clear cache
-		p.upstreamServices = make(map[string]ServiceAndDescriptors)
-	}()
-
-	if len(p.upstreamServices) == 0 {
+func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
+	services := getUpstreamServices(params.Context)
+	if len(services) == 0 {
 		return nil
 	}
 
-	transformationFilter := p.transformation.GetTransformationFilter()
-	if transformationFilter == nil {
-		log.Warnf("ERROR: nil transformation filter returned from transformation plugin")
-		return nil
+	// in a stable order, so the filters only change when the services do
+	var upstreamNames []string
+	for upstreamName := range services {
+		upstreamNames = append(upstreamNames, upstreamName)
 	}
+	sort.Strings(upstreamNames)
 
 	var filters []plugins.StagedFilter
-	for _, serviceAndDescriptor := range p.upstreamServices {
+	for _, upstreamName := range upstreamNames {
+		serviceAndDescriptor := services[upstreamName]
 		descriptorBytes, err := proto.Marshal(serviceAndDescriptor.Descriptors)
 		if err != nil {
 			log.Warnf("ERROR: marshaling proto descriptor: %v", err)
@@ -299,6 +318,8 @@ func (p *Plugin) HttpFilters(_ *plugins.FilterPluginParams) []plugins.StagedFilt
 				Config: filterConfig,
 			},
 			Stage: pluginStage,
+			// the transcoder reads the body the transformation filter builds from the request
+			After: []string{transformation.FilterName},
 		})
 	}
 
@@ -306,7 +327,10 @@ func (p *Plugin) HttpFilters(_ *plugins.FilterPluginParams) []plugins.StagedFilt
 		log.Warnf("ERROR: no valid GrpcJsonTranscoder available")
 		return nil
 	}
-	filters = append([]plugins.StagedFilter{*transformationFilter}, filters...)
+	// the rest plugin may already have added the transformation filter for this translation
+	if transformationFilter := p.transformation.GetTransformationFilter(params.Context); transformationFilter != nil {
+		filters = append([]plugins.StagedFilter{*transformationFilter}, filters...)
+	}
 
 	return filters
 }
@@ -58,10 +58,12 @@ var _ = Describe("Plugin", func() {
 				},
 			}
 			p := NewPlugin()
+			ctx := plugins.NewContext(nil)
 			b, err := ioutil.ReadFile("test/proto.pb")
 			Expect(err).NotTo(HaveOccurred())
 			params := &plugins.UpstreamPluginParams{
-				Files: map[string]*dependencies.File{"file_1": {Ref: "file_1", Contents: b}},
+				Context: ctx,
+				Files:   map[string]*dependencies.File{"file_1": {Ref: "file_1", Contents: b}},
 			}
 			out := &envoyapi.Cluster{}
 			err = p.ProcessUpstream(params, in, out)
@@ -93,10 +95,12 @@ var _ = Describe("Plugin", func() {
 				},
 			}
 			p := NewPlugin()
+			ctx := plugins.NewContext(nil)
 			b, err := ioutil.ReadFile("test/proto.pb")
 			Expect(err).NotTo(HaveOccurred())
 			params := &plugins.UpstreamPluginParams{
-				Files: map[string]*dependencies.File{"file_1": {Ref: "file_1", Contents: b}},
+				Context: ctx,
+				Files:   map[string]*dependencies.File{"file_1": {Ref: "file_1", Contents: b}},
 			}
 			out := &envoyapi.Cluster{}
 			err = p.ProcessUpstream(params, in1, out)
@@ -105,7 +109,7 @@ var _ = Describe("Plugin", func() {
 			out = &envoyapi.Cluster{}
 			err = p.ProcessUpstream(params, in2, out)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(p.upstreamServices).To(HaveLen(2))
+			Expect(getUpstreamServices(ctx)).To(HaveLen(2))
 		})
 
 		It("Stores the descriptors proto in the plugin memory and adds to it http rules", func() {
@@ -120,16 +124,18 @@ var _ = Describe("Plugin", func() {
 				},
 			}
 			p := NewPlugin()
+			ctx := plugins.NewContext(nil)
 			b, err := ioutil.ReadFile("test/proto.pb")
 			Expect(err).NotTo(HaveOccurred())
 			params := &plugins.UpstreamPluginParams{
-				Files: map[string]*dependencies.File{"file_1": {Ref: "file_1", Contents: b}},
+				Context: ctx,
+				Files:   map[string]*dependencies.File{"file_1": {Ref: "file_1", Contents: b}},
 			}
 			out := &envoyapi.Cluster{}
 			err = p.ProcessUpstream(params, in, out)
 			Expect(err).To(BeNil())
-			Expect(p.upstreamServices["myupstream"].FullServiceName).To(Equal("bookstore.Bookstore"))
-			Expect(p.upstreamServices["myupstream"].Descriptors).NotTo(BeNil())
+			Expect(getUpstreamServices(ctx)["myupstream"].FullServiceName).To(Equal("bookstore.Bookstore"))
+			Expect(getUpstreamServices(ctx)["myupstream"].Descriptors).NotTo(BeNil())
 			route := &v1.Route{
 				Matcher: &v1.Route_RequestMatcher{
 					RequestMatcher: &v1.RequestMatcher{
@@ -146,11 +152,12 @@ var _ = Describe("Plugin", func() {
 				},
 			}
 			outRoute := &envoyroute.Route{}
-			err = p.ProcessRoute(nil, route, outRoute)
+			err = p.ProcessRoute(&plugins.RoutePluginParams{Context: ctx}, route, outRoute)
 			Expect(err).To(BeNil())
-			filters := p.HttpFilters(nil)
+			filters := p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})
 			Expect(filters).To(HaveLen(2))
 			Expect(filters[0].HttpFilter.Name).To(Equal("io.solo.transformation"))
+			Expect(filters[1].After).To(ContainElement("io.solo.transformation"))
 			Expect(filters[0].HttpFilter.Config.Fields["transformations"].Kind.(*types.Value_StructValue).StructValue.Fields).
 				To(HaveLen(1))
 			for _, v := range filters[0].HttpFilter.Config.Fields["transformations"].Kind.(*types.Value_StructValue).StructValue.Fields {
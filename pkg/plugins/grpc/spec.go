@@ -2,9 +2,25 @@ package grpc
 
 import (
 	"github.com/gogo/protobuf/types"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindServiceProperties,
+		Type:        ServiceTypeGRPC,
+		Version:     "v1",
+		Description: "the gRPC services of the upstream, and the artifact with their proto descriptors",
+		New:         func() interface{} { return &ServiceProperties{} },
+		Example:     EncodeServiceProperties(ServiceProperties{GRPCServiceNames: []string{"bookstore.Bookstore"}, DescriptorsFileRef: "grpc-descriptors/bookstore.descriptors"}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeServiceProperties(spec)
+			return err
+		},
+	})
+}
+
 type ServiceProperties struct {
 	// the name of the gRPC services defined in the descriptors (to route to)
 	GRPCServiceNames []string `json:"service_names"`
@@ -28,12 +28,26 @@ type Dependencies struct {
 	FileRefs   []string
 }
 
+// A translation runs in three phases, all sharing the same Context:
+//   - init: Init is called on every InitPlugin before anything else is translated
+//   - process: ProcessUpstream, ParseFunctionSpec and ProcessRoute are called for the upstreams, functions and routes
+//   - finalize: HttpFilters is called on every FilterPlugin once everything has been processed
+//
+// plugins must not keep state between calls in their own fields; anything the finalize phase needs
+// from the process phase is stored in the Context
 type TranslatorPlugin interface {
 	GetDependencies(cfg *v1.Config) *Dependencies
 }
 
+type InitPlugin interface {
+	TranslatorPlugin
+	// Init prepares the plugin's state for a translation. an error fails the whole translation
+	Init(ctx *Context) error
+}
+
 // Parameters for ProcessUpstream()
 type UpstreamPluginParams struct {
+	Context              *Context
 	EnvoyNameForUpstream EnvoyNameForUpstream
 	Secrets              secretwatcher.SecretMap
 	Files                filewatcher.Files
@@ -46,6 +60,8 @@ type UpstreamPlugin interface {
 
 // Params for ParseFunctionSpec()
 type FunctionPluginParams struct {
+	Context      *Context
+	UpstreamName string
 	UpstreamType string
 	ServiceType  string
 }
@@ -60,6 +76,7 @@ type FunctionPlugin interface {
 
 // Params for ProcessRoute()
 type RoutePluginParams struct {
+	Context *Context
 	// some route plugins need to know about the upstream(s) they route to
 	Upstreams []*v1.Upstream
 }
@@ -70,14 +87,23 @@ type RoutePlugin interface {
 }
 
 // Params for HttpFilters()
-type FilterPluginParams struct{}
+type FilterPluginParams struct {
+	Context *Context
+}
 
+// StagedFilter is an http filter and its place in the filter chain.
+// filters are ordered by stage, then by name, unless Before or After require otherwise
 type StagedFilter struct {
 	HttpFilter *envoyhttp.HttpFilter
 	Stage      Stage
+	// names of filters this filter must come before, whatever their stage. names not in the chain are ignored
+	Before []string
+	// names of filters this filter must come after, whatever their stage. names not in the chain are ignored
+	After []string
 }
 
 type FilterPlugin interface {
 	TranslatorPlugin
+	// a plugin that cannot tell which filters are needed calls Fail on the context, which fails the translation
 	HttpFilters(params *FilterPluginParams) []StagedFilter
 }
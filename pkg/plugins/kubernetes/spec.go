@@ -4,9 +4,25 @@ import (
 	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindUpstreamSpec,
+		Type:        UpstreamTypeKube,
+		Version:     "v1",
+		Description: "the kubernetes service, and optionally the port and pod labels, whose endpoints the upstream routes to",
+		New:         func() interface{} { return &UpstreamSpec{} },
+		Example:     EncodeUpstreamSpec(UpstreamSpec{ServiceName: "my-service", ServiceNamespace: "default", ServicePort: 8080}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeUpstreamSpec(spec)
+			return err
+		},
+	})
+}
+
 type UpstreamSpec struct {
 	ServiceName      string            `json:"service_name"`
 	ServiceNamespace string            `json:"service_namespace"`
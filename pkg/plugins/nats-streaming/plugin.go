@@ -13,17 +13,27 @@ import (
 	"github.com/solo-io/gloo/pkg/coreplugins/common"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 )
 
 //go:generate protoc -I=. -I=${GOPATH}/src/github.com/gogo/protobuf/ --gogo_out=. nats_streaming_filter.proto
 
 func init() {
 	plugins.Register(&Plugin{}, nil)
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindServiceProperties,
+		Type:        ServiceTypeNatsStreaming,
+		Version:     "v1",
+		Description: "the NATS Streaming cluster id and discover prefix. both have defaults",
+		New:         func() interface{} { return &ServiceProperties{} },
+		Example:     EncodeServiceProperties(ServiceProperties{ClusterID: defaultClusterId, DiscoverPrefix: defaultDiscoverPrefix}),
+	})
 }
 
-type Plugin struct {
-	filters []plugins.StagedFilter
-}
+type Plugin struct{}
+
+// the filter for each nats streaming upstream, contributed by the upstream
+type filtersKey struct{}
 
 const (
 	ServiceTypeNatsStreaming = "nats-streaming"
@@ -57,8 +67,10 @@ func (p *Plugin) GetDependencies(cfg *v1.Config) *plugins.Dependencies {
 }
 
 func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
-	filters := p.filters
-	p.filters = nil
+	var filters []plugins.StagedFilter
+	for _, filter := range params.Context.Contributions(filtersKey{}) {
+		filters = append(filters, filter.(plugins.StagedFilter))
+	}
 	return filters
 }
 
@@ -88,7 +100,7 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 	common.InitFilterMetadataField(filterName, clusterId, out.Metadata).Kind = &types.Value_StringValue{StringValue: defaultClusterId}
 	common.InitFilterMetadataField(filterName, discoverPrefix, out.Metadata).Kind = &types.Value_StringValue{StringValue: dp}
 
-	p.filters = append(p.filters, plugins.StagedFilter{HttpFilter: &envoyhttp.HttpFilter{Name: filterName, Config: natsConfig(out.Name)}, Stage: pluginStage})
+	params.Context.Contribute(filtersKey{}, plugins.StagedFilter{HttpFilter: &envoyhttp.HttpFilter{Name: filterName, Config: natsConfig(out.Name)}, Stage: pluginStage})
 
 	return nil
 }
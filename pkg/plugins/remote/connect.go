@@ -0,0 +1,148 @@
+package remote
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+// Connect connects to a plugin that is already serving on address, which is host:port or unix:///path/to/socket.
+// the connection is secured with creds. without creds, the plugin must serve on a unix socket or a loopback address,
+// so plugin calls and the secrets they carry never leave the host unencrypted.
+// it fails if the plugin is not serving within startTimeout
+func Connect(name, address string, creds credentials.TransportCredentials, startTimeout, callTimeout time.Duration) (*Plugin, error) {
+	if creds == nil && !isLocal(address) {
+		return nil, errors.Errorf("plugin %v at %v is not on this host. connecting to it requires tls", name, address)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+	return connect(ctx, name, address, creds, callTimeout)
+}
+
+// ClientTLS returns the credentials to connect to plugins over tls with. the certificates of plugins are verified
+// against the ca in caFile. the control plane presents the certificate in certFile, if given, to plugins that
+// verify their clients
+func ClientTLS(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading the ca of remote plugins")
+	}
+	cfg := &tls.Config{RootCAs: x509.NewCertPool()}
+	if !cfg.RootCAs.AppendCertsFromPEM(ca) {
+		return nil, errors.Errorf("no certificates found in %v", caFile)
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading the client certificate for remote plugins")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// isLocal returns true if address is a unix socket or a loopback address
+func isLocal(address string) bool {
+	network, addr := splitAddress(address)
+	if network == "unix" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func connect(ctx context.Context, name, address string, creds credentials.TransportCredentials, callTimeout time.Duration) (*Plugin, error) {
+	network, addr := splitAddress(address)
+	security := grpc.WithInsecure()
+	if creds != nil {
+		security = grpc.WithTransportCredentials(creds)
+	}
+	cc, err := grpc.DialContext(ctx, addr,
+		security,
+		grpc.WithBlock(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout(network, addr, timeout)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to plugin %v at %v", name, address)
+	}
+	plugin := NewPlugin(name, cc, callTimeout)
+	if err := plugin.addSchemas(ctx); err != nil {
+		cc.Close()
+		return nil, err
+	}
+	return plugin, nil
+}
+
+// Launch runs the command of a plugin and connects to it. the plugin is told the unix socket to serve on
+// in the AddressEnv environment variable, and is killed when stop is closed.
+// it fails if the plugin exits or is not serving within startTimeout
+func Launch(name string, command []string, startTimeout, callTimeout time.Duration, stop <-chan struct{}) (*Plugin, error) {
+	if len(command) == 0 {
+		return nil, errors.Errorf("no command given for plugin %v", name)
+	}
+	dir, err := ioutil.TempDir("", "gloo-plugin-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a directory for the plugin socket")
+	}
+	address := "unix://" + filepath.Join(dir, "plugin.sock")
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), AddressEnv+"="+address)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrapf(err, "starting plugin %v", name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+	exited := make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		close(exited)
+		cancel()
+		os.RemoveAll(dir)
+		select {
+		case <-stop:
+		default:
+			log.Warnf("plugin %v exited: %v. calls to it will fail until the control plane is restarted", name, err)
+		}
+	}()
+	go func() {
+		select {
+		case <-stop:
+			cmd.Process.Kill()
+		case <-exited:
+		}
+	}()
+
+	plugin, err := connect(ctx, name, address, nil, callTimeout)
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+	log.Printf("launched plugin %v", name)
+	return plugin, nil
+}
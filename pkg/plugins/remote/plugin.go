@@ -0,0 +1,231 @@
+package remote
+
+import (
+	"context"
+	"time"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+//go:generate protoc -I=. -I=../../../api/v1 -I=${GOPATH}/src/github.com/gogo/protobuf/ --gogo_out=plugins=grpc,Mgoogle/protobuf/struct.proto=github.com/gogo/protobuf/types,Mconfig.proto=github.com/solo-io/gloo/pkg/api/types/v1,Mupstream.proto=github.com/solo-io/gloo/pkg/api/types/v1,Mvirtualhost.proto=github.com/solo-io/gloo/pkg/api/types/v1,Mmetadata.proto=github.com/solo-io/gloo/pkg/api/types/v1,Mstatus.proto=github.com/solo-io/gloo/pkg/api/types/v1:. remote.proto
+
+// Plugin is a translator plugin that runs in another process and is called over grpc.
+// a call to ProcessUpstream, ParseFunctionSpec or ProcessRoute that fails or takes longer than the timeout
+// is an error on the upstream or route being translated, so a misbehaving plugin cannot fail the whole translation.
+// GetDependencies cannot return errors, so its failures are logged and nothing is returned.
+// a failure of HttpFilters fails the whole translation, since leaving out a filter such as auth would let requests through unchecked.
+// the schemas the plugin describes are registered when it is connected to, so its specs are validated like those of built-in plugins
+type Plugin struct {
+	name    string
+	client  TranslatorPluginClient
+	timeout time.Duration
+}
+
+// NewPlugin returns a plugin that calls the plugin served on the connection. every call must finish within timeout
+func NewPlugin(name string, cc *grpc.ClientConn, timeout time.Duration) *Plugin {
+	return &Plugin{
+		name:    name,
+		client:  NewTranslatorPluginClient(cc),
+		timeout: timeout,
+	}
+}
+
+func (p *Plugin) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), p.timeout)
+}
+
+// unimplemented returns true if the plugin does not take part in the phase it was called for
+func unimplemented(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unimplemented
+}
+
+// dependenciesKey is the key the dependencies of a plugin are stored under in the context of a translation
+type dependenciesKey struct {
+	plugin *Plugin
+}
+
+// Init asks the plugin for its dependencies once per translation, so only the secrets and files
+// it asked for are sent to it when processing upstreams
+func (p *Plugin) Init(ctx *plugins.Context) error {
+	ctx.SetValue(dependenciesKey{plugin: p}, p.GetDependencies(ctx.Config))
+	return nil
+}
+
+func (p *Plugin) GetDependencies(cfg *v1.Config) *plugins.Dependencies {
+	ctx, cancel := p.callContext()
+	defer cancel()
+	resp, err := p.client.GetDependencies(ctx, &GetDependenciesRequest{Config: cfg})
+	if err != nil {
+		if !unimplemented(err) {
+			log.Warnf("remote plugin %v: getting dependencies: %v", p.name, err)
+		}
+		return nil
+	}
+	if len(resp.SecretRefs) == 0 && len(resp.FileRefs) == 0 {
+		return nil
+	}
+	return &plugins.Dependencies{
+		SecretRefs: resp.SecretRefs,
+		FileRefs:   resp.FileRefs,
+	}
+}
+
+func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Upstream, out *envoyapi.Cluster) error {
+	cluster, err := proto.Marshal(out)
+	if err != nil {
+		return errors.Wrapf(err, "remote plugin %v: marshalling cluster", p.name)
+	}
+	req := &ProcessUpstreamRequest{
+		Upstream: in,
+		Cluster:  cluster,
+		Secrets:  make(map[string]*Secret),
+		Files:    make(map[string][]byte),
+	}
+	deps, _ := params.Context.Value(dependenciesKey{plugin: p}).(*plugins.Dependencies)
+	if deps != nil {
+		for _, ref := range deps.SecretRefs {
+			if secret, ok := params.Secrets[ref]; ok {
+				req.Secrets[ref] = &Secret{Data: secret.Data}
+			}
+		}
+		for _, ref := range deps.FileRefs {
+			if file, ok := params.Files[ref]; ok {
+				req.Files[ref] = file.Contents
+			}
+		}
+	}
+	ctx, cancel := p.callContext()
+	defer cancel()
+	resp, err := p.client.ProcessUpstream(ctx, req)
+	if err != nil {
+		if unimplemented(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "remote plugin %v", p.name)
+	}
+	if len(resp.Cluster) == 0 {
+		return nil
+	}
+	var processed envoyapi.Cluster
+	if err := proto.Unmarshal(resp.Cluster, &processed); err != nil {
+		return errors.Wrapf(err, "remote plugin %v: invalid cluster", p.name)
+	}
+	*out = processed
+	return nil
+}
+
+func (p *Plugin) ParseFunctionSpec(params *plugins.FunctionPluginParams, in v1.FunctionSpec) (*types.Struct, error) {
+	ctx, cancel := p.callContext()
+	defer cancel()
+	resp, err := p.client.ParseFunctionSpec(ctx, &ParseFunctionSpecRequest{
+		UpstreamName: params.UpstreamName,
+		UpstreamType: params.UpstreamType,
+		ServiceType:  params.ServiceType,
+		FunctionSpec: in,
+	})
+	if err != nil {
+		if unimplemented(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "remote plugin %v", p.name)
+	}
+	return resp.EnvoyFunctionSpec, nil
+}
+
+func (p *Plugin) ProcessRoute(params *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
+	route, err := proto.Marshal(out)
+	if err != nil {
+		return errors.Wrapf(err, "remote plugin %v: marshalling route", p.name)
+	}
+	ctx, cancel := p.callContext()
+	defer cancel()
+	resp, err := p.client.ProcessRoute(ctx, &ProcessRouteRequest{
+		Route:      in,
+		EnvoyRoute: route,
+		Upstreams:  params.Upstreams,
+	})
+	if err != nil {
+		if unimplemented(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "remote plugin %v", p.name)
+	}
+	if len(resp.EnvoyRoute) == 0 {
+		return nil
+	}
+	var processed envoyroute.Route
+	if err := proto.Unmarshal(resp.EnvoyRoute, &processed); err != nil {
+		return errors.Wrapf(err, "remote plugin %v: invalid route", p.name)
+	}
+	*out = processed
+	return nil
+}
+
+func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
+	ctx, cancel := p.callContext()
+	defer cancel()
+	resp, err := p.client.HttpFilters(ctx, &HttpFiltersRequest{Config: params.Context.Config})
+	if err != nil {
+		if !unimplemented(err) {
+			params.Context.Fail(errors.Wrapf(err, "remote plugin %v: getting http filters", p.name))
+		}
+		return nil
+	}
+	var filters []plugins.StagedFilter
+	for _, filter := range resp.Filters {
+		filters = append(filters, plugins.StagedFilter{
+			HttpFilter: &envoyhttp.HttpFilter{
+				Name:   filter.Name,
+				Config: filter.Config,
+			},
+			Stage:  plugins.Stage(filter.Stage),
+			Before: filter.Before,
+			After:  filter.After,
+		})
+	}
+	return filters
+}
+
+// addSchemas registers the schemas of the specs the plugin decodes, so they are validated like those of built-in plugins
+func (p *Plugin) addSchemas(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	resp, err := p.client.Describe(ctx, &DescribeRequest{})
+	if err != nil {
+		if unimplemented(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "remote plugin %v: describing schemas", p.name)
+	}
+	for _, s := range resp.Schemas {
+		var properties schemas.Property
+		if err := protoutil.UnmarshalStruct(s.Properties, &properties); err != nil {
+			return errors.Wrapf(err, "remote plugin %v: invalid schema for %v %v", p.name, s.Type, s.Kind)
+		}
+		if err := schemas.Add(schemas.Schema{
+			Kind:        schemas.Kind(s.Kind),
+			Type:        s.Type,
+			Version:     s.Version,
+			Description: s.Description,
+			Property:    &properties,
+		}); err != nil {
+			return errors.Wrapf(err, "remote plugin %v", p.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package remote_test
+
+import (
+	"context"
+	"net"
+	"time"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/gogo/protobuf/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/plugins"
+	. "github.com/solo-io/gloo/pkg/plugins/remote"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+const fakeType = "fake"
+
+type fakePlugin struct {
+	delay time.Duration
+}
+
+func (p *fakePlugin) GetDependencies(cfg *v1.Config) *plugins.Dependencies {
+	for _, us := range cfg.Upstreams {
+		if us.Type == fakeType {
+			return &plugins.Dependencies{SecretRefs: []string{"fake-secret"}}
+		}
+	}
+	return nil
+}
+
+func (p *fakePlugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Upstream, out *envoyapi.Cluster) error {
+	time.Sleep(p.delay)
+	if in.Type != fakeType {
+		return nil
+	}
+	for ref := range params.Secrets {
+		if ref != "fake-secret" {
+			return errors.Errorf("received secret %v, which the plugin did not ask for", ref)
+		}
+	}
+	secret, ok := params.Secrets["fake-secret"]
+	if !ok {
+		return errors.New("fake-secret not found")
+	}
+	out.Type = envoyapi.Cluster_STRICT_DNS
+	out.Name = out.Name + "-" + secret.Data["suffix"]
+	return nil
+}
+
+func (p *fakePlugin) ParseFunctionSpec(params *plugins.FunctionPluginParams, in v1.FunctionSpec) (*types.Struct, error) {
+	if params.UpstreamType != fakeType {
+		return nil, nil
+	}
+	return &types.Struct{Fields: map[string]*types.Value{
+		"upstream": {Kind: &types.Value_StringValue{StringValue: params.UpstreamName}},
+	}}, nil
+}
+
+func (p *fakePlugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
+	if p.GetDependencies(params.Context.Config) == nil {
+		return nil
+	}
+	return []plugins.StagedFilter{{
+		HttpFilter: &envoyhttp.HttpFilter{
+			Name: "io.solo.fake",
+			Config: &types.Struct{Fields: map[string]*types.Value{
+				"enabled": {Kind: &types.Value_BoolValue{BoolValue: true}},
+			}},
+		},
+		Stage: plugins.OutAuth,
+		After: []string{"io.solo.transformation"},
+	}}
+}
+
+// routeOnlyPlugin only takes part in processing routes
+type routeOnlyPlugin struct{}
+
+func (p *routeOnlyPlugin) GetDependencies(_ *v1.Config) *plugins.Dependencies {
+	return nil
+}
+
+func (p *routeOnlyPlugin) ProcessRoute(params *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
+	if len(params.Upstreams) == 0 {
+		return errors.New("no upstreams")
+	}
+	out.Decorator = &envoyroute.Decorator{Operation: params.Upstreams[0].Name}
+	return nil
+}
+
+// describingServer describes schemas that are not registered in this process
+type describingServer struct {
+	TranslatorPluginServer
+	schemas []*Schema
+}
+
+func (s *describingServer) Describe(_ context.Context, _ *DescribeRequest) (*DescribeResponse, error) {
+	return &DescribeResponse{Schemas: s.schemas}, nil
+}
+
+// serve serves the plugin on a local port, and returns a client for it that gives up on calls after callTimeout
+func serve(plugin plugins.TranslatorPlugin, callTimeout time.Duration) (*Plugin, func()) {
+	return serveServer(NewServer(plugin), callTimeout)
+}
+
+func serveServer(server TranslatorPluginServer, callTimeout time.Duration) (*Plugin, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	s := grpc.NewServer()
+	RegisterTranslatorPluginServer(s, server)
+	go s.Serve(lis)
+	p, err := Connect("fake", lis.Addr().String(), nil, time.Second, callTimeout)
+	Expect(err).NotTo(HaveOccurred())
+	return p, s.Stop
+}
+
+var _ = Describe("Remote plugins", func() {
+	var (
+		p    *Plugin
+		stop func()
+		cfg  *v1.Config
+	)
+	AfterEach(func() {
+		stop()
+	})
+	Context("a plugin that takes part in every phase", func() {
+		BeforeEach(func() {
+			p, stop = serve(&fakePlugin{}, time.Second)
+			cfg = &v1.Config{Upstreams: []*v1.Upstream{{Name: "my-upstream", Type: fakeType}}}
+		})
+		It("returns the plugin's dependencies", func() {
+			Expect(p.GetDependencies(cfg)).To(Equal(&plugins.Dependencies{SecretRefs: []string{"fake-secret"}}))
+			Expect(p.GetDependencies(&v1.Config{})).To(BeNil())
+		})
+		It("sends the cluster and the secrets the plugin asked for and applies the plugin's changes", func() {
+			out := &envoyapi.Cluster{Name: "my-upstream", ConnectTimeout: time.Second}
+			params := &plugins.UpstreamPluginParams{
+				Context: plugins.NewContext(cfg),
+				Secrets: map[string]*dependencies.Secret{
+					"fake-secret":  {Ref: "fake-secret", Data: map[string]string{"suffix": "processed"}},
+					"other-secret": {Ref: "other-secret", Data: map[string]string{"password": "hunter2"}},
+				},
+			}
+			Expect(p.Init(params.Context)).NotTo(HaveOccurred())
+			err := p.ProcessUpstream(params, cfg.Upstreams[0], out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Name).To(Equal("my-upstream-processed"))
+			Expect(out.Type).To(Equal(envoyapi.Cluster_STRICT_DNS))
+			Expect(out.ConnectTimeout).To(Equal(time.Second))
+		})
+		It("returns the plugin's errors with the plugin's name", func() {
+			out := &envoyapi.Cluster{Name: "my-upstream"}
+			err := p.ProcessUpstream(&plugins.UpstreamPluginParams{Context: plugins.NewContext(cfg)}, cfg.Upstreams[0], out)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("remote plugin fake"))
+			Expect(err.Error()).To(ContainSubstring("fake-secret not found"))
+			Expect(out.Name).To(Equal("my-upstream"))
+		})
+		It("parses function specs", func() {
+			spec, err := p.ParseFunctionSpec(&plugins.FunctionPluginParams{UpstreamName: "my-upstream", UpstreamType: fakeType}, &types.Struct{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Fields["upstream"].GetStringValue()).To(Equal("my-upstream"))
+			spec, err = p.ParseFunctionSpec(&plugins.FunctionPluginParams{UpstreamType: "other"}, &types.Struct{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec).To(BeNil())
+		})
+		It("returns the plugin's http filters", func() {
+			filters := p.HttpFilters(&plugins.FilterPluginParams{Context: plugins.NewContext(cfg)})
+			Expect(filters).To(HaveLen(1))
+			Expect(filters[0].HttpFilter.Name).To(Equal("io.solo.fake"))
+			Expect(filters[0].HttpFilter.Config.Fields["enabled"].GetBoolValue()).To(BeTrue())
+			Expect(filters[0].Stage).To(Equal(plugins.OutAuth))
+			Expect(filters[0].After).To(Equal([]string{"io.solo.transformation"}))
+			Expect(p.HttpFilters(&plugins.FilterPluginParams{Context: plugins.NewContext(&v1.Config{})})).To(BeEmpty())
+		})
+	})
+	Context("a plugin that only processes routes", func() {
+		BeforeEach(func() {
+			p, stop = serve(&routeOnlyPlugin{}, time.Second)
+		})
+		It("sends the upstreams of the route and applies the plugin's changes", func() {
+			out := &envoyroute.Route{}
+			params := &plugins.RoutePluginParams{Upstreams: []*v1.Upstream{{Name: "my-upstream"}}}
+			err := p.ProcessRoute(params, &v1.Route{}, out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Decorator.Operation).To(Equal("my-upstream"))
+		})
+		It("leaves the other phases alone", func() {
+			out := &envoyapi.Cluster{Name: "my-upstream"}
+			err := p.ProcessUpstream(&plugins.UpstreamPluginParams{Context: plugins.NewContext(nil)}, &v1.Upstream{Name: "my-upstream"}, out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(&envoyapi.Cluster{Name: "my-upstream"}))
+			spec, err := p.ParseFunctionSpec(&plugins.FunctionPluginParams{}, &types.Struct{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec).To(BeNil())
+			Expect(p.HttpFilters(&plugins.FilterPluginParams{Context: plugins.NewContext(&v1.Config{})})).To(BeEmpty())
+			Expect(p.GetDependencies(&v1.Config{})).To(BeNil())
+		})
+	})
+	Context("a plugin that does not answer within the timeout", func() {
+		BeforeEach(func() {
+			p, stop = serve(&fakePlugin{delay: time.Second}, 50*time.Millisecond)
+		})
+		It("returns an error", func() {
+			out := &envoyapi.Cluster{Name: "my-upstream"}
+			err := p.ProcessUpstream(&plugins.UpstreamPluginParams{Context: plugins.NewContext(nil)}, &v1.Upstream{Name: "my-upstream", Type: fakeType}, out)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("remote plugin fake"))
+			Expect(err.Error()).To(ContainSubstring("DeadlineExceeded"))
+		})
+	})
+	Context("a plugin that describes its schemas", func() {
+		var describe func(version string) func()
+		BeforeEach(func() {
+			properties, err := protoutil.MarshalStruct(&schemas.Property{
+				Type: "object",
+				Properties: map[string]*schemas.Property{
+					"fake_retries": {Type: "integer"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			describe = func(version string) func() {
+				var stop func()
+				p, stop = serveServer(&describingServer{
+					TranslatorPluginServer: NewServer(&routeOnlyPlugin{}),
+					schemas: []*Schema{{
+						Kind:       string(schemas.KindRouteExtensions),
+						Type:       "fake-remote",
+						Version:    version,
+						Properties: properties,
+					}},
+				}, time.Second)
+				return stop
+			}
+			stop = describe("v1")
+		})
+		It("validates route extensions against the plugin's schema", func() {
+			valid := &types.Struct{Fields: map[string]*types.Value{
+				"fake_retries": {Kind: &types.Value_NumberValue{NumberValue: 3}},
+			}}
+			Expect(schemas.ValidateRouteExtensions(valid)).NotTo(HaveOccurred())
+			invalid := &types.Struct{Fields: map[string]*types.Value{
+				"fake_retries": {Kind: &types.Value_StringValue{StringValue: "three"}},
+			}}
+			err := schemas.ValidateRouteExtensions(invalid)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake_retries"))
+		})
+		It("connects again to a plugin with the same schemas", func() {
+			stop()
+			stop = describe("v1")
+		})
+		It("fails to connect to a plugin with a different version of a schema", func() {
+			stop()
+			stop = func() {}
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			s := grpc.NewServer()
+			defer s.Stop()
+			RegisterTranslatorPluginServer(s, &describingServer{
+				TranslatorPluginServer: NewServer(&routeOnlyPlugin{}),
+				schemas: []*Schema{{
+					Kind:       string(schemas.KindRouteExtensions),
+					Type:       "fake-remote",
+					Version:    "v2",
+					Properties: &types.Struct{},
+				}},
+			})
+			go s.Serve(lis)
+			_, err = Connect("fake", lis.Addr().String(), nil, time.Second, time.Second)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("already registered"))
+		})
+	})
+	It("fails the translation when it cannot get the http filters of a plugin that is down", func() {
+		p, stop = serve(&fakePlugin{}, time.Second)
+		stop()
+		stop = func() {}
+		ctx := plugins.NewContext(&v1.Config{Upstreams: []*v1.Upstream{{Name: "my-upstream", Type: fakeType}}})
+		Expect(p.HttpFilters(&plugins.FilterPluginParams{Context: ctx})).To(BeEmpty())
+		Expect(ctx.Err()).To(HaveOccurred())
+		Expect(ctx.Err().Error()).To(ContainSubstring("remote plugin fake"))
+	})
+	It("fails to connect to a plugin that is not serving", func() {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr := lis.Addr().String()
+		lis.Close()
+		stop = func() {}
+		_, err = Connect("fake", addr, nil, 100*time.Millisecond, time.Second)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("connecting to plugin fake"))
+	})
+	It("refuses to connect without tls to a plugin that is not on this host", func() {
+		stop = func() {}
+		_, err := Connect("fake", "10.1.2.3:8080", nil, 100*time.Millisecond, time.Second)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("requires tls"))
+	})
+})
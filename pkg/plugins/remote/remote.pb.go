@@ -0,0 +1,798 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: remote.proto
+
+/*
+Package remote is a generated protocol buffer package.
+
+It is generated from these files:
+
+	remote.proto
+
+It has these top-level messages:
+
+	DescribeRequest
+	DescribeResponse
+	Schema
+	GetDependenciesRequest
+	GetDependenciesResponse
+	Secret
+	ProcessUpstreamRequest
+	ProcessUpstreamResponse
+	ParseFunctionSpecRequest
+	ParseFunctionSpecResponse
+	ProcessRouteRequest
+	ProcessRouteResponse
+	HttpFiltersRequest
+	HttpFiltersResponse
+	HttpFilter
+*/
+package remote
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/gogo/protobuf/types"
+import v14 "github.com/solo-io/gloo/pkg/api/types/v1"
+import v12 "github.com/solo-io/gloo/pkg/api/types/v1"
+import v13 "github.com/solo-io/gloo/pkg/api/types/v1"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// the stages of the http filter chain, in order
+type Stage int32
+
+const (
+	Stage_PRE_IN_AUTH  Stage = 0
+	Stage_IN_AUTH      Stage = 1
+	Stage_POST_IN_AUTH Stage = 2
+	Stage_PRE_OUT_AUTH Stage = 3
+	Stage_OUT_AUTH     Stage = 4
+)
+
+var Stage_name = map[int32]string{
+	0: "PRE_IN_AUTH",
+	1: "IN_AUTH",
+	2: "POST_IN_AUTH",
+	3: "PRE_OUT_AUTH",
+	4: "OUT_AUTH",
+}
+var Stage_value = map[string]int32{
+	"PRE_IN_AUTH":  0,
+	"IN_AUTH":      1,
+	"POST_IN_AUTH": 2,
+	"PRE_OUT_AUTH": 3,
+	"OUT_AUTH":     4,
+}
+
+func (x Stage) String() string {
+	return proto.EnumName(Stage_name, int32(x))
+}
+func (Stage) EnumDescriptor() ([]byte, []int) { return fileDescriptorRemote, []int{0} }
+
+type DescribeRequest struct {
+}
+
+func (m *DescribeRequest) Reset()                    { *m = DescribeRequest{} }
+func (m *DescribeRequest) String() string            { return proto.CompactTextString(m) }
+func (*DescribeRequest) ProtoMessage()               {}
+func (*DescribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{0} }
+
+type DescribeResponse struct {
+	Schemas []*Schema `protobuf:"bytes,1,rep,name=schemas" json:"schemas,omitempty"`
+}
+
+func (m *DescribeResponse) Reset()                    { *m = DescribeResponse{} }
+func (m *DescribeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DescribeResponse) ProtoMessage()               {}
+func (*DescribeResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{1} }
+
+func (m *DescribeResponse) GetSchemas() []*Schema {
+	if m != nil {
+		return m.Schemas
+	}
+	return nil
+}
+
+// the schema of a spec the plugin decodes from a google.protobuf.Struct
+type Schema struct {
+	// the field of a config object the spec is stored in: upstream_spec, function_spec, service_properties or route_extensions
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// the upstream or service type the spec belongs to. for route extensions, the name of the plugin that reads them
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// the version of the spec. it changes whenever a field is removed or changes its meaning
+	Version     string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// the OpenAPI v3 schema of the spec
+	Properties *google_protobuf.Struct `protobuf:"bytes,5,opt,name=properties" json:"properties,omitempty"`
+}
+
+func (m *Schema) Reset()                    { *m = Schema{} }
+func (m *Schema) String() string            { return proto.CompactTextString(m) }
+func (*Schema) ProtoMessage()               {}
+func (*Schema) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{2} }
+
+func (m *Schema) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Schema) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Schema) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Schema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Schema) GetProperties() *google_protobuf.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+type GetDependenciesRequest struct {
+	Config *v14.Config `protobuf:"bytes,1,opt,name=config" json:"config,omitempty"`
+}
+
+func (m *GetDependenciesRequest) Reset()                    { *m = GetDependenciesRequest{} }
+func (m *GetDependenciesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDependenciesRequest) ProtoMessage()               {}
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{3} }
+
+func (m *GetDependenciesRequest) GetConfig() *v14.Config {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type GetDependenciesResponse struct {
+	SecretRefs []string `protobuf:"bytes,1,rep,name=secret_refs,json=secretRefs" json:"secret_refs,omitempty"`
+	FileRefs   []string `protobuf:"bytes,2,rep,name=file_refs,json=fileRefs" json:"file_refs,omitempty"`
+}
+
+func (m *GetDependenciesResponse) Reset()                    { *m = GetDependenciesResponse{} }
+func (m *GetDependenciesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDependenciesResponse) ProtoMessage()               {}
+func (*GetDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{4} }
+
+func (m *GetDependenciesResponse) GetSecretRefs() []string {
+	if m != nil {
+		return m.SecretRefs
+	}
+	return nil
+}
+
+func (m *GetDependenciesResponse) GetFileRefs() []string {
+	if m != nil {
+		return m.FileRefs
+	}
+	return nil
+}
+
+type Secret struct {
+	Data map[string]string `protobuf:"bytes,1,rep,name=data" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Secret) Reset()                    { *m = Secret{} }
+func (m *Secret) String() string            { return proto.CompactTextString(m) }
+func (*Secret) ProtoMessage()               {}
+func (*Secret) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{5} }
+
+func (m *Secret) GetData() map[string]string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ProcessUpstreamRequest struct {
+	Upstream *v12.Upstream `protobuf:"bytes,1,opt,name=upstream" json:"upstream,omitempty"`
+	// the serialized envoy.api.v2.Cluster, as built by the plugins that ran before this one
+	Cluster []byte `protobuf:"bytes,2,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// the secrets and files the plugin asked for in GetDependencies, by ref
+	Secrets map[string]*Secret `protobuf:"bytes,3,rep,name=secrets" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Files   map[string][]byte  `protobuf:"bytes,4,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *ProcessUpstreamRequest) Reset()                    { *m = ProcessUpstreamRequest{} }
+func (m *ProcessUpstreamRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProcessUpstreamRequest) ProtoMessage()               {}
+func (*ProcessUpstreamRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{6} }
+
+func (m *ProcessUpstreamRequest) GetUpstream() *v12.Upstream {
+	if m != nil {
+		return m.Upstream
+	}
+	return nil
+}
+
+func (m *ProcessUpstreamRequest) GetCluster() []byte {
+	if m != nil {
+		return m.Cluster
+	}
+	return nil
+}
+
+func (m *ProcessUpstreamRequest) GetSecrets() map[string]*Secret {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
+}
+
+func (m *ProcessUpstreamRequest) GetFiles() map[string][]byte {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type ProcessUpstreamResponse struct {
+	// the serialized envoy.api.v2.Cluster, with the plugin's changes. left empty if the plugin made none
+	Cluster []byte `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+}
+
+func (m *ProcessUpstreamResponse) Reset()                    { *m = ProcessUpstreamResponse{} }
+func (m *ProcessUpstreamResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProcessUpstreamResponse) ProtoMessage()               {}
+func (*ProcessUpstreamResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{7} }
+
+func (m *ProcessUpstreamResponse) GetCluster() []byte {
+	if m != nil {
+		return m.Cluster
+	}
+	return nil
+}
+
+type ParseFunctionSpecRequest struct {
+	UpstreamName string                  `protobuf:"bytes,1,opt,name=upstream_name,json=upstreamName,proto3" json:"upstream_name,omitempty"`
+	UpstreamType string                  `protobuf:"bytes,2,opt,name=upstream_type,json=upstreamType,proto3" json:"upstream_type,omitempty"`
+	ServiceType  string                  `protobuf:"bytes,3,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	FunctionSpec *google_protobuf.Struct `protobuf:"bytes,4,opt,name=function_spec,json=functionSpec" json:"function_spec,omitempty"`
+}
+
+func (m *ParseFunctionSpecRequest) Reset()                    { *m = ParseFunctionSpecRequest{} }
+func (m *ParseFunctionSpecRequest) String() string            { return proto.CompactTextString(m) }
+func (*ParseFunctionSpecRequest) ProtoMessage()               {}
+func (*ParseFunctionSpecRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{8} }
+
+func (m *ParseFunctionSpecRequest) GetUpstreamName() string {
+	if m != nil {
+		return m.UpstreamName
+	}
+	return ""
+}
+
+func (m *ParseFunctionSpecRequest) GetUpstreamType() string {
+	if m != nil {
+		return m.UpstreamType
+	}
+	return ""
+}
+
+func (m *ParseFunctionSpecRequest) GetServiceType() string {
+	if m != nil {
+		return m.ServiceType
+	}
+	return ""
+}
+
+func (m *ParseFunctionSpecRequest) GetFunctionSpec() *google_protobuf.Struct {
+	if m != nil {
+		return m.FunctionSpec
+	}
+	return nil
+}
+
+type ParseFunctionSpecResponse struct {
+	// left unset if the function spec does not belong to the plugin
+	EnvoyFunctionSpec *google_protobuf.Struct `protobuf:"bytes,1,opt,name=envoy_function_spec,json=envoyFunctionSpec" json:"envoy_function_spec,omitempty"`
+}
+
+func (m *ParseFunctionSpecResponse) Reset()                    { *m = ParseFunctionSpecResponse{} }
+func (m *ParseFunctionSpecResponse) String() string            { return proto.CompactTextString(m) }
+func (*ParseFunctionSpecResponse) ProtoMessage()               {}
+func (*ParseFunctionSpecResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{9} }
+
+func (m *ParseFunctionSpecResponse) GetEnvoyFunctionSpec() *google_protobuf.Struct {
+	if m != nil {
+		return m.EnvoyFunctionSpec
+	}
+	return nil
+}
+
+type ProcessRouteRequest struct {
+	Route *v13.Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+	// the serialized envoy.api.v2.route.Route, as built by the plugins that ran before this one
+	EnvoyRoute []byte `protobuf:"bytes,2,opt,name=envoy_route,json=envoyRoute,proto3" json:"envoy_route,omitempty"`
+	// the upstreams the route sends requests to
+	Upstreams []*v12.Upstream `protobuf:"bytes,3,rep,name=upstreams" json:"upstreams,omitempty"`
+}
+
+func (m *ProcessRouteRequest) Reset()                    { *m = ProcessRouteRequest{} }
+func (m *ProcessRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProcessRouteRequest) ProtoMessage()               {}
+func (*ProcessRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{10} }
+
+func (m *ProcessRouteRequest) GetRoute() *v13.Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *ProcessRouteRequest) GetEnvoyRoute() []byte {
+	if m != nil {
+		return m.EnvoyRoute
+	}
+	return nil
+}
+
+func (m *ProcessRouteRequest) GetUpstreams() []*v12.Upstream {
+	if m != nil {
+		return m.Upstreams
+	}
+	return nil
+}
+
+type ProcessRouteResponse struct {
+	// the serialized envoy.api.v2.route.Route, with the plugin's changes. left empty if the plugin made none
+	EnvoyRoute []byte `protobuf:"bytes,1,opt,name=envoy_route,json=envoyRoute,proto3" json:"envoy_route,omitempty"`
+}
+
+func (m *ProcessRouteResponse) Reset()                    { *m = ProcessRouteResponse{} }
+func (m *ProcessRouteResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProcessRouteResponse) ProtoMessage()               {}
+func (*ProcessRouteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{11} }
+
+func (m *ProcessRouteResponse) GetEnvoyRoute() []byte {
+	if m != nil {
+		return m.EnvoyRoute
+	}
+	return nil
+}
+
+type HttpFiltersRequest struct {
+	Config *v14.Config `protobuf:"bytes,1,opt,name=config" json:"config,omitempty"`
+}
+
+func (m *HttpFiltersRequest) Reset()                    { *m = HttpFiltersRequest{} }
+func (m *HttpFiltersRequest) String() string            { return proto.CompactTextString(m) }
+func (*HttpFiltersRequest) ProtoMessage()               {}
+func (*HttpFiltersRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{12} }
+
+func (m *HttpFiltersRequest) GetConfig() *v14.Config {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type HttpFiltersResponse struct {
+	Filters []*HttpFilter `protobuf:"bytes,1,rep,name=filters" json:"filters,omitempty"`
+}
+
+func (m *HttpFiltersResponse) Reset()                    { *m = HttpFiltersResponse{} }
+func (m *HttpFiltersResponse) String() string            { return proto.CompactTextString(m) }
+func (*HttpFiltersResponse) ProtoMessage()               {}
+func (*HttpFiltersResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{13} }
+
+func (m *HttpFiltersResponse) GetFilters() []*HttpFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+type HttpFilter struct {
+	Name   string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config *google_protobuf.Struct `protobuf:"bytes,2,opt,name=config" json:"config,omitempty"`
+	Stage  Stage                   `protobuf:"varint,3,opt,name=stage,proto3,enum=remote.Stage" json:"stage,omitempty"`
+	// names of filters this filter must come before or after, whatever their stage
+	Before []string `protobuf:"bytes,4,rep,name=before" json:"before,omitempty"`
+	After  []string `protobuf:"bytes,5,rep,name=after" json:"after,omitempty"`
+}
+
+func (m *HttpFilter) Reset()                    { *m = HttpFilter{} }
+func (m *HttpFilter) String() string            { return proto.CompactTextString(m) }
+func (*HttpFilter) ProtoMessage()               {}
+func (*HttpFilter) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{14} }
+
+func (m *HttpFilter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HttpFilter) GetConfig() *google_protobuf.Struct {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *HttpFilter) GetStage() Stage {
+	if m != nil {
+		return m.Stage
+	}
+	return Stage_PRE_IN_AUTH
+}
+
+func (m *HttpFilter) GetBefore() []string {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *HttpFilter) GetAfter() []string {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DescribeRequest)(nil), "remote.DescribeRequest")
+	proto.RegisterType((*DescribeResponse)(nil), "remote.DescribeResponse")
+	proto.RegisterType((*Schema)(nil), "remote.Schema")
+	proto.RegisterType((*GetDependenciesRequest)(nil), "remote.GetDependenciesRequest")
+	proto.RegisterType((*GetDependenciesResponse)(nil), "remote.GetDependenciesResponse")
+	proto.RegisterType((*Secret)(nil), "remote.Secret")
+	proto.RegisterType((*ProcessUpstreamRequest)(nil), "remote.ProcessUpstreamRequest")
+	proto.RegisterType((*ProcessUpstreamResponse)(nil), "remote.ProcessUpstreamResponse")
+	proto.RegisterType((*ParseFunctionSpecRequest)(nil), "remote.ParseFunctionSpecRequest")
+	proto.RegisterType((*ParseFunctionSpecResponse)(nil), "remote.ParseFunctionSpecResponse")
+	proto.RegisterType((*ProcessRouteRequest)(nil), "remote.ProcessRouteRequest")
+	proto.RegisterType((*ProcessRouteResponse)(nil), "remote.ProcessRouteResponse")
+	proto.RegisterType((*HttpFiltersRequest)(nil), "remote.HttpFiltersRequest")
+	proto.RegisterType((*HttpFiltersResponse)(nil), "remote.HttpFiltersResponse")
+	proto.RegisterType((*HttpFilter)(nil), "remote.HttpFilter")
+	proto.RegisterEnum("remote.Stage", Stage_name, Stage_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for TranslatorPlugin service
+
+type TranslatorPluginClient interface {
+	// the schemas of the specs the plugin decodes, so the control plane can validate them
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
+	// the secrets and files the plugin needs to process the config
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
+	// adds the plugin's configuration to the envoy cluster of an upstream
+	ProcessUpstream(ctx context.Context, in *ProcessUpstreamRequest, opts ...grpc.CallOption) (*ProcessUpstreamResponse, error)
+	// translates the spec of a function to the spec envoy's filter expects
+	ParseFunctionSpec(ctx context.Context, in *ParseFunctionSpecRequest, opts ...grpc.CallOption) (*ParseFunctionSpecResponse, error)
+	// adds the plugin's configuration to the envoy route of a route
+	ProcessRoute(ctx context.Context, in *ProcessRouteRequest, opts ...grpc.CallOption) (*ProcessRouteResponse, error)
+	// the http filters the plugin needs for the config
+	HttpFilters(ctx context.Context, in *HttpFiltersRequest, opts ...grpc.CallOption) (*HttpFiltersResponse, error)
+}
+
+type translatorPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTranslatorPluginClient(cc *grpc.ClientConn) TranslatorPluginClient {
+	return &translatorPluginClient{cc}
+}
+
+func (c *translatorPluginClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := grpc.Invoke(ctx, "/remote.TranslatorPlugin/Describe", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorPluginClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
+	out := new(GetDependenciesResponse)
+	err := grpc.Invoke(ctx, "/remote.TranslatorPlugin/GetDependencies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorPluginClient) ProcessUpstream(ctx context.Context, in *ProcessUpstreamRequest, opts ...grpc.CallOption) (*ProcessUpstreamResponse, error) {
+	out := new(ProcessUpstreamResponse)
+	err := grpc.Invoke(ctx, "/remote.TranslatorPlugin/ProcessUpstream", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorPluginClient) ParseFunctionSpec(ctx context.Context, in *ParseFunctionSpecRequest, opts ...grpc.CallOption) (*ParseFunctionSpecResponse, error) {
+	out := new(ParseFunctionSpecResponse)
+	err := grpc.Invoke(ctx, "/remote.TranslatorPlugin/ParseFunctionSpec", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorPluginClient) ProcessRoute(ctx context.Context, in *ProcessRouteRequest, opts ...grpc.CallOption) (*ProcessRouteResponse, error) {
+	out := new(ProcessRouteResponse)
+	err := grpc.Invoke(ctx, "/remote.TranslatorPlugin/ProcessRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorPluginClient) HttpFilters(ctx context.Context, in *HttpFiltersRequest, opts ...grpc.CallOption) (*HttpFiltersResponse, error) {
+	out := new(HttpFiltersResponse)
+	err := grpc.Invoke(ctx, "/remote.TranslatorPlugin/HttpFilters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for TranslatorPlugin service
+
+type TranslatorPluginServer interface {
+	// the schemas of the specs the plugin decodes, so the control plane can validate them
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
+	// the secrets and files the plugin needs to process the config
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
+	// adds the plugin's configuration to the envoy cluster of an upstream
+	ProcessUpstream(context.Context, *ProcessUpstreamRequest) (*ProcessUpstreamResponse, error)
+	// translates the spec of a function to the spec envoy's filter expects
+	ParseFunctionSpec(context.Context, *ParseFunctionSpecRequest) (*ParseFunctionSpecResponse, error)
+	// adds the plugin's configuration to the envoy route of a route
+	ProcessRoute(context.Context, *ProcessRouteRequest) (*ProcessRouteResponse, error)
+	// the http filters the plugin needs for the config
+	HttpFilters(context.Context, *HttpFiltersRequest) (*HttpFiltersResponse, error)
+}
+
+func RegisterTranslatorPluginServer(s *grpc.Server, srv TranslatorPluginServer) {
+	s.RegisterService(&_TranslatorPlugin_serviceDesc, srv)
+}
+
+func _TranslatorPlugin_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorPluginServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.TranslatorPlugin/Describe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorPluginServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslatorPlugin_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorPluginServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.TranslatorPlugin/GetDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorPluginServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslatorPlugin_ProcessUpstream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessUpstreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorPluginServer).ProcessUpstream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.TranslatorPlugin/ProcessUpstream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorPluginServer).ProcessUpstream(ctx, req.(*ProcessUpstreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslatorPlugin_ParseFunctionSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseFunctionSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorPluginServer).ParseFunctionSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.TranslatorPlugin/ParseFunctionSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorPluginServer).ParseFunctionSpec(ctx, req.(*ParseFunctionSpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslatorPlugin_ProcessRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorPluginServer).ProcessRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.TranslatorPlugin/ProcessRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorPluginServer).ProcessRoute(ctx, req.(*ProcessRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslatorPlugin_HttpFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HttpFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorPluginServer).HttpFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.TranslatorPlugin/HttpFilters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorPluginServer).HttpFilters(ctx, req.(*HttpFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TranslatorPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "remote.TranslatorPlugin",
+	HandlerType: (*TranslatorPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _TranslatorPlugin_Describe_Handler,
+		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _TranslatorPlugin_GetDependencies_Handler,
+		},
+		{
+			MethodName: "ProcessUpstream",
+			Handler:    _TranslatorPlugin_ProcessUpstream_Handler,
+		},
+		{
+			MethodName: "ParseFunctionSpec",
+			Handler:    _TranslatorPlugin_ParseFunctionSpec_Handler,
+		},
+		{
+			MethodName: "ProcessRoute",
+			Handler:    _TranslatorPlugin_ProcessRoute_Handler,
+		},
+		{
+			MethodName: "HttpFilters",
+			Handler:    _TranslatorPlugin_HttpFilters_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remote.proto",
+}
+
+func init() { proto.RegisterFile("remote.proto", fileDescriptorRemote) }
+
+var fileDescriptorRemote = []byte{
+	// 943 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcb, 0x6e, 0xdb, 0x46,
+	0x14, 0x2d, 0xf5, 0xb2, 0x75, 0x45, 0xbf, 0xc6, 0x81, 0xcd, 0xd2, 0x41, 0xad, 0x30, 0x5d, 0xa8,
+	0x69, 0x20, 0xa3, 0xca, 0xc2, 0x46, 0xe1, 0xa2, 0x28, 0x62, 0x3b, 0x49, 0x17, 0x89, 0x40, 0xc9,
+	0x48, 0x77, 0x02, 0x4d, 0x5d, 0x2a, 0x44, 0x24, 0x92, 0x9d, 0x19, 0x0a, 0xf0, 0xba, 0xbf, 0xd2,
+	0x7e, 0x4a, 0x7f, 0xa5, 0x8b, 0x7e, 0x45, 0x31, 0x2f, 0x8a, 0x7a, 0xd8, 0x41, 0x77, 0xbc, 0xe7,
+	0x9c, 0xb9, 0x73, 0xe7, 0xcc, 0xbd, 0x43, 0xb0, 0x29, 0xce, 0x52, 0x8e, 0xdd, 0x8c, 0xa6, 0x3c,
+	0x25, 0x0d, 0x15, 0xb9, 0x4f, 0x27, 0x69, 0x3a, 0x99, 0xe2, 0x99, 0x44, 0xef, 0xf2, 0xe8, 0x8c,
+	0x71, 0x9a, 0x87, 0x5c, 0xa9, 0x5c, 0x3b, 0x4c, 0x93, 0x28, 0x9e, 0xe8, 0x68, 0x37, 0xcf, 0x18,
+	0xa7, 0x18, 0xcc, 0x74, 0x7c, 0x30, 0x8f, 0x29, 0xcf, 0x83, 0xe9, 0xa7, 0x94, 0xe9, 0x05, 0xde,
+	0x01, 0xec, 0x5d, 0x21, 0x0b, 0x69, 0x7c, 0x87, 0x3e, 0xfe, 0x9e, 0x23, 0xe3, 0xde, 0x25, 0xec,
+	0x2f, 0x20, 0x96, 0xa5, 0x09, 0x43, 0xd2, 0x81, 0x2d, 0x16, 0x7e, 0xc2, 0x59, 0xc0, 0x1c, 0xab,
+	0x5d, 0xed, 0xb4, 0x7a, 0xbb, 0x5d, 0x5d, 0xdd, 0x40, 0xc2, 0xbe, 0xa1, 0xbd, 0xbf, 0x2c, 0x68,
+	0x28, 0x8c, 0x10, 0xa8, 0x7d, 0x8e, 0x93, 0xb1, 0x63, 0xb5, 0xad, 0x4e, 0xd3, 0x97, 0xdf, 0x02,
+	0xe3, 0xf7, 0x19, 0x3a, 0x15, 0x85, 0x89, 0x6f, 0xe2, 0xc0, 0xd6, 0x1c, 0x29, 0x8b, 0xd3, 0xc4,
+	0xa9, 0x4a, 0xd8, 0x84, 0xa4, 0x0d, 0xad, 0xb1, 0x2c, 0x25, 0xe3, 0x82, 0xad, 0x49, 0xb6, 0x0c,
+	0x91, 0x73, 0x80, 0x8c, 0xa6, 0x19, 0x52, 0x1e, 0x23, 0x73, 0xea, 0x6d, 0xab, 0xd3, 0xea, 0x1d,
+	0x77, 0x95, 0x47, 0x5d, 0xe3, 0x51, 0x77, 0x20, 0x3d, 0xf2, 0x4b, 0x52, 0xef, 0x12, 0x8e, 0xde,
+	0x20, 0xbf, 0xc2, 0x0c, 0x93, 0x31, 0x26, 0x61, 0x8c, 0x4c, 0x9f, 0x9f, 0x78, 0xd0, 0x50, 0x2e,
+	0xca, 0xc2, 0x5b, 0x3d, 0xe8, 0xce, 0x7f, 0xe8, 0xbe, 0x96, 0x88, 0xaf, 0x19, 0xef, 0x23, 0x1c,
+	0xaf, 0xad, 0xd6, 0x56, 0x9d, 0x42, 0x8b, 0x61, 0x48, 0x91, 0x8f, 0x28, 0x46, 0xca, 0xae, 0xa6,
+	0x0f, 0x0a, 0xf2, 0x31, 0x62, 0xe4, 0x04, 0x9a, 0x51, 0x3c, 0x45, 0x45, 0x57, 0x24, 0xbd, 0x2d,
+	0x00, 0x41, 0x7a, 0x29, 0x34, 0x06, 0x52, 0x4a, 0x5e, 0x42, 0x6d, 0x1c, 0xf0, 0x40, 0xfb, 0xed,
+	0x14, 0x7e, 0x4b, 0xb6, 0x7b, 0x15, 0xf0, 0xe0, 0x3a, 0xe1, 0xf4, 0xde, 0x97, 0x2a, 0xf7, 0x1c,
+	0x9a, 0x05, 0x44, 0xf6, 0xa1, 0xfa, 0x19, 0xef, 0xb5, 0xef, 0xe2, 0x93, 0x3c, 0x81, 0xfa, 0x3c,
+	0x98, 0xe6, 0xc6, 0x77, 0x15, 0xfc, 0x58, 0xb9, 0xb0, 0xbc, 0x7f, 0x2b, 0x70, 0xd4, 0xa7, 0x69,
+	0x88, 0x8c, 0xdd, 0xea, 0x6e, 0x31, 0x46, 0x74, 0x60, 0xdb, 0x34, 0x90, 0xb6, 0xc2, 0x16, 0x56,
+	0x14, 0xb2, 0x82, 0x15, 0x37, 0x18, 0x4e, 0x73, 0xc6, 0x91, 0xca, 0x0d, 0x6c, 0xdf, 0x84, 0xe4,
+	0x1a, 0xb6, 0xd4, 0xd1, 0x99, 0x53, 0x95, 0x07, 0xf9, 0xde, 0x1c, 0x64, 0xf3, 0xa6, 0xfa, 0x7c,
+	0x4c, 0x9d, 0xcd, 0xac, 0x25, 0x3f, 0x43, 0x5d, 0x58, 0xc4, 0x9c, 0x9a, 0x4c, 0xf2, 0xdd, 0x17,
+	0x92, 0xdc, 0x08, 0xad, 0x4a, 0xa1, 0xd6, 0xb9, 0xbf, 0x82, 0x5d, 0xce, 0xbc, 0xc1, 0xa2, 0x6f,
+	0xcb, 0x16, 0x95, 0x1b, 0x5c, 0xdd, 0xdc, 0xc2, 0x32, 0xf7, 0x02, 0x60, 0xb1, 0xc1, 0x97, 0xcc,
+	0xb6, 0xcb, 0x66, 0xbf, 0x82, 0xe3, 0xb5, 0x8a, 0x75, 0xdb, 0x94, 0x2c, 0xb4, 0x96, 0x2c, 0xf4,
+	0xfe, 0xb6, 0xc0, 0xe9, 0x07, 0x94, 0xe1, 0x4d, 0x9e, 0x84, 0xa2, 0xe9, 0x07, 0x19, 0x86, 0xe6,
+	0x8e, 0x9e, 0xc3, 0x8e, 0xb9, 0x85, 0x51, 0x12, 0xcc, 0x50, 0xd7, 0x61, 0x1b, 0xf0, 0x7d, 0x30,
+	0xc3, 0x25, 0x51, 0x69, 0xfa, 0x0a, 0xd1, 0x50, 0x4c, 0xe1, 0x33, 0xb0, 0x19, 0xd2, 0x79, 0x1c,
+	0xa2, 0xd2, 0xa8, 0x51, 0x6c, 0x69, 0x4c, 0x4a, 0x2e, 0x61, 0x27, 0xd2, 0x35, 0x8c, 0x58, 0x86,
+	0xa1, 0x53, 0x7b, 0x7c, 0xde, 0xec, 0xa8, 0x54, 0xb1, 0x37, 0x86, 0xaf, 0x37, 0x1c, 0x43, 0x1f,
+	0xff, 0x0d, 0x1c, 0x62, 0x32, 0x4f, 0xef, 0x47, 0xcb, 0x1b, 0x58, 0x8f, 0x6f, 0x70, 0x20, 0xd7,
+	0x94, 0x13, 0x7a, 0x7f, 0x58, 0x70, 0xa8, 0x3d, 0xf6, 0xd3, 0x9c, 0x9b, 0x57, 0x8d, 0x9c, 0x42,
+	0x9d, 0x8a, 0x58, 0xa7, 0x6c, 0x8a, 0x4e, 0x56, 0x02, 0x85, 0x8b, 0xb9, 0x55, 0x15, 0x28, 0x99,
+	0xba, 0x3b, 0x90, 0x90, 0xd4, 0x91, 0x17, 0xd0, 0x34, 0x86, 0x99, 0x66, 0x5e, 0x9e, 0x87, 0x05,
+	0xed, 0x9d, 0xc3, 0x93, 0xe5, 0x22, 0x16, 0x8f, 0x43, 0x79, 0x13, 0x6b, 0x75, 0x13, 0xef, 0x02,
+	0xc8, 0x5b, 0xce, 0xb3, 0x9b, 0x78, 0xca, 0x91, 0xfe, 0xaf, 0x27, 0xe9, 0x35, 0x1c, 0x2e, 0xad,
+	0xd4, 0x3b, 0xbe, 0x84, 0xad, 0x48, 0x41, 0xfa, 0x25, 0x21, 0xa6, 0xb1, 0x17, 0x6a, 0xdf, 0x48,
+	0xbc, 0x3f, 0x2d, 0x80, 0x05, 0x2e, 0x5e, 0xeb, 0x52, 0x53, 0xc9, 0x6f, 0x72, 0x56, 0xd4, 0x52,
+	0x79, 0xfc, 0x72, 0xb4, 0x8c, 0x3c, 0x87, 0x3a, 0xe3, 0xc1, 0x44, 0x75, 0xd4, 0x6e, 0x6f, 0xa7,
+	0x18, 0x2c, 0x01, 0xfa, 0x8a, 0x23, 0x47, 0xd0, 0xb8, 0xc3, 0x28, 0xa5, 0x28, 0x27, 0xbc, 0xe9,
+	0xeb, 0x48, 0xcc, 0x52, 0x10, 0x89, 0xa1, 0xa8, 0x4b, 0x58, 0x05, 0x2f, 0x3e, 0x42, 0x5d, 0xae,
+	0x26, 0x7b, 0xd0, 0xea, 0xfb, 0xd7, 0xa3, 0x77, 0xef, 0x47, 0xbf, 0xdc, 0x0e, 0xdf, 0xee, 0x7f,
+	0x45, 0x5a, 0xb0, 0x65, 0x02, 0x8b, 0xec, 0x83, 0xdd, 0xff, 0x30, 0x18, 0x16, 0x74, 0x45, 0x22,
+	0xfe, 0xf5, 0xe8, 0xc3, 0xed, 0x50, 0x21, 0x55, 0x62, 0xc3, 0x76, 0x11, 0xd5, 0x7a, 0xff, 0x54,
+	0x61, 0x7f, 0x48, 0x83, 0x84, 0x4d, 0x03, 0x9e, 0xd2, 0xfe, 0x34, 0x9f, 0xc4, 0x09, 0xf9, 0x09,
+	0xb6, 0xcd, 0x0f, 0x91, 0x1c, 0x9b, 0xea, 0x57, 0xfe, 0x9a, 0xae, 0xb3, 0x4e, 0xe8, 0x1b, 0xf0,
+	0x61, 0x6f, 0xe5, 0x5f, 0x41, 0xbe, 0x31, 0xe2, 0xcd, 0xbf, 0x20, 0xf7, 0xf4, 0x41, 0x7e, 0x91,
+	0x73, 0xe5, 0x21, 0x59, 0xe4, 0xdc, 0xfc, 0x26, 0xba, 0xa7, 0x0f, 0xf2, 0x3a, 0xe7, 0x6f, 0x70,
+	0xb0, 0x36, 0x9f, 0xa4, 0x5d, 0xac, 0x7a, 0xe0, 0x05, 0x72, 0x9f, 0x3d, 0xa2, 0xd0, 0x99, 0xdf,
+	0x81, 0x5d, 0x9e, 0x06, 0x72, 0xb2, 0x52, 0x4a, 0x79, 0x50, 0xdd, 0xa7, 0x9b, 0x49, 0x9d, 0xea,
+	0x06, 0x5a, 0xa5, 0x2e, 0x27, 0xee, 0x7a, 0x33, 0x17, 0x26, 0x9e, 0x6c, 0xe4, 0x54, 0x9e, 0xbb,
+	0x86, 0xec, 0xd6, 0x57, 0xff, 0x0d, 0x00, 0x04, 0x72, 0x2a, 0x6b, 0x65, 0x09, 0x00, 0x00,
+}
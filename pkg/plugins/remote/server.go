@@ -0,0 +1,207 @@
+package remote
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/solo-io/gloo/internal/control-plane/filewatcher"
+	"github.com/solo-io/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/solo-io/gloo/pkg/secretwatcher"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+// AddressEnv is the environment variable that tells a plugin launched by the control plane the address to serve on
+const AddressEnv = "GLOO_PLUGIN_ADDRESS"
+
+// Serve serves the plugin on the address the control plane launched it with
+func Serve(plugin plugins.TranslatorPlugin) error {
+	address := os.Getenv(AddressEnv)
+	if address == "" {
+		return errors.Errorf("%v is not set", AddressEnv)
+	}
+	return ListenAndServe(address, plugin)
+}
+
+// ListenAndServe serves the plugin on address, which is host:port or unix:///path/to/socket.
+// a plugin the control plane connects to over the network must be served with tls, e.g. with
+// grpc.Creds(credentials.NewServerTLSFromFile(certFile, keyFile)) in opts
+func ListenAndServe(address string, plugin plugins.TranslatorPlugin, opts ...grpc.ServerOption) error {
+	network, addr := splitAddress(address)
+	lis, err := net.Listen(network, addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %v", address)
+	}
+	s := grpc.NewServer(opts...)
+	RegisterTranslatorPluginServer(s, NewServer(plugin))
+	return s.Serve(lis)
+}
+
+func splitAddress(address string) (string, string) {
+	if strings.HasPrefix(address, "unix://") {
+		return "unix", strings.TrimPrefix(address, "unix://")
+	}
+	return "tcp", address
+}
+
+// NewServer serves a plugin written against the plugins package to the control plane.
+// every call gets a context of its own, so the plugin cannot pass state from one phase to the next
+func NewServer(plugin plugins.TranslatorPlugin) TranslatorPluginServer {
+	return &server{plugin: plugin}
+}
+
+type server struct {
+	plugin plugins.TranslatorPlugin
+}
+
+// initContext initializes the context of a call the way the control plane initializes the context of a translation
+func (s *server) initContext(ctx *plugins.Context) error {
+	if initPlugin, ok := s.plugin.(plugins.InitPlugin); ok {
+		return initPlugin.Init(ctx)
+	}
+	return nil
+}
+
+// Describe describes every schema registered in the plugin's process, which are those of the specs it decodes
+func (s *server) Describe(_ context.Context, _ *DescribeRequest) (*DescribeResponse, error) {
+	resp := &DescribeResponse{}
+	for _, schema := range schemas.Registered() {
+		properties, err := protoutil.MarshalStruct(schema.Properties())
+		if err != nil {
+			return nil, errors.Wrapf(err, "describing the schema for %v %v", schema.Type, schema.Kind)
+		}
+		resp.Schemas = append(resp.Schemas, &Schema{
+			Kind:        string(schema.Kind),
+			Type:        schema.Type,
+			Version:     schema.Version,
+			Description: schema.Description,
+			Properties:  properties,
+		})
+	}
+	return resp, nil
+}
+
+func (s *server) GetDependencies(_ context.Context, req *GetDependenciesRequest) (*GetDependenciesResponse, error) {
+	deps := s.plugin.GetDependencies(req.Config)
+	if deps == nil {
+		return &GetDependenciesResponse{}, nil
+	}
+	return &GetDependenciesResponse{SecretRefs: deps.SecretRefs, FileRefs: deps.FileRefs}, nil
+}
+
+func (s *server) ProcessUpstream(_ context.Context, req *ProcessUpstreamRequest) (*ProcessUpstreamResponse, error) {
+	upstreamPlugin, ok := s.plugin.(plugins.UpstreamPlugin)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "not an upstream plugin")
+	}
+	var out envoyapi.Cluster
+	if err := proto.Unmarshal(req.Cluster, &out); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid cluster: %v", err)
+	}
+	params := &plugins.UpstreamPluginParams{
+		Context: plugins.NewContext(nil),
+		// the control plane names clusters after their upstreams
+		EnvoyNameForUpstream: func(upstreamName string) string {
+			return upstreamName
+		},
+		Secrets: make(secretwatcher.SecretMap),
+		Files:   make(filewatcher.Files),
+	}
+	for ref, secret := range req.Secrets {
+		params.Secrets[ref] = &dependencies.Secret{Ref: ref, Data: secret.Data}
+	}
+	for ref, contents := range req.Files {
+		params.Files[ref] = &dependencies.File{Ref: ref, Contents: contents}
+	}
+	if err := s.initContext(params.Context); err != nil {
+		return nil, err
+	}
+	if err := upstreamPlugin.ProcessUpstream(params, req.Upstream, &out); err != nil {
+		return nil, err
+	}
+	cluster, err := proto.Marshal(&out)
+	if err != nil {
+		return nil, err
+	}
+	return &ProcessUpstreamResponse{Cluster: cluster}, nil
+}
+
+func (s *server) ParseFunctionSpec(_ context.Context, req *ParseFunctionSpecRequest) (*ParseFunctionSpecResponse, error) {
+	functionPlugin, ok := s.plugin.(plugins.FunctionPlugin)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "not a function plugin")
+	}
+	params := &plugins.FunctionPluginParams{
+		Context:      plugins.NewContext(nil),
+		UpstreamName: req.UpstreamName,
+		UpstreamType: req.UpstreamType,
+		ServiceType:  req.ServiceType,
+	}
+	if err := s.initContext(params.Context); err != nil {
+		return nil, err
+	}
+	spec, err := functionPlugin.ParseFunctionSpec(params, req.FunctionSpec)
+	if err != nil {
+		return nil, err
+	}
+	return &ParseFunctionSpecResponse{EnvoyFunctionSpec: spec}, nil
+}
+
+func (s *server) ProcessRoute(_ context.Context, req *ProcessRouteRequest) (*ProcessRouteResponse, error) {
+	routePlugin, ok := s.plugin.(plugins.RoutePlugin)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "not a route plugin")
+	}
+	var out envoyroute.Route
+	if err := proto.Unmarshal(req.EnvoyRoute, &out); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid route: %v", err)
+	}
+	params := &plugins.RoutePluginParams{
+		Context:   plugins.NewContext(nil),
+		Upstreams: req.Upstreams,
+	}
+	if err := s.initContext(params.Context); err != nil {
+		return nil, err
+	}
+	if err := routePlugin.ProcessRoute(params, req.Route, &out); err != nil {
+		return nil, err
+	}
+	route, err := proto.Marshal(&out)
+	if err != nil {
+		return nil, err
+	}
+	return &ProcessRouteResponse{EnvoyRoute: route}, nil
+}
+
+func (s *server) HttpFilters(_ context.Context, req *HttpFiltersRequest) (*HttpFiltersResponse, error) {
+	filterPlugin, ok := s.plugin.(plugins.FilterPlugin)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "not a filter plugin")
+	}
+	params := &plugins.FilterPluginParams{Context: plugins.NewContext(req.Config)}
+	if err := s.initContext(params.Context); err != nil {
+		return nil, err
+	}
+	resp := &HttpFiltersResponse{}
+	for _, filter := range filterPlugin.HttpFilters(params) {
+		resp.Filters = append(resp.Filters, &HttpFilter{
+			Name:   filter.HttpFilter.Name,
+			Config: filter.HttpFilter.Config,
+			Stage:  Stage(filter.Stage),
+			Before: filter.Before,
+			After:  filter.After,
+		})
+	}
+	return resp, nil
+}
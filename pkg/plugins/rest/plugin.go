@@ -47,10 +47,10 @@ func (p *Plugin) ProcessUpstream(params *plugins.UpstreamPluginParams, in *v1.Up
 
 func (p *Plugin) ProcessRoute(pluginParams *plugins.RoutePluginParams, in *v1.Route, out *envoyroute.Route) error {
 	getTransformationFunction := createTransformationForRestFunction(pluginParams.Upstreams)
-	if err := p.transformation.AddRequestTransformationsToRoute(getTransformationFunction, in, out); err != nil {
+	if err := p.transformation.AddRequestTransformationsToRoute(pluginParams.Context, getTransformationFunction, in, out); err != nil {
 		return errors.Wrap(err, "failed to process request transformation")
 	}
-	if err := p.transformation.AddResponseTransformationsToRoute(in, out); err != nil {
+	if err := p.transformation.AddResponseTransformationsToRoute(pluginParams.Context, in, out); err != nil {
 		return errors.Wrap(err, "failed to process response transformation")
 	}
 	return nil
@@ -129,8 +129,8 @@ func findFunction(upstreams []*v1.Upstream, upstreamName, functionName string) (
 	return nil, errors.Errorf("function %v/%v not found", upstreamName, functionName)
 }
 
-func (p *Plugin) HttpFilters(_ *plugins.FilterPluginParams) []plugins.StagedFilter {
-	filter := p.transformation.GetTransformationFilter()
+func (p *Plugin) HttpFilters(params *plugins.FilterPluginParams) []plugins.StagedFilter {
+	filter := p.transformation.GetTransformationFilter(params.Context)
 	if filter == nil {
 		return nil
 	}
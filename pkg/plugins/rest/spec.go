@@ -2,9 +2,36 @@ package rest
 
 import (
 	"github.com/gogo/protobuf/types"
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
 	"github.com/solo-io/gloo/pkg/protoutil"
 )
 
+func init() {
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindFunctionSpec,
+		Type:        ServiceTypeREST,
+		Version:     "v1",
+		Description: "the templates a request to a REST function is transformed with",
+		New:         func() interface{} { return &Template{} },
+		Example:     EncodeFunctionSpec(Template{Path: "/api/pets/{{id}}", Header: map[string]string{":method": "GET"}}),
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeFunctionSpec(spec)
+			return err
+		},
+	})
+	schemas.Register(schemas.Schema{
+		Kind:        schemas.KindRouteExtensions,
+		Type:        ServiceTypeREST,
+		Version:     "v1",
+		Description: "the parameters extracted from requests to REST functions, and the transformation of their responses",
+		New:         func() interface{} { return &RouteExtension{} },
+		Decode: func(spec *types.Struct) error {
+			_, err := DecodeRouteExtension(spec)
+			return err
+		},
+	})
+}
+
 // this goes on the route extension
 type RouteExtension struct {
 	Parameters       *Parameters `json:"parameters,omitempty"`
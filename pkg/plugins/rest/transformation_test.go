@@ -17,7 +17,7 @@ var _ = Describe("Transformation", func() {
 		out := &envoyroute.Route{}
 
 		upstreamName := "nothing"
-		params := &plugins.RoutePluginParams{}
+		params := &plugins.RoutePluginParams{Context: plugins.NewContext(nil)}
 
 		in := NewNonFunctionSingleDestRoute(upstreamName)
 		in.Extensions = EncodeRouteExtension(RouteExtension{
@@ -30,7 +30,7 @@ var _ = Describe("Transformation", func() {
 		Expect(out.Metadata).NotTo(BeNil())
 		Expect(out.Metadata.FilterMetadata).To(HaveKey("io.solo.transformation"))
 		Expect(out.Metadata.FilterMetadata["io.solo.transformation"].Fields).To(HaveKey("response-transformation"))
-		filter := p.HttpFilters(nil)
+		filter := p.HttpFilters(&plugins.FilterPluginParams{Context: params.Context})
 		Expect(filter).To(HaveLen(1))
 		Expect(filter[0].Stage).To(Equal(plugins.PostInAuth))
 		Expect(filter[0].HttpFilter.Config).NotTo(BeNil())
@@ -82,6 +82,7 @@ var _ = Describe("Transformation", func() {
 		upstreamName := "users-svc"
 		funcName := "get_user"
 		params := &plugins.RoutePluginParams{
+			Context: plugins.NewContext(nil),
 			Upstreams: []*v1.Upstream{
 				NewFunctionalUpstream(upstreamName, funcName),
 			},
@@ -94,7 +95,7 @@ var _ = Describe("Transformation", func() {
 		Expect(out.Metadata).NotTo(BeNil())
 		Expect(out.Metadata.FilterMetadata).To(HaveKey("io.solo.transformation"))
 		Expect(out.Metadata.FilterMetadata["io.solo.transformation"].Fields).To(HaveKey("request-transformation"))
-		filter := p.HttpFilters(nil)
+		filter := p.HttpFilters(&plugins.FilterPluginParams{Context: params.Context})
 		Expect(filter).To(HaveLen(1))
 		Expect(filter[0].Stage).To(Equal(plugins.PostInAuth))
 		Expect(filter[0].HttpFilter.Config).NotTo(BeNil())
@@ -396,6 +397,7 @@ var _ = Describe("Transformation", func() {
 		upstreamName := "users-svc"
 		funcName := "get_user"
 		params := &plugins.RoutePluginParams{
+			Context: plugins.NewContext(nil),
 			Upstreams: []*v1.Upstream{
 				NewFunctionalUpstream(upstreamName, funcName),
 			},
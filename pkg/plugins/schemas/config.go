@@ -0,0 +1,76 @@
+package schemas
+
+import (
+	"github.com/gogo/protobuf/types"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// ValidateUpstream checks the spec of the upstream, the specs of its functions and its service properties
+// against the registered schemas. specs of types with no registered schema are not checked
+func ValidateUpstream(us *v1.Upstream) error {
+	var errs error
+	if s, ok := Get(KindUpstreamSpec, us.Type); ok {
+		if err := s.Validate(us.Spec); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "invalid %v spec", us.Type))
+		}
+	}
+	serviceType := us.GetServiceInfo().GetType()
+	if s, ok := Get(KindServiceProperties, serviceType); ok {
+		if err := s.Validate(us.ServiceInfo.Properties); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "invalid %v service properties", serviceType))
+		}
+	}
+	if s, ok := FunctionSchema(us.Type, serviceType); ok {
+		for _, fn := range us.Functions {
+			if err := s.Validate(fn.Spec); err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "invalid spec for function %v", fn.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// FunctionSchema returns the schema of the function specs of upstreams with the upstream and service type
+func FunctionSchema(upstreamType, serviceType string) (Schema, bool) {
+	if s, ok := Get(KindFunctionSpec, serviceType); ok && serviceType != "" {
+		return s, true
+	}
+	return Get(KindFunctionSpec, upstreamType)
+}
+
+// ValidateVirtualHost checks the extensions of the routes of the virtual host against the registered schemas
+func ValidateVirtualHost(vh *v1.VirtualHost) error {
+	var errs error
+	for i, route := range vh.Routes {
+		if err := ValidateRouteExtensions(route.Extensions); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "invalid extensions on route %v", i))
+		}
+	}
+	return errs
+}
+
+// ValidateRouteExtensions checks the route extensions a plugin has registered a schema for.
+// plugins without a schema, such as those run out of process, may read any other field, so other fields are accepted
+func ValidateRouteExtensions(extensions *types.Struct) error {
+	schemas := OfKind(KindRouteExtensions)
+	if len(schemas) == 0 {
+		return nil
+	}
+	return validate(extensions, schemas, true)
+}
+
+// RouteExtensionsProperties returns the OpenAPI schema of route extensions, with the fields of every plugin that reads them
+func RouteExtensionsProperties() *Property {
+	merged := &Property{Type: "object", Properties: make(map[string]*Property)}
+	for _, s := range OfKind(KindRouteExtensions) {
+		for name, p := range s.Properties().Properties {
+			if _, ok := merged.Properties[name]; !ok {
+				merged.Properties[name] = p
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,197 @@
+package schemas
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// Property is the OpenAPI v3 schema of a spec or one of its fields
+type Property struct {
+	Type        string               `json:"type,omitempty"`
+	Format      string               `json:"format,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Properties  map[string]*Property `json:"properties,omitempty"`
+	Items       *Property            `json:"items,omitempty"`
+	// the schema of the values of a map
+	AdditionalProperties *Property `json:"additionalProperties,omitempty"`
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// propertyFor returns the schema of the go value, as encoding/json marshals it
+func propertyFor(v interface{}) *Property {
+	return propertyForType(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+func propertyForType(t reflect.Type, visiting map[reflect.Type]bool) *Property {
+	if t == nil {
+		return &Property{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == durationType {
+		return &Property{Type: "integer", Format: "int64", Description: "nanoseconds"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Property{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Property{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Property{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Property{Type: "integer"}
+	case reflect.Float32:
+		return &Property{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Property{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Property{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Property{Type: "string", Format: "byte"}
+		}
+		return &Property{Type: "array", Items: propertyForType(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Property{Type: "object", AdditionalProperties: propertyForType(t.Elem(), visiting)}
+	case reflect.Struct:
+		// recursive types are described down to their first repetition
+		if visiting[t] {
+			return &Property{Type: "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		p := &Property{Type: "object", Properties: make(map[string]*Property)}
+		addFields(p, t, visiting)
+		return p
+	}
+	// interfaces can hold anything
+	return &Property{}
+}
+
+// addFields adds the fields encoding/json marshals for the struct, including those of embedded structs
+func addFields(p *Property, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addFields(p, embedded, visiting)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		p.Properties[name] = propertyForType(field.Type, visiting)
+	}
+}
+
+// jsonName returns the name the json tag gives the field, and whether encoding/json ignores the field
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+// check rejects values of the wrong type and fields the schema does not define, like decoding into a go type would.
+// value is a decoded json value, and path the name of the field it was found at
+func (p *Property) check(path string, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	mismatch := func() error {
+		if path == "" {
+			return errors.Errorf("spec must be of type %v", p.Type)
+		}
+		return errors.Errorf("field %q must be of type %v", path, p.Type)
+	}
+	switch p.Type {
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			return mismatch()
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return mismatch()
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return mismatch()
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return mismatch()
+		}
+		if p.Items == nil {
+			return nil
+		}
+		var errs error
+		for i, item := range items {
+			if err := p.Items.check(fmt.Sprintf("%v[%d]", path, i), item); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		return errs
+	case "object":
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		var names []string
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var errs error
+		for _, name := range names {
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			var field *Property
+			switch {
+			case p.Properties != nil:
+				field = p.field(name)
+				if field == nil {
+					errs = multierror.Append(errs, errors.Errorf("unknown field %q", fieldPath))
+					continue
+				}
+			case p.AdditionalProperties != nil:
+				field = p.AdditionalProperties
+			default:
+				continue
+			}
+			if err := field.check(fieldPath, fields[name]); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+// Kind is the field of a config object that a spec is stored in
+type Kind string
+
+const (
+	// Upstream.spec, by upstream type
+	KindUpstreamSpec Kind = "upstream_spec"
+	// Function.spec, by the service type of the upstream, or its upstream type if no schema is registered for the service type
+	KindFunctionSpec Kind = "function_spec"
+	// ServiceInfo.properties, by service type
+	KindServiceProperties Kind = "service_properties"
+	// Route.extensions. every route plugin reads the same extensions, so the schemas of all plugins are merged
+	KindRouteExtensions Kind = "route_extensions"
+)
+
+var kinds = []Kind{KindUpstreamSpec, KindFunctionSpec, KindServiceProperties, KindRouteExtensions}
+
+// Schema describes a spec that a plugin decodes from a google.protobuf.Struct
+type Schema struct {
+	Kind Kind
+	// the upstream or service type the spec belongs to. for route extensions, the name of the plugin that reads them
+	Type string
+	// the version of the spec. it changes whenever a field is removed or changes its meaning
+	Version string
+	// what the spec configures, for docs
+	Description string
+	// returns a pointer to a new value of the go type the plugin decodes the spec into.
+	// the fields of the spec and their types are those of the go type, named by their json tags
+	New func() interface{}
+	// the schema of a spec with no go type in this process, such as one described by a remote plugin.
+	// only used if New is nil
+	Property *Property
+	// an example of the spec, for docs and scaffolding. optional
+	Example *types.Struct
+	// the plugin's decoder, for the validation the types of the fields cannot express. optional
+	Decode func(spec *types.Struct) error
+}
+
+// Register registers the schema of a spec. it is called from the init() of the plugin that decodes the spec
+func Register(s Schema) {
+	if err := Add(s); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// Add registers the schema of a spec, failing if a different version of it is already registered.
+// adding a schema that is already registered does nothing, so a plugin that reconnects can describe its specs again
+func Add(s Schema) error {
+	if s.Kind == "" || s.Type == "" || s.Version == "" || (s.New == nil && s.Property == nil) {
+		return errors.Errorf("schema must have a kind, type, version and go type or properties: %#v", s)
+	}
+	defaultRegistry.lock.Lock()
+	defer defaultRegistry.lock.Unlock()
+	if existing, ok := defaultRegistry.get(s.Kind, s.Type); ok {
+		if existing.Version != s.Version {
+			return errors.Errorf("version %v of the schema for %v %v is already registered, cannot add version %v",
+				existing.Version, s.Type, s.Kind, s.Version)
+		}
+		return nil
+	}
+	defaultRegistry.schemas = append(defaultRegistry.schemas, s)
+	return nil
+}
+
+var defaultRegistry = &registry{}
+
+type registry struct {
+	lock    sync.RWMutex
+	schemas []Schema
+}
+
+func (r *registry) get(kind Kind, typ string) (Schema, bool) {
+	for _, s := range r.schemas {
+		if s.Kind == kind && s.Type == typ {
+			return s, true
+		}
+	}
+	return Schema{}, false
+}
+
+// Registered returns every registered schema, sorted by kind and type
+func Registered() []Schema {
+	var out []Schema
+	for _, kind := range kinds {
+		out = append(out, OfKind(kind)...)
+	}
+	return out
+}
+
+// OfKind returns the registered schemas of the kind, sorted by type
+func OfKind(kind Kind) []Schema {
+	defaultRegistry.lock.RLock()
+	defer defaultRegistry.lock.RUnlock()
+	var out []Schema
+	for _, s := range defaultRegistry.schemas {
+		if s.Kind == kind {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Type < out[j].Type })
+	return out
+}
+
+// Get returns the schema registered for the kind and type
+func Get(kind Kind, typ string) (Schema, bool) {
+	defaultRegistry.lock.RLock()
+	defer defaultRegistry.lock.RUnlock()
+	return defaultRegistry.get(kind, typ)
+}
+
+// Properties returns the OpenAPI schema of the spec
+func (s Schema) Properties() *Property {
+	p := s.property()
+	p.Description = s.Description
+	return p
+}
+
+// property returns a copy of the schema of the spec, from its go type if it has one
+func (s Schema) property() *Property {
+	if s.New != nil {
+		return propertyFor(s.New())
+	}
+	p := *s.Property
+	return &p
+}
+
+// Validate rejects fields the spec does not define and values of the wrong type, then runs the plugin's decoder.
+// a missing spec is left to the plugin to report at translation time
+func (s Schema) Validate(spec *types.Struct) error {
+	return validate(spec, []Schema{s}, false)
+}
+
+// validate checks the spec against several schemas that each define some of its fields.
+// unless allowUnknown is set, every field must be defined by at least one of them.
+// the fields a schema defines are always checked strictly, down to their nested objects
+func validate(spec *types.Struct, schemas []Schema, allowUnknown bool) error {
+	if spec == nil {
+		return nil
+	}
+	jsn, err := protoutil.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(jsn, &fields); err != nil {
+		return err
+	}
+	properties := make([]*Property, len(schemas))
+	for i, s := range schemas {
+		properties[i] = s.property()
+	}
+	var errs error
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		known := false
+		for _, p := range properties {
+			if p.hasField(name) {
+				known = true
+				break
+			}
+		}
+		if !known && !allowUnknown {
+			errs = multierror.Append(errs, errors.Errorf("unknown field %q", name))
+		}
+	}
+	for i, s := range schemas {
+		own := make(map[string]json.RawMessage)
+		for name, value := range fields {
+			if properties[i].hasField(name) {
+				own[name] = value
+			}
+		}
+		if err := s.decodeStrict(own, properties[i]); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if s.Decode != nil {
+			if err := s.Decode(spec); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// decodeStrict decodes the fields into the go type of the spec, rejecting unknown fields of nested objects.
+// specs without a go type are checked against their properties instead
+func (s Schema) decodeStrict(fields map[string]json.RawMessage, p *Property) error {
+	jsn, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if s.New == nil {
+		var value interface{}
+		if err := json.Unmarshal(jsn, &value); err != nil {
+			return err
+		}
+		return p.check("", value)
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsn))
+	dec.DisallowUnknownFields()
+	return dec.Decode(s.New())
+}
+
+// hasField returns true if the object has the field. like encoding/json, names are matched case-insensitively
+func (p *Property) hasField(name string) bool {
+	return p.field(name) != nil
+}
+
+// field returns the schema of the field of the object, or nil if it has no such field
+func (p *Property) field(name string) *Property {
+	if field, ok := p.Properties[name]; ok {
+		return field
+	}
+	for fieldName, field := range p.Properties {
+		if strings.EqualFold(fieldName, name) {
+			return field
+		}
+	}
+	return nil
+}
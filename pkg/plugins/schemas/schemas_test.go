@@ -0,0 +1,267 @@
+package schemas_test
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	. "github.com/solo-io/gloo/pkg/plugins/schemas"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/memory"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+type testUpstreamSpec struct {
+	Region string   `json:"region"`
+	Port   int      `json:"port,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Nested struct {
+		Name string `json:"name"`
+	} `json:"nested"`
+}
+
+type testFunctionSpec struct {
+	Path string `json:"path"`
+}
+
+type testExtensions struct {
+	Timeout int `json:"timeout,omitempty"`
+}
+
+type otherTestExtensions struct {
+	Retries     int `json:"retries,omitempty"`
+	RetryPolicy *struct {
+		Attempts int `json:"attempts"`
+	} `json:"retry_policy,omitempty"`
+}
+
+func init() {
+	Register(Schema{
+		Kind:    KindUpstreamSpec,
+		Type:    "schemas-test",
+		Version: "v1",
+		New:     func() interface{} { return &testUpstreamSpec{} },
+		Decode: func(spec *types.Struct) error {
+			var s testUpstreamSpec
+			if err := protoutil.UnmarshalStruct(spec, &s); err != nil {
+				return err
+			}
+			if s.Region == "" {
+				return errors.New("region is required")
+			}
+			return nil
+		},
+	})
+	Register(Schema{
+		Kind:    KindFunctionSpec,
+		Type:    "schemas-test",
+		Version: "v1",
+		New:     func() interface{} { return &testFunctionSpec{} },
+	})
+	Register(Schema{
+		Kind:    KindRouteExtensions,
+		Type:    "schemas-test",
+		Version: "v1",
+		New:     func() interface{} { return &testExtensions{} },
+	})
+	Register(Schema{
+		Kind:    KindRouteExtensions,
+		Type:    "schemas-test-other",
+		Version: "v1",
+		New:     func() interface{} { return &otherTestExtensions{} },
+	})
+}
+
+func toStruct(m map[string]interface{}) *types.Struct {
+	s, err := protoutil.MarshalStruct(m)
+	Must(err)
+	return s
+}
+
+func testUpstream(spec map[string]interface{}) *v1.Upstream {
+	return &v1.Upstream{
+		Name: "test",
+		Type: "schemas-test",
+		Spec: toStruct(spec),
+	}
+}
+
+var _ = Describe("Schemas", func() {
+	Describe("ValidateUpstream", func() {
+		It("accepts a spec that matches the schema", func() {
+			us := testUpstream(map[string]interface{}{
+				"region": "us-east-1",
+				"port":   8080,
+				"tags":   []string{"a", "b"},
+				"nested": map[string]interface{}{"name": "n"},
+			})
+			us.Functions = []*v1.Function{{Name: "fn", Spec: toStruct(map[string]interface{}{"path": "/fn"})}}
+			Expect(ValidateUpstream(us)).NotTo(HaveOccurred())
+		})
+		It("matches field names case-insensitively, like the plugins' decoders", func() {
+			Expect(ValidateUpstream(testUpstream(map[string]interface{}{"Region": "us-east-1"}))).NotTo(HaveOccurred())
+		})
+		It("rejects unknown fields", func() {
+			err := ValidateUpstream(testUpstream(map[string]interface{}{"region": "us-east-1", "regoin": "us-east-1"}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("regoin"))
+		})
+		It("rejects unknown fields of nested objects", func() {
+			err := ValidateUpstream(testUpstream(map[string]interface{}{
+				"region": "us-east-1",
+				"nested": map[string]interface{}{"nmae": "n"},
+			}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("nmae"))
+		})
+		It("rejects values of the wrong type", func() {
+			err := ValidateUpstream(testUpstream(map[string]interface{}{"region": "us-east-1", "port": "eighty"}))
+			Expect(err).To(HaveOccurred())
+		})
+		It("runs the plugin's decoder", func() {
+			err := ValidateUpstream(testUpstream(map[string]interface{}{"port": 80}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("region is required"))
+		})
+		It("validates function specs", func() {
+			us := testUpstream(map[string]interface{}{"region": "us-east-1"})
+			us.Functions = []*v1.Function{{Name: "fn", Spec: toStruct(map[string]interface{}{"pth": "/fn"})}}
+			err := ValidateUpstream(us)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fn"))
+		})
+		It("does not check upstreams of types with no schema", func() {
+			us := testUpstream(map[string]interface{}{"anything": true})
+			us.Type = "schemas-test-unregistered"
+			Expect(ValidateUpstream(us)).NotTo(HaveOccurred())
+		})
+	})
+	Describe("ValidateRouteExtensions", func() {
+		It("accepts fields of every plugin that reads route extensions", func() {
+			Expect(ValidateRouteExtensions(toStruct(map[string]interface{}{"timeout": 1, "retries": 2}))).NotTo(HaveOccurred())
+		})
+		It("accepts fields no plugin has registered a schema for", func() {
+			Expect(ValidateRouteExtensions(toStruct(map[string]interface{}{"timeout": 1, "unregistered": "x"}))).NotTo(HaveOccurred())
+		})
+		It("rejects unknown fields inside the fields of a registered plugin", func() {
+			err := ValidateRouteExtensions(toStruct(map[string]interface{}{
+				"timeout":      1,
+				"unregistered": map[string]interface{}{"anything": true},
+				"retry_policy": map[string]interface{}{"attempts": 2, "atempts": 3},
+			}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("atempts"))
+			Expect(err.Error()).NotTo(ContainSubstring("unregistered"))
+		})
+		It("rejects values of the wrong type for the fields of a registered plugin", func() {
+			err := ValidateRouteExtensions(toStruct(map[string]interface{}{"timeout": "soon", "unregistered": 1}))
+			Expect(err).To(HaveOccurred())
+		})
+		It("publishes the merged properties", func() {
+			props := RouteExtensionsProperties()
+			Expect(props.Properties).To(HaveKey("timeout"))
+			Expect(props.Properties).To(HaveKey("retries"))
+		})
+	})
+	Describe("Add", func() {
+		described := Schema{
+			Kind:    KindUpstreamSpec,
+			Type:    "schemas-test-described",
+			Version: "v1",
+			Property: &Property{
+				Type: "object",
+				Properties: map[string]*Property{
+					"port":   {Type: "integer"},
+					"nested": {Type: "object", Properties: map[string]*Property{"name": {Type: "string"}}},
+				},
+			},
+		}
+		BeforeEach(func() {
+			Expect(Add(described)).NotTo(HaveOccurred())
+		})
+		It("does nothing if the same version is already registered", func() {
+			Expect(Add(described)).NotTo(HaveOccurred())
+		})
+		It("fails if a different version is already registered", func() {
+			other := described
+			other.Version = "v2"
+			err := Add(other)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("already registered"))
+			s, ok := Get(KindUpstreamSpec, "schemas-test-described")
+			Expect(ok).To(BeTrue())
+			Expect(s.Version).To(Equal("v1"))
+		})
+		It("validates specs against the properties of schemas without a go type", func() {
+			us := testUpstream(map[string]interface{}{"port": 80, "nested": map[string]interface{}{"name": "n"}})
+			us.Type = "schemas-test-described"
+			Expect(ValidateUpstream(us)).NotTo(HaveOccurred())
+
+			us.Spec = toStruct(map[string]interface{}{"port": "eighty", "nested": map[string]interface{}{"nmae": "n"}})
+			err := ValidateUpstream(us)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("port"))
+			Expect(err.Error()).To(ContainSubstring("nested.nmae"))
+		})
+	})
+	Describe("Properties", func() {
+		It("describes the go type of the spec", func() {
+			s, ok := Get(KindUpstreamSpec, "schemas-test")
+			Expect(ok).To(BeTrue())
+			props := s.Properties()
+			Expect(props.Type).To(Equal("object"))
+			Expect(props.Properties["region"].Type).To(Equal("string"))
+			Expect(props.Properties["port"].Type).To(Equal("integer"))
+			Expect(props.Properties["tags"].Items.Type).To(Equal("string"))
+			Expect(props.Properties["nested"].Properties).To(HaveKey("name"))
+		})
+	})
+	Describe("Storage", func() {
+		var store storage.Interface
+		BeforeEach(func() {
+			store = Storage(memory.NewStorage())
+			Must(store.V1().Register())
+		})
+		It("writes valid config objects", func() {
+			_, err := store.V1().Upstreams().Create(testUpstream(map[string]interface{}{"region": "us-east-1"}))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = store.V1().VirtualHosts().Create(&v1.VirtualHost{
+				Name:   "test",
+				Routes: []*v1.Route{{Extensions: toStruct(map[string]interface{}{"timeout": 1})}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects invalid config objects with an invalid error", func() {
+			_, err := store.V1().Upstreams().Create(testUpstream(map[string]interface{}{"regoin": "us-east-1"}))
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsInvalid(err)).To(BeTrue())
+			_, err = store.V1().VirtualHosts().Create(&v1.VirtualHost{
+				Name:   "test",
+				Routes: []*v1.Route{{Extensions: toStruct(map[string]interface{}{"timout": 1})}},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsInvalid(err)).To(BeTrue())
+		})
+		It("rejects invalid updates", func() {
+			us, err := store.V1().Upstreams().Create(testUpstream(map[string]interface{}{"region": "us-east-1"}))
+			Must(err)
+			us.Spec = toStruct(map[string]interface{}{"region": "us-east-1", "port": "eighty"})
+			_, err = store.V1().Upstreams().Update(us)
+			Expect(storage.IsInvalid(err)).To(BeTrue())
+		})
+		It("writes the status of config objects", func() {
+			_, err := store.V1().Upstreams().Create(testUpstream(map[string]interface{}{"region": "us-east-1"}))
+			Must(err)
+			status := &v1.Status{State: v1.Status_Rejected, Reason: "test"}
+			_, err = store.V1().Upstreams().(storage.StatusWriter).UpdateStatus("test", status)
+			Expect(err).NotTo(HaveOccurred())
+			us, err := store.V1().Upstreams().Get("test")
+			Must(err)
+			Expect(us.Status).To(Equal(status))
+		})
+	})
+})
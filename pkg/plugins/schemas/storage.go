@@ -0,0 +1,105 @@
+package schemas
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+// Storage wraps store so that upstreams and virtual hosts whose specs do not match the registered schemas
+// are rejected with an invalid error before they are written. reads and watches are passed through,
+// so config objects written before a schema was registered can still be read, and have their status written
+func Storage(store storage.Interface) storage.Interface {
+	return &schemaStorage{store: store}
+}
+
+type schemaStorage struct {
+	store storage.Interface
+}
+
+func (s *schemaStorage) V1() storage.V1 {
+	return &schemaV1{V1: s.store.V1()}
+}
+
+type schemaV1 struct {
+	storage.V1
+}
+
+func (v *schemaV1) Upstreams() storage.Upstreams {
+	return &schemaUpstreams{Upstreams: v.V1.Upstreams()}
+}
+
+func (v *schemaV1) VirtualHosts() storage.VirtualHosts {
+	return &schemaVirtualHosts{VirtualHosts: v.V1.VirtualHosts()}
+}
+
+type schemaUpstreams struct {
+	storage.Upstreams
+}
+
+func (c *schemaUpstreams) Create(item *v1.Upstream) (*v1.Upstream, error) {
+	if err := ValidateUpstream(item); err != nil {
+		return nil, storage.NewInvalidErr(errors.Wrapf(err, "upstream %v", item.Name))
+	}
+	return c.Upstreams.Create(item)
+}
+
+func (c *schemaUpstreams) Update(item *v1.Upstream) (*v1.Upstream, error) {
+	if err := ValidateUpstream(item); err != nil {
+		return nil, storage.NewInvalidErr(errors.Wrapf(err, "upstream %v", item.Name))
+	}
+	return c.Upstreams.Update(item)
+}
+
+// UpdateStatus writes the status without validating the spec, so invalid config objects can be reported
+func (c *schemaUpstreams) UpdateStatus(name string, status *v1.Status) (string, error) {
+	if statusWriter, ok := c.Upstreams.(storage.StatusWriter); ok {
+		return statusWriter.UpdateStatus(name, status)
+	}
+	us, err := c.Upstreams.Get(name)
+	if err != nil {
+		return "", err
+	}
+	us.Status = status
+	updated, err := c.Upstreams.Update(us)
+	if err != nil {
+		return "", err
+	}
+	return updated.GetMetadata().GetResourceVersion(), nil
+}
+
+type schemaVirtualHosts struct {
+	storage.VirtualHosts
+}
+
+func (c *schemaVirtualHosts) Create(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	if err := ValidateVirtualHost(item); err != nil {
+		return nil, storage.NewInvalidErr(errors.Wrapf(err, "virtual host %v", item.Name))
+	}
+	return c.VirtualHosts.Create(item)
+}
+
+func (c *schemaVirtualHosts) Update(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	if err := ValidateVirtualHost(item); err != nil {
+		return nil, storage.NewInvalidErr(errors.Wrapf(err, "virtual host %v", item.Name))
+	}
+	return c.VirtualHosts.Update(item)
+}
+
+// UpdateStatus writes the status without validating the spec, so invalid config objects can be reported
+func (c *schemaVirtualHosts) UpdateStatus(name string, status *v1.Status) (string, error) {
+	if statusWriter, ok := c.VirtualHosts.(storage.StatusWriter); ok {
+		return statusWriter.UpdateStatus(name, status)
+	}
+	vh, err := c.VirtualHosts.Get(name)
+	if err != nil {
+		return "", err
+	}
+	vh.Status = status
+	updated, err := c.VirtualHosts.Update(vh)
+	if err != nil {
+		return "", err
+	}
+	return updated.GetMetadata().GetResourceVersion(), nil
+}
@@ -92,7 +92,15 @@ func (w *secretWatcher) TrackSecrets(secretRefs []string) {
 		log.Warnf("failed to get updated secret list: %v", err)
 		return
 	}
-	w.syncSecrets(list, nil)
+	secrets := filterSecrets(toMap(list), secretRefs)
+	// the first read is sent even if none of the secrets exist, so the receiver knows the refs have been read
+	if w.lastSeen != nil {
+		if _, equal := messagediff.PrettyDiff(w.lastSeen, secrets); equal {
+			return
+		}
+	}
+	w.lastSeen = secrets
+	w.secrets <- secrets
 }
 
 func (w *secretWatcher) Secrets() <-chan SecretMap {
@@ -70,7 +70,7 @@ func (c *ConsulStorageClient) Update(item *StorableItem) (*StorableItem, error)
 	if success, _, err := c.consul.KV().CAS(updatedP, nil); err != nil {
 		return nil, errors.Wrapf(err, "writing kv pair %s", updatedP.Key)
 	} else if !success {
-		return nil, errors.Errorf("resource version was invalid for storageItem: %s", item.GetName())
+		return nil, storage.NewConflictErr(errors.Errorf("resource version was invalid for storageItem: %s", item.GetName()))
 	}
 
 	cfgObject, err := c.Get(item.GetName())
@@ -138,6 +138,7 @@ func (c *ConsulStorageClient) Watch(handlers ...StorableItemEventHandler) (*stor
 			virtualHosts []*v1.VirtualHost
 			upstreams    []*v1.Upstream
 			files        []*dependencies.File
+			secrets      []*dependencies.Secret
 		)
 		for _, p := range pairs {
 			item, err := itemFromKVPair(c.rootPath, p)
@@ -152,8 +153,10 @@ func (c *ConsulStorageClient) Watch(handlers ...StorableItemEventHandler) (*stor
 				virtualHosts = append(virtualHosts, item.VirtualHost)
 			case item.File != nil:
 				files = append(files, item.File)
+			case item.Secret != nil:
+				secrets = append(secrets, item.Secret)
 			default:
-				panic("virtual host, file or upstream must be set")
+				panic("virtual host, file, secret or upstream must be set")
 
 			}
 		}
@@ -172,6 +175,10 @@ func (c *ConsulStorageClient) Watch(handlers ...StorableItemEventHandler) (*stor
 			for _, h := range handlers {
 				h.FileEventHandler.OnUpdate(files, nil)
 			}
+		case len(secrets) > 0:
+			for _, h := range handlers {
+				h.SecretEventHandler.OnUpdate(secrets, nil)
+			}
 		}
 		return nil
 	}
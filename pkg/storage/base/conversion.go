@@ -1,6 +1,7 @@
 package base
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -41,28 +42,49 @@ func setResourceVersion(item *StorableItem, p *api.KVPair) {
 }
 
 func itemFromKVPair(rootPath string, p *api.KVPair) (*StorableItem, error) {
+	item, err := decodeItem(StorableItemType(p.Flags), strings.TrimPrefix(p.Key, rootPath+"/"), p.Value)
+	if err != nil {
+		return nil, err
+	}
+	setResourceVersion(item, p)
+	return item, nil
+}
+
+// decodeItem converts a stored value back into the item it was created from.
+// files and secrets are named by their key rather than by the value
+func decodeItem(itemType StorableItemType, name string, value []byte) (*StorableItem, error) {
 	item := &StorableItem{}
-	switch StorableItemType(p.Flags) {
+	switch itemType {
 	case StorableItemTypeUpstream:
 		var us v1.Upstream
-		err := proto.Unmarshal(p.Value, &us)
+		err := proto.Unmarshal(value, &us)
 		if err != nil {
 			return nil, errors.Wrap(err, "unmarshalling value as upstream")
 		}
 		item.Upstream = &us
 	case StorableItemTypeVirtualHost:
 		var vh v1.VirtualHost
-		err := proto.Unmarshal(p.Value, &vh)
+		err := proto.Unmarshal(value, &vh)
 		if err != nil {
 			return nil, errors.Wrap(err, "unmarshalling value as virtualhost")
 		}
 		item.VirtualHost = &vh
 	case StorableItemTypeFile:
 		item.File = &dependencies.File{
-			Ref:      strings.TrimPrefix(p.Key, rootPath+"/"),
-			Contents: p.Value,
+			Ref:      name,
+			Contents: value,
+		}
+	case StorableItemTypeSecret:
+		var data map[string]string
+		if err := json.Unmarshal(value, &data); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling value as secret")
 		}
+		item.Secret = &dependencies.Secret{
+			Ref:  name,
+			Data: data,
+		}
+	default:
+		return nil, errors.Errorf("unknown storable item type %v", itemType)
 	}
-	setResourceVersion(item, p)
 	return item, nil
 }
@@ -0,0 +1,233 @@
+package base
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+// time to wait before listing again after a list failed
+const retryInterval = time.Second
+
+// EtcdStorageClient stores items of a single type under rootPath.
+// the resource version of an item is the revision at which its key was last modified,
+// and updates only succeed if the key has not been modified since that revision
+type EtcdStorageClient struct {
+	rootPath string
+	itemType StorableItemType
+	etcd     *clientv3.Client
+}
+
+func NewEtcdStorageClient(rootPath string, itemType StorableItemType, etcd *clientv3.Client) *EtcdStorageClient {
+	return &EtcdStorageClient{
+		rootPath: rootPath,
+		itemType: itemType,
+		etcd:     etcd,
+	}
+}
+
+func (c *EtcdStorageClient) Create(item *StorableItem) (*StorableItem, error) {
+	data, err := item.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting bytes to store for %s", item.GetName())
+	}
+	k := key(c.rootPath, item.GetName())
+
+	// create the item only if the key has never been written (or was deleted)
+	resp, err := c.etcd.Txn(context.Background()).
+		If(clientv3.Compare(clientv3.CreateRevision(k), "=", 0)).
+		Then(clientv3.OpPut(k, string(data))).
+		Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "writing key %s", k)
+	}
+	if !resp.Succeeded {
+		return nil, storage.NewAlreadyExistsErr(
+			errors.Errorf("key found for storageItem %s: %s", item.GetName(), k))
+	}
+	return c.Get(item.GetName())
+}
+
+func (c *EtcdStorageClient) Update(item *StorableItem) (*StorableItem, error) {
+	data, err := item.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting bytes to store for %s", item.GetName())
+	}
+	k := key(c.rootPath, item.GetName())
+	var modRevision int64
+	if rv := item.GetResourceVersion(); rv != "" {
+		modRevision, err = strconv.ParseInt(rv, 10, 64)
+		if err != nil {
+			return nil, storage.NewConflictErr(errors.Errorf("resource version %q is invalid for storageItem: %s", rv, item.GetName()))
+		}
+	}
+
+	// update the item only if it was not modified since it was read
+	resp, err := c.etcd.Txn(context.Background()).
+		If(clientv3.Compare(clientv3.ModRevision(k), "=", modRevision)).
+		Then(clientv3.OpPut(k, string(data))).
+		Else(clientv3.OpGet(k, clientv3.WithCountOnly())).
+		Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "writing key %s", k)
+	}
+	if !resp.Succeeded {
+		if resp.Responses[0].GetResponseRange().Count == 0 {
+			return nil, errors.Errorf("key not found for storageItem %s: %s", item.GetName(), k)
+		}
+		return nil, storage.NewConflictErr(errors.Errorf("resource version was invalid for storageItem: %s", item.GetName()))
+	}
+	return c.Get(item.GetName())
+}
+
+func (c *EtcdStorageClient) Delete(name string) error {
+	k := key(c.rootPath, name)
+	if _, err := c.etcd.Delete(context.Background(), k); err != nil {
+		return errors.Wrapf(err, "deleting %s", name)
+	}
+	return nil
+}
+
+func (c *EtcdStorageClient) Get(name string) (*StorableItem, error) {
+	k := key(c.rootPath, name)
+	resp, err := c.etcd.Get(context.Background(), k)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting key %v", k)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, errors.Errorf("key %s not found for storageItem %s", k, name)
+	}
+	obj, err := c.itemFromKV(resp.Kvs[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "converting etcd key-value to storageItem")
+	}
+	return obj, nil
+}
+
+func (c *EtcdStorageClient) List() ([]*StorableItem, error) {
+	items, _, err := c.list()
+	return items, err
+}
+
+// list also returns the revision of the store the items were read at
+func (c *EtcdStorageClient) list() ([]*StorableItem, int64, error) {
+	resp, err := c.etcd.Get(context.Background(), c.rootPath+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "listing keys for root %s", c.rootPath)
+	}
+	var storageItems []*StorableItem
+	for _, kv := range resp.Kvs {
+		obj, err := c.itemFromKV(kv)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "converting %s to storageItem", kv.Key)
+		}
+		storageItems = append(storageItems, obj)
+	}
+	return storageItems, resp.Header.Revision, nil
+}
+
+func (c *EtcdStorageClient) itemFromKV(kv *mvccpb.KeyValue) (*StorableItem, error) {
+	item, err := decodeItem(c.itemType, strings.TrimPrefix(string(kv.Key), c.rootPath+"/"), kv.Value)
+	if err != nil {
+		return nil, err
+	}
+	item.SetResourceVersion(strconv.FormatInt(kv.ModRevision, 10))
+	return item, nil
+}
+
+// Watch calls OnUpdate with the full list of items once when it starts, and again after every change.
+// changes are watched from the revision of the first list, so none are missed between the list and the watch
+func (c *EtcdStorageClient) Watch(handlers ...StorableItemEventHandler) (*storage.Watcher, error) {
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-stop
+			cancel()
+		}()
+		for {
+			revision, err := c.sync(handlers)
+			if err != nil {
+				log.Warnf("error syncing with etcd keys: %v", err)
+				select {
+				case <-stop:
+					return
+				case <-time.After(retryInterval):
+					continue
+				}
+			}
+			// watch from the revision after the list, restarting with a fresh list if the watch fails
+			// (e.g. because the revision has been compacted)
+			watchCtx, cancelWatch := context.WithCancel(ctx)
+			for resp := range c.etcd.Watch(watchCtx, c.rootPath+"/", clientv3.WithPrefix(), clientv3.WithRev(revision+1)) {
+				if err := resp.Err(); err != nil {
+					log.Warnf("etcd watch on %s failed: %v", c.rootPath, err)
+					break
+				}
+				if _, err := c.sync(handlers); err != nil {
+					log.Warnf("error syncing with etcd keys: %v", err)
+				}
+			}
+			cancelWatch()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}), nil
+}
+
+func (c *EtcdStorageClient) sync(handlers []StorableItemEventHandler) (int64, error) {
+	items, revision, err := c.list()
+	if err != nil {
+		return 0, err
+	}
+	callHandlers(c.itemType, items, handlers)
+	return revision, nil
+}
+
+// callHandlers calls OnUpdate with the full list of items on the handler for itemType,
+// even if the list is empty
+func callHandlers(itemType StorableItemType, items []*StorableItem, handlers []StorableItemEventHandler) {
+	var (
+		virtualHosts []*v1.VirtualHost
+		upstreams    []*v1.Upstream
+		files        []*dependencies.File
+		secrets      []*dependencies.Secret
+	)
+	for _, item := range items {
+		switch {
+		case item.Upstream != nil:
+			upstreams = append(upstreams, item.Upstream)
+		case item.VirtualHost != nil:
+			virtualHosts = append(virtualHosts, item.VirtualHost)
+		case item.File != nil:
+			files = append(files, item.File)
+		case item.Secret != nil:
+			secrets = append(secrets, item.Secret)
+		}
+	}
+	for _, h := range handlers {
+		switch itemType {
+		case StorableItemTypeUpstream:
+			h.UpstreamEventHandler.OnUpdate(upstreams, nil)
+		case StorableItemTypeVirtualHost:
+			h.VirtualHostEventHandler.OnUpdate(virtualHosts, nil)
+		case StorableItemTypeFile:
+			h.FileEventHandler.OnUpdate(files, nil)
+		case StorableItemTypeSecret:
+			h.SecretEventHandler.OnUpdate(secrets, nil)
+		}
+	}
+}
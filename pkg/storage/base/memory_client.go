@@ -0,0 +1,179 @@
+package base
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+// MemoryStorageClient stores items of a single type in memory. it is safe for concurrent use.
+// items are stored encoded, so callers never share memory with the store.
+// the resource version of an item is the revision of the client at which the item was last written
+type MemoryStorageClient struct {
+	itemType StorableItemType
+
+	lock     sync.RWMutex
+	revision int64
+	items    map[string]memoryItem
+	// one channel per running watch, signalled after every write
+	watches map[chan struct{}]struct{}
+}
+
+type memoryItem struct {
+	data     []byte
+	revision int64
+}
+
+func NewMemoryStorageClient(itemType StorableItemType) *MemoryStorageClient {
+	return &MemoryStorageClient{
+		itemType: itemType,
+		items:    make(map[string]memoryItem),
+		watches:  make(map[chan struct{}]struct{}),
+	}
+}
+
+func (c *MemoryStorageClient) Create(item *StorableItem) (*StorableItem, error) {
+	data, err := item.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting bytes to store for %s", item.GetName())
+	}
+	name := item.GetName()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.items[name]; exists {
+		return nil, storage.NewAlreadyExistsErr(errors.Errorf("storageItem %s already exists", name))
+	}
+	return c.put(name, data)
+}
+
+func (c *MemoryStorageClient) Update(item *StorableItem) (*StorableItem, error) {
+	data, err := item.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting bytes to store for %s", item.GetName())
+	}
+	name := item.GetName()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	existing, exists := c.items[name]
+	if !exists {
+		return nil, errors.Errorf("storageItem %s not found", name)
+	}
+	if item.GetResourceVersion() != strconv.FormatInt(existing.revision, 10) {
+		return nil, storage.NewConflictErr(errors.Errorf("resource version was invalid for storageItem: %s", name))
+	}
+	return c.put(name, data)
+}
+
+// put must be called with the lock held
+func (c *MemoryStorageClient) put(name string, data []byte) (*StorableItem, error) {
+	c.revision++
+	stored := memoryItem{data: data, revision: c.revision}
+	c.items[name] = stored
+	c.notify()
+	return c.decode(name, stored)
+}
+
+func (c *MemoryStorageClient) Delete(name string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.items[name]; !exists {
+		return errors.Errorf("storageItem %s not found", name)
+	}
+	delete(c.items, name)
+	c.revision++
+	c.notify()
+	return nil
+}
+
+func (c *MemoryStorageClient) Get(name string) (*StorableItem, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	stored, exists := c.items[name]
+	if !exists {
+		return nil, errors.Errorf("storageItem %s not found", name)
+	}
+	return c.decode(name, stored)
+}
+
+// List returns the items sorted by name
+func (c *MemoryStorageClient) List() ([]*StorableItem, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var names []string
+	for name := range c.items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var storageItems []*StorableItem
+	for _, name := range names {
+		item, err := c.decode(name, c.items[name])
+		if err != nil {
+			return nil, err
+		}
+		storageItems = append(storageItems, item)
+	}
+	return storageItems, nil
+}
+
+func (c *MemoryStorageClient) decode(name string, stored memoryItem) (*StorableItem, error) {
+	item, err := decodeItem(c.itemType, name, stored.data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding storageItem %s", name)
+	}
+	item.SetResourceVersion(strconv.FormatInt(stored.revision, 10))
+	return item, nil
+}
+
+// notify must be called with the lock held. a watch that has not handled the last change yet
+// is already going to list again, so it is not signalled twice
+func (c *MemoryStorageClient) notify() {
+	for changed := range c.watches {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Watch calls OnUpdate with the full list of items once when it starts, and again after changes.
+// changes made while the handlers are running are delivered together in the next list
+func (c *MemoryStorageClient) Watch(handlers ...StorableItemEventHandler) (*storage.Watcher, error) {
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		changed := make(chan struct{}, 1)
+		c.lock.Lock()
+		c.watches[changed] = struct{}{}
+		c.lock.Unlock()
+		defer func() {
+			c.lock.Lock()
+			delete(c.watches, changed)
+			c.lock.Unlock()
+		}()
+
+		changed <- struct{}{}
+		for {
+			select {
+			case <-changed:
+				if err := c.sync(handlers); err != nil {
+					errs <- err
+				}
+			case <-stop:
+				return
+			}
+		}
+	}), nil
+}
+
+func (c *MemoryStorageClient) sync(handlers []StorableItemEventHandler) error {
+	items, err := c.List()
+	if err != nil {
+		return err
+	}
+	callHandlers(c.itemType, items, handlers)
+	return nil
+}
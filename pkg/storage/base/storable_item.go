@@ -1,6 +1,8 @@
 package base
 
 import (
+	"encoding/json"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 	storage "github.com/solo-io/gloo/pkg/storage"
@@ -11,6 +13,7 @@ type StorableItem struct {
 	Upstream    *v1.Upstream
 	VirtualHost *v1.VirtualHost
 	File        *dependencies.File
+	Secret      *dependencies.Secret
 }
 
 func (item *StorableItem) GetName() string {
@@ -21,8 +24,10 @@ func (item *StorableItem) GetName() string {
 		return item.VirtualHost.GetName()
 	case item.File != nil:
 		return item.File.Ref
+	case item.Secret != nil:
+		return item.Secret.Ref
 	default:
-		panic("virtual host, file or upstream must be set")
+		panic("virtual host, file, secret or upstream must be set")
 	}
 }
 
@@ -40,8 +45,10 @@ func (item *StorableItem) GetResourceVersion() string {
 		return item.VirtualHost.GetMetadata().GetResourceVersion()
 	case item.File != nil:
 		return item.File.ResourceVersion
+	case item.Secret != nil:
+		return item.Secret.ResourceVersion
 	default:
-		panic("virtual host, file or upstream must be set")
+		panic("virtual host, file, secret or upstream must be set")
 	}
 }
 
@@ -59,8 +66,10 @@ func (item *StorableItem) SetResourceVersion(rv string) {
 		item.VirtualHost.Metadata.ResourceVersion = rv
 	case item.File != nil:
 		item.File.ResourceVersion = rv
+	case item.Secret != nil:
+		item.Secret.ResourceVersion = rv
 	default:
-		panic("virtual host, file or upstream must be set")
+		panic("virtual host, file, secret or upstream must be set")
 	}
 }
 
@@ -72,8 +81,10 @@ func (item *StorableItem) GetBytes() ([]byte, error) {
 		return proto.Marshal(item.VirtualHost)
 	case item.File != nil:
 		return item.File.Contents, nil
+	case item.Secret != nil:
+		return json.Marshal(item.Secret.Data)
 	default:
-		panic("virtual host, file or upstream must be set")
+		panic("virtual host, file, secret or upstream must be set")
 	}
 }
 
@@ -85,8 +96,10 @@ func (item *StorableItem) GetTypeFlag() StorableItemType {
 		return StorableItemTypeVirtualHost
 	case item.File != nil:
 		return StorableItemTypeFile
+	case item.Secret != nil:
+		return StorableItemTypeSecret
 	default:
-		panic("virtual host, file or upstream must be set")
+		panic("virtual host, file, secret or upstream must be set")
 	}
 }
 
@@ -96,10 +109,12 @@ const (
 	StorableItemTypeUpstream StorableItemType = iota
 	StorableItemTypeVirtualHost
 	StorableItemTypeFile
+	StorableItemTypeSecret
 )
 
 type StorableItemEventHandler struct {
 	UpstreamEventHandler    storage.UpstreamEventHandler
 	VirtualHostEventHandler storage.VirtualHostEventHandler
 	FileEventHandler        dependencies.FileEventHandler
+	SecretEventHandler      dependencies.SecretEventHandler
 }
@@ -0,0 +1,143 @@
+package composite
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+// Backend is one of the storage backends read by the composite storage client
+type Backend struct {
+	// Name identifies the backend in errors and in the statuses of conflicting config objects,
+	// e.g. the storage type of the backend
+	Name    string
+	Storage storage.Interface
+}
+
+// Client reads config objects from several storage backends as if they were one.
+// backends are listed in order of precedence: if config objects of the same kind and name are
+// read from more than one backend, the one in the earliest backend is used, and the others
+// are rejected with a status naming the backend that takes precedence.
+// new config objects are created in the write target for their kind. existing config objects are
+// updated, deleted and have their status written in the backend they are read from
+type Client struct {
+	v1 *v1client
+}
+
+// NewStorage creates a composite storage client for the backends, in order of precedence.
+// upstreamsTarget and virtualHostsTarget name the backends new config objects of each kind are created in.
+// an empty target is the first backend
+func NewStorage(backends []Backend, upstreamsTarget, virtualHostsTarget string) (*Client, error) {
+	if len(backends) == 0 {
+		return nil, errors.Errorf("composite storage requires at least one backend")
+	}
+	names := make(map[string]bool)
+	for _, b := range backends {
+		if b.Storage == nil {
+			return nil, errors.Errorf("no storage given for backend %v", b.Name)
+		}
+		if names[b.Name] {
+			return nil, errors.Errorf("backend %v is given more than once", b.Name)
+		}
+		names[b.Name] = true
+	}
+	upstreamsIndex, err := targetIndex(backends, upstreamsTarget)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid write target for upstreams")
+	}
+	virtualHostsIndex, err := targetIndex(backends, virtualHostsTarget)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid write target for virtual hosts")
+	}
+	upstreams := &upstreamsClient{target: upstreamsIndex}
+	virtualHosts := &virtualHostsClient{target: virtualHostsIndex}
+	for _, b := range backends {
+		upstreams.backends = append(upstreams.backends, upstreamsBackend{name: b.Name, client: b.Storage.V1().Upstreams()})
+		virtualHosts.backends = append(virtualHosts.backends, virtualHostsBackend{name: b.Name, client: b.Storage.V1().VirtualHosts()})
+	}
+	return &Client{
+		v1: &v1client{
+			backends:     backends,
+			upstreams:    upstreams,
+			virtualHosts: virtualHosts,
+		},
+	}, nil
+}
+
+func targetIndex(backends []Backend, target string) (int, error) {
+	if target == "" {
+		return 0, nil
+	}
+	for i, b := range backends {
+		if b.Name == target {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("%v is not one of the backends", target)
+}
+
+func (c *Client) V1() storage.V1 {
+	return c.v1
+}
+
+type v1client struct {
+	backends     []Backend
+	upstreams    *upstreamsClient
+	virtualHosts *virtualHostsClient
+}
+
+func (c *v1client) Register() error {
+	for _, b := range c.backends {
+		if err := b.Storage.V1().Register(); err != nil {
+			return errors.Wrapf(err, "registering %v storage", b.Name)
+		}
+	}
+	return nil
+}
+
+func (c *v1client) Upstreams() storage.Upstreams {
+	return c.upstreams
+}
+
+func (c *v1client) VirtualHosts() storage.VirtualHosts {
+	return c.virtualHosts
+}
+
+// conflictReason is the reason a config object read from one backend is rejected
+// because another backend has one of the same kind and name
+func conflictReason(kind, name, backend string) string {
+	return fmt.Sprintf("%v %v is also defined in %v storage, which takes precedence", kind, name, backend)
+}
+
+// needsConflictStatus returns true if the status does not yet report the conflict
+func needsConflictStatus(status *v1.Status, reason string) bool {
+	return status.GetState() != v1.Status_Rejected || status.GetReason() != reason
+}
+
+func allSynced(synced []bool) bool {
+	for _, s := range synced {
+		if !s {
+			return false
+		}
+	}
+	return true
+}
+
+// runAll returns a watcher that runs all of the watchers until they are stopped
+func runAll(watchers []*storage.Watcher) *storage.Watcher {
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		var wg sync.WaitGroup
+		for _, watcher := range watchers {
+			wg.Add(1)
+			go func(watcher *storage.Watcher) {
+				defer wg.Done()
+				watcher.Run(stop, errs)
+			}(watcher)
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,182 @@
+package composite_test
+
+import (
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	. "github.com/solo-io/gloo/pkg/storage/composite"
+	"github.com/solo-io/gloo/pkg/storage/memory"
+)
+
+var _ = Describe("CompositeStorageClient", func() {
+	var (
+		kube, file storage.Interface
+		client     storage.Interface
+	)
+	BeforeEach(func() {
+		kube = memory.NewStorage()
+		file = memory.NewStorage()
+		var err error
+		client, err = NewStorage([]Backend{
+			{Name: "kube", Storage: kube},
+			{Name: "file", Storage: file},
+		}, "file", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("requires the write targets to be backends", func() {
+		_, err := NewStorage([]Backend{{Name: "kube", Storage: kube}}, "consul", "")
+		Expect(err).To(HaveOccurred())
+	})
+	Describe("Upstreams", func() {
+		It("creates config objects in the write target for their kind", func() {
+			_, err := client.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.V1().Upstreams().Get("myupstream")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = kube.V1().Upstreams().Get("myupstream")
+			Expect(err).To(HaveOccurred())
+
+			_, err = client.V1().VirtualHosts().Create(&v1.VirtualHost{Name: "myvirtualhost"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = kube.V1().VirtualHosts().Get("myvirtualhost")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("does not create a config object that exists in another backend", func() {
+			_, err := kube.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "bar"})
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsAlreadyExists(err)).To(BeTrue())
+		})
+		It("updates and deletes config objects in the backend they are read from", func() {
+			us, err := kube.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			us.Type = "bar"
+			_, err = client.V1().Upstreams().Update(us)
+			Expect(err).NotTo(HaveOccurred())
+			out, err := kube.V1().Upstreams().Get("myupstream")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Type).To(Equal("bar"))
+
+			err = client.V1().Upstreams().Delete("myupstream")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = kube.V1().Upstreams().Get("myupstream")
+			Expect(err).To(HaveOccurred())
+		})
+		It("lists the config objects of every backend, the earlier backend taking precedence", func() {
+			fromKube, err := kube.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "kube"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "file"})
+			Expect(err).NotTo(HaveOccurred())
+			fromFile, err := file.V1().Upstreams().Create(&v1.Upstream{Name: "another", Type: "file"})
+			Expect(err).NotTo(HaveOccurred())
+
+			list, err := client.V1().Upstreams().List(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(Equal([]*v1.Upstream{fromFile, fromKube}))
+
+			out, err := client.V1().Upstreams().Get("shared")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(fromKube))
+		})
+		It("watches the merged list and rejects conflicting config objects", func() {
+			lists := make(chan []*v1.Upstream, 10)
+			stop := make(chan struct{})
+			defer close(stop)
+			w, err := client.V1().Upstreams().Watch(nil, &storage.UpstreamEventHandlerFuncs{
+				UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+					lists <- updatedList
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go w.Run(stop, make(chan error))
+			Eventually(lists).Should(Receive(BeEmpty()))
+
+			fromFile, err := file.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "file"})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*v1.Upstream{fromFile})))
+
+			fromKube, err := kube.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "kube"})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*v1.Upstream{fromKube})))
+
+			Eventually(func() (*v1.Status, error) {
+				us, err := file.V1().Upstreams().Get("shared")
+				return us.GetStatus(), err
+			}).Should(Equal(&v1.Status{
+				State:  v1.Status_Rejected,
+				Reason: "upstream shared is also defined in kube storage, which takes precedence",
+			}))
+		})
+		It("does not rewrite a conflict status the backend already holds", func() {
+			counting := &countingUpstreams{Upstreams: file.V1().Upstreams()}
+			var err error
+			client, err = NewStorage([]Backend{
+				{Name: "kube", Storage: kube},
+				{Name: "file", Storage: &countingStorage{v1: &countingV1{V1: file.V1(), upstreams: counting}}},
+			}, "file", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			lists := make(chan []*v1.Upstream, 10)
+			stop := make(chan struct{})
+			defer close(stop)
+			w, err := client.V1().Upstreams().Watch(nil, &storage.UpstreamEventHandlerFuncs{
+				UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+					lists <- updatedList
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go w.Run(stop, make(chan error))
+			Eventually(lists).Should(Receive(BeEmpty()))
+
+			_, err = file.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "file"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = kube.V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "kube"})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(counting.writes).Should(Equal(int32(1)))
+
+			// every later event merges the lists again and finds the same conflict
+			for i, name := range []string{"a", "b", "c"} {
+				_, err = file.V1().Upstreams().Create(&v1.Upstream{Name: name, Type: "file"})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(lists).Should(Receive(HaveLen(i + 2)))
+			}
+			Consistently(counting.writes).Should(Equal(int32(1)))
+		})
+	})
+})
+
+// countingUpstreams counts the statuses written through it
+type countingUpstreams struct {
+	storage.Upstreams
+	count int32
+}
+
+func (c *countingUpstreams) UpdateStatus(name string, status *v1.Status) (string, error) {
+	atomic.AddInt32(&c.count, 1)
+	return c.Upstreams.(storage.StatusWriter).UpdateStatus(name, status)
+}
+
+func (c *countingUpstreams) writes() int32 {
+	return atomic.LoadInt32(&c.count)
+}
+
+type countingStorage struct {
+	v1 *countingV1
+}
+
+func (s *countingStorage) V1() storage.V1 {
+	return s.v1
+}
+
+type countingV1 struct {
+	storage.V1
+	upstreams *countingUpstreams
+}
+
+func (v *countingV1) Upstreams() storage.Upstreams {
+	return v.upstreams
+}
@@ -0,0 +1,239 @@
+package composite
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+type upstreamsBackend struct {
+	name   string
+	client storage.Upstreams
+}
+
+type upstreamsClient struct {
+	backends []upstreamsBackend
+	// index of the backend new upstreams are created in
+	target int
+}
+
+// owner returns the index of the first backend that has the upstream, and the upstream
+func (c *upstreamsClient) owner(name string) (int, *v1.Upstream, error) {
+	var errs error
+	for i, b := range c.backends {
+		us, err := b.client.Get(name)
+		if err == nil {
+			return i, us, nil
+		}
+		errs = multierror.Append(errs, errors.Wrapf(err, "%v storage", b.name))
+	}
+	return 0, nil, errors.Wrapf(errs, "upstream %v not found", name)
+}
+
+func (c *upstreamsClient) Create(item *v1.Upstream) (*v1.Upstream, error) {
+	for i, b := range c.backends {
+		if i == c.target {
+			continue
+		}
+		if _, err := b.client.Get(item.Name); err == nil {
+			return nil, storage.NewAlreadyExistsErr(errors.Errorf("upstream %v is defined in %v storage", item.Name, b.name))
+		}
+	}
+	return c.backends[c.target].client.Create(item)
+}
+
+func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
+	i, _, err := c.owner(item.Name)
+	if err != nil {
+		return nil, err
+	}
+	return c.backends[i].client.Update(item)
+}
+
+func (c *upstreamsClient) Delete(name string) error {
+	i, _, err := c.owner(name)
+	if err != nil {
+		return err
+	}
+	return c.backends[i].client.Delete(name)
+}
+
+func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
+	_, us, err := c.owner(name)
+	return us, err
+}
+
+// UpdateStatus writes the status in the backend the upstream is read from
+func (c *upstreamsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	i, us, err := c.owner(name)
+	if err != nil {
+		return "", err
+	}
+	return c.writeStatus(i, us, status)
+}
+
+// writeStatus writes the status of the upstream in the given backend.
+// backends that cannot write a status on its own have the whole upstream updated
+func (c *upstreamsClient) writeStatus(backend int, us *v1.Upstream, status *v1.Status) (string, error) {
+	client := c.backends[backend].client
+	if statusWriter, ok := client.(storage.StatusWriter); ok {
+		return statusWriter.UpdateStatus(us.Name, status)
+	}
+	us.Status = status
+	updated, err := client.Update(us)
+	if err != nil {
+		return "", err
+	}
+	return updated.GetMetadata().GetResourceVersion(), nil
+}
+
+// storedStatus returns the status the backend holds for the upstream. backends that write statuses on their own
+// do not deliver them with the upstreams they watch, so the status is read back from the backend
+func (c *upstreamsClient) storedStatus(backend int, us *v1.Upstream) (*v1.Status, error) {
+	client := c.backends[backend].client
+	if _, ok := client.(storage.StatusWriter); !ok {
+		return us.Status, nil
+	}
+	stored, err := client.Get(us.Name)
+	if err != nil {
+		return nil, err
+	}
+	return stored.Status, nil
+}
+
+// List returns the selected upstreams of all backends. conflicts are resolved by
+// the selectors being applied after merging, so an upstream always hides the
+// upstreams of the same name in later backends, whether or not it is selected
+func (c *upstreamsClient) List(opts *storage.ListOptions) ([]*v1.Upstream, error) {
+	lists := make([][]*v1.Upstream, len(c.backends))
+	for i, b := range c.backends {
+		list, err := b.client.List(nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list upstreams in %v storage", b.name)
+		}
+		lists[i] = list
+	}
+	merged, _ := c.merge(lists)
+	return storage.SelectUpstreams(opts, merged), nil
+}
+
+// shadowedUpstream is an upstream hidden by one of the same name in a backend that takes precedence
+type shadowedUpstream struct {
+	backend  int
+	upstream *v1.Upstream
+	reason   string
+}
+
+// merge returns the upstreams of the backends' lists that are not shadowed, sorted by name, and those that are
+func (c *upstreamsClient) merge(lists [][]*v1.Upstream) ([]*v1.Upstream, []shadowedUpstream) {
+	owners := make(map[string]int)
+	var (
+		merged   []*v1.Upstream
+		shadowed []shadowedUpstream
+	)
+	for i, list := range lists {
+		for _, us := range list {
+			owner, ok := owners[us.Name]
+			if !ok {
+				owners[us.Name] = i
+				merged = append(merged, us)
+				continue
+			}
+			if owner != i {
+				shadowed = append(shadowed, shadowedUpstream{
+					backend:  i,
+					upstream: us,
+					reason:   conflictReason("upstream", us.Name, c.backends[owner].name),
+				})
+			}
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+	return merged, shadowed
+}
+
+// reportConflicts rejects the shadowed upstreams, unless their status already reports the conflict
+func (c *upstreamsClient) reportConflicts(shadowed []shadowedUpstream) {
+	for _, s := range shadowed {
+		current, err := c.storedStatus(s.backend, s.upstream)
+		if err != nil {
+			log.Warnf("failed to read the status of upstream %v in %v storage: %v", s.upstream.Name, c.backends[s.backend].name, err)
+			continue
+		}
+		if !needsConflictStatus(current, s.reason) {
+			continue
+		}
+		status := &v1.Status{State: v1.Status_Rejected, Reason: s.reason}
+		if _, err := c.writeStatus(s.backend, s.upstream, status); err != nil {
+			log.Warnf("failed to report conflict for upstream %v in %v storage: %v", s.upstream.Name, c.backends[s.backend].name, err)
+		}
+	}
+}
+
+// Watch gives the handlers the merged list each time the upstreams of any backend change,
+// once every backend has delivered its first list
+func (c *upstreamsClient) Watch(opts *storage.ListOptions, handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
+	merger := &upstreamsMerger{
+		client:   c,
+		opts:     opts,
+		handlers: handlers,
+		lists:    make([][]*v1.Upstream, len(c.backends)),
+		synced:   make([]bool, len(c.backends)),
+	}
+	var watchers []*storage.Watcher
+	for i, b := range c.backends {
+		backend := i
+		onChange := func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+			merger.update(backend, updatedList)
+		}
+		watcher, err := b.client.Watch(nil, &storage.UpstreamEventHandlerFuncs{
+			AddFunc:    onChange,
+			UpdateFunc: onChange,
+			DeleteFunc: onChange,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to watch upstreams in %v storage", b.name)
+		}
+		watchers = append(watchers, watcher)
+	}
+	return runAll(watchers), nil
+}
+
+type upstreamsMerger struct {
+	client   *upstreamsClient
+	opts     *storage.ListOptions
+	handlers []storage.UpstreamEventHandler
+
+	lock sync.Mutex
+	// the latest list delivered by each backend
+	lists  [][]*v1.Upstream
+	synced []bool
+}
+
+func (m *upstreamsMerger) update(backend int, updatedList []*v1.Upstream) {
+	m.lock.Lock()
+	m.lists[backend] = updatedList
+	m.synced[backend] = true
+	if !allSynced(m.synced) {
+		m.lock.Unlock()
+		return
+	}
+	merged, shadowed := m.client.merge(m.lists)
+	selected := storage.SelectUpstreams(m.opts, merged)
+	// the handlers are called with the lock held, so they never get an older merged list after a newer one
+	for _, h := range m.handlers {
+		h.OnUpdate(selected, nil)
+	}
+	m.lock.Unlock()
+
+	// written without the lock, as the write triggers another event from the backend
+	m.client.reportConflicts(shadowed)
+}
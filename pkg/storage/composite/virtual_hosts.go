@@ -0,0 +1,239 @@
+package composite
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+type virtualHostsBackend struct {
+	name   string
+	client storage.VirtualHosts
+}
+
+type virtualHostsClient struct {
+	backends []virtualHostsBackend
+	// index of the backend new virtual hosts are created in
+	target int
+}
+
+// owner returns the index of the first backend that has the virtual host, and the virtual host
+func (c *virtualHostsClient) owner(name string) (int, *v1.VirtualHost, error) {
+	var errs error
+	for i, b := range c.backends {
+		vh, err := b.client.Get(name)
+		if err == nil {
+			return i, vh, nil
+		}
+		errs = multierror.Append(errs, errors.Wrapf(err, "%v storage", b.name))
+	}
+	return 0, nil, errors.Wrapf(errs, "virtual host %v not found", name)
+}
+
+func (c *virtualHostsClient) Create(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	for i, b := range c.backends {
+		if i == c.target {
+			continue
+		}
+		if _, err := b.client.Get(item.Name); err == nil {
+			return nil, storage.NewAlreadyExistsErr(errors.Errorf("virtual host %v is defined in %v storage", item.Name, b.name))
+		}
+	}
+	return c.backends[c.target].client.Create(item)
+}
+
+func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	i, _, err := c.owner(item.Name)
+	if err != nil {
+		return nil, err
+	}
+	return c.backends[i].client.Update(item)
+}
+
+func (c *virtualHostsClient) Delete(name string) error {
+	i, _, err := c.owner(name)
+	if err != nil {
+		return err
+	}
+	return c.backends[i].client.Delete(name)
+}
+
+func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
+	_, vh, err := c.owner(name)
+	return vh, err
+}
+
+// UpdateStatus writes the status in the backend the virtual host is read from
+func (c *virtualHostsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	i, vh, err := c.owner(name)
+	if err != nil {
+		return "", err
+	}
+	return c.writeStatus(i, vh, status)
+}
+
+// writeStatus writes the status of the virtual host in the given backend.
+// backends that cannot write a status on its own have the whole virtual host updated
+func (c *virtualHostsClient) writeStatus(backend int, vh *v1.VirtualHost, status *v1.Status) (string, error) {
+	client := c.backends[backend].client
+	if statusWriter, ok := client.(storage.StatusWriter); ok {
+		return statusWriter.UpdateStatus(vh.Name, status)
+	}
+	vh.Status = status
+	updated, err := client.Update(vh)
+	if err != nil {
+		return "", err
+	}
+	return updated.GetMetadata().GetResourceVersion(), nil
+}
+
+// storedStatus returns the status the backend holds for the virtual host. backends that write statuses on their own
+// do not deliver them with the virtual hosts they watch, so the status is read back from the backend
+func (c *virtualHostsClient) storedStatus(backend int, vh *v1.VirtualHost) (*v1.Status, error) {
+	client := c.backends[backend].client
+	if _, ok := client.(storage.StatusWriter); !ok {
+		return vh.Status, nil
+	}
+	stored, err := client.Get(vh.Name)
+	if err != nil {
+		return nil, err
+	}
+	return stored.Status, nil
+}
+
+// List returns the selected virtual hosts of all backends. conflicts are resolved by
+// the selectors being applied after merging, so a virtual host always hides the
+// virtual hosts of the same name in later backends, whether or not it is selected
+func (c *virtualHostsClient) List(opts *storage.ListOptions) ([]*v1.VirtualHost, error) {
+	lists := make([][]*v1.VirtualHost, len(c.backends))
+	for i, b := range c.backends {
+		list, err := b.client.List(nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list virtual hosts in %v storage", b.name)
+		}
+		lists[i] = list
+	}
+	merged, _ := c.merge(lists)
+	return storage.SelectVirtualHosts(opts, merged), nil
+}
+
+// shadowedVirtualHost is a virtual host hidden by one of the same name in a backend that takes precedence
+type shadowedVirtualHost struct {
+	backend     int
+	virtualHost *v1.VirtualHost
+	reason      string
+}
+
+// merge returns the virtual hosts of the backends' lists that are not shadowed, sorted by name, and those that are
+func (c *virtualHostsClient) merge(lists [][]*v1.VirtualHost) ([]*v1.VirtualHost, []shadowedVirtualHost) {
+	owners := make(map[string]int)
+	var (
+		merged   []*v1.VirtualHost
+		shadowed []shadowedVirtualHost
+	)
+	for i, list := range lists {
+		for _, vh := range list {
+			owner, ok := owners[vh.Name]
+			if !ok {
+				owners[vh.Name] = i
+				merged = append(merged, vh)
+				continue
+			}
+			if owner != i {
+				shadowed = append(shadowed, shadowedVirtualHost{
+					backend:     i,
+					virtualHost: vh,
+					reason:      conflictReason("virtual host", vh.Name, c.backends[owner].name),
+				})
+			}
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+	return merged, shadowed
+}
+
+// reportConflicts rejects the shadowed virtual hosts, unless their status already reports the conflict
+func (c *virtualHostsClient) reportConflicts(shadowed []shadowedVirtualHost) {
+	for _, s := range shadowed {
+		current, err := c.storedStatus(s.backend, s.virtualHost)
+		if err != nil {
+			log.Warnf("failed to read the status of virtual host %v in %v storage: %v", s.virtualHost.Name, c.backends[s.backend].name, err)
+			continue
+		}
+		if !needsConflictStatus(current, s.reason) {
+			continue
+		}
+		status := &v1.Status{State: v1.Status_Rejected, Reason: s.reason}
+		if _, err := c.writeStatus(s.backend, s.virtualHost, status); err != nil {
+			log.Warnf("failed to report conflict for virtual host %v in %v storage: %v", s.virtualHost.Name, c.backends[s.backend].name, err)
+		}
+	}
+}
+
+// Watch gives the handlers the merged list each time the virtual hosts of any backend change,
+// once every backend has delivered its first list
+func (c *virtualHostsClient) Watch(opts *storage.ListOptions, handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+	merger := &virtualHostsMerger{
+		client:   c,
+		opts:     opts,
+		handlers: handlers,
+		lists:    make([][]*v1.VirtualHost, len(c.backends)),
+		synced:   make([]bool, len(c.backends)),
+	}
+	var watchers []*storage.Watcher
+	for i, b := range c.backends {
+		backend := i
+		onChange := func(updatedList []*v1.VirtualHost, _ *v1.VirtualHost) {
+			merger.update(backend, updatedList)
+		}
+		watcher, err := b.client.Watch(nil, &storage.VirtualHostEventHandlerFuncs{
+			AddFunc:    onChange,
+			UpdateFunc: onChange,
+			DeleteFunc: onChange,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to watch virtual hosts in %v storage", b.name)
+		}
+		watchers = append(watchers, watcher)
+	}
+	return runAll(watchers), nil
+}
+
+type virtualHostsMerger struct {
+	client   *virtualHostsClient
+	opts     *storage.ListOptions
+	handlers []storage.VirtualHostEventHandler
+
+	lock sync.Mutex
+	// the latest list delivered by each backend
+	lists  [][]*v1.VirtualHost
+	synced []bool
+}
+
+func (m *virtualHostsMerger) update(backend int, updatedList []*v1.VirtualHost) {
+	m.lock.Lock()
+	m.lists[backend] = updatedList
+	m.synced[backend] = true
+	if !allSynced(m.synced) {
+		m.lock.Unlock()
+		return
+	}
+	merged, shadowed := m.client.merge(m.lists)
+	selected := storage.SelectVirtualHosts(m.opts, merged)
+	// the handlers are called with the lock held, so they never get an older merged list after a newer one
+	for _, h := range m.handlers {
+		h.OnUpdate(selected, nil)
+	}
+	m.lock.Unlock()
+
+	// written without the lock, as the write triggers another event from the backend
+	m.client.reportConflicts(shadowed)
+}
@@ -26,10 +26,12 @@ func NewStorage(cfg *api.Config, rootPath string, syncFrequency time.Duration) (
 	return &Client{
 		v1: &v1client{
 			upstreams: &upstreamsClient{
-				base: base.NewConsulStorageClient(rootPath+"/upstreams", client),
+				base:     base.NewConsulStorageClient(rootPath+"/upstreams", client),
+				statuses: &statusClient{rootPath: rootPath + "/status/upstreams", consul: client},
 			},
 			virtualHosts: &virtualHostsClient{
-				base: base.NewConsulStorageClient(rootPath+"/virtualhosts", client),
+				base:     base.NewConsulStorageClient(rootPath+"/virtualhosts", client),
+				statuses: &statusClient{rootPath: rootPath + "/status/virtualhosts", consul: client},
 			},
 		},
 	}, nil
@@ -161,7 +161,7 @@ var _ = Describe("ConsulStorageClient", func() {
 						Expect(err).NotTo(HaveOccurred())
 						us3, err := client.V1().Upstreams().Create(input3)
 						Expect(err).NotTo(HaveOccurred())
-						out, err := client.V1().Upstreams().List()
+						out, err := client.V1().Upstreams().List(nil)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(out).To(ContainElement(us1))
 						Expect(out).To(ContainElement(us2))
@@ -176,7 +176,7 @@ var _ = Describe("ConsulStorageClient", func() {
 						stop := make(chan struct{})
 						defer close(stop)
 						errs := make(chan error)
-						w, err := client.V1().Upstreams().Watch(&storage.UpstreamEventHandlerFuncs{
+						w, err := client.V1().Upstreams().Watch(nil, &storage.UpstreamEventHandlerFuncs{
 							UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
 								lists <- updatedList
 							},
@@ -352,7 +352,7 @@ var _ = Describe("ConsulStorageClient", func() {
 						time.Sleep(time.Second)
 						vh3, err := client.V1().VirtualHosts().Create(input3)
 						Expect(err).NotTo(HaveOccurred())
-						out, err := client.V1().VirtualHosts().List()
+						out, err := client.V1().VirtualHosts().List(nil)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(out).To(ContainElement(vh1))
 						Expect(out).To(ContainElement(vh2))
@@ -367,7 +367,7 @@ var _ = Describe("ConsulStorageClient", func() {
 						stop := make(chan struct{})
 						defer close(stop)
 						errs := make(chan error)
-						w, err := client.V1().VirtualHosts().Watch(&storage.VirtualHostEventHandlerFuncs{
+						w, err := client.V1().VirtualHosts().Watch(nil, &storage.VirtualHostEventHandlerFuncs{
 							UpdateFunc: func(updatedList []*v1.VirtualHost, _ *v1.VirtualHost) {
 								lists <- updatedList
 							},
@@ -0,0 +1,72 @@
+package consul
+
+import (
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+// statusClient keeps the status of each item under a root of its own,
+// so writing a status neither changes the modify index of the item nor triggers watches on the items
+type statusClient struct {
+	rootPath string
+	consul   *api.Client
+}
+
+func (c *statusClient) key(name string) string {
+	return c.rootPath + "/" + name
+}
+
+// returns nil if no status was written for the item
+func (c *statusClient) get(name string) (*v1.Status, error) {
+	p, _, err := c.consul.KV().Get(c.key(name), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting status for %v", name)
+	}
+	if p == nil {
+		return nil, nil
+	}
+	var status v1.Status
+	if err := protoutil.Unmarshal(p.Value, &status); err != nil {
+		return nil, errors.Wrapf(err, "unmarshalling status for %v", name)
+	}
+	return &status, nil
+}
+
+func (c *statusClient) list() (map[string]*v1.Status, error) {
+	pairs, _, err := c.consul.KV().List(c.rootPath+"/", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing statuses")
+	}
+	statuses := make(map[string]*v1.Status)
+	for _, p := range pairs {
+		var status v1.Status
+		if err := protoutil.Unmarshal(p.Value, &status); err != nil {
+			return nil, errors.Wrapf(err, "unmarshalling status %v", p.Key)
+		}
+		statuses[strings.TrimPrefix(p.Key, c.rootPath+"/")] = &status
+	}
+	return statuses, nil
+}
+
+func (c *statusClient) put(name string, status *v1.Status) error {
+	data, err := protoutil.Marshal(status)
+	if err != nil {
+		return errors.Wrapf(err, "marshalling status for %v", name)
+	}
+	if _, err := c.consul.KV().Put(&api.KVPair{Key: c.key(name), Value: data}, nil); err != nil {
+		return errors.Wrapf(err, "writing status for %v", name)
+	}
+	return nil
+}
+
+func (c *statusClient) delete(name string) error {
+	if _, err := c.consul.KV().Delete(c.key(name), nil); err != nil {
+		return errors.Wrapf(err, "deleting status for %v", name)
+	}
+	return nil
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type upstreamsClient struct {
-	base *base.ConsulStorageClient
+	base     *base.ConsulStorageClient
+	statuses *statusClient
 }
 
 func (c *upstreamsClient) Create(item *v1.Upstream) (*v1.Upstream, error) {
@@ -27,7 +28,21 @@ func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
 }
 
 func (c *upstreamsClient) Delete(name string) error {
-	return c.base.Delete(name)
+	if err := c.base.Delete(name); err != nil {
+		return err
+	}
+	return c.statuses.delete(name)
+}
+
+func (c *upstreamsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if err := c.statuses.put(name, status); err != nil {
+		return "", err
+	}
+	return out.GetResourceVersion(), nil
 }
 
 func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
@@ -35,25 +50,42 @@ func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
 	if err != nil {
 		return nil, err
 	}
+	status, err := c.statuses.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		out.Upstream.Status = status
+	}
 	return out.Upstream, nil
 }
 
-func (c *upstreamsClient) List() ([]*v1.Upstream, error) {
+func (c *upstreamsClient) List(opts *storage.ListOptions) ([]*v1.Upstream, error) {
 	list, err := c.base.List()
 	if err != nil {
 		return nil, err
 	}
+	statuses, err := c.statuses.list()
+	if err != nil {
+		return nil, err
+	}
 	var upstreams []*v1.Upstream
 	for _, obj := range list {
+		if !opts.Matches(obj.Upstream.GetMetadata().GetLabels()) {
+			continue
+		}
+		if status, ok := statuses[obj.GetName()]; ok {
+			obj.Upstream.Status = status
+		}
 		upstreams = append(upstreams, obj.Upstream)
 	}
 	return upstreams, nil
 }
 
-func (c *upstreamsClient) Watch(handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
+func (c *upstreamsClient) Watch(opts *storage.ListOptions, handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
 	var baseHandlers []base.StorableItemEventHandler
 	for _, h := range handlers {
-		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{UpstreamEventHandler: h})
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{UpstreamEventHandler: storage.UpstreamSelector(opts, h)})
 	}
 	return c.base.Watch(baseHandlers...)
 }
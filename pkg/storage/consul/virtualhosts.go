@@ -7,7 +7,8 @@ import (
 )
 
 type virtualHostsClient struct {
-	base *base.ConsulStorageClient
+	base     *base.ConsulStorageClient
+	statuses *statusClient
 }
 
 func (c *virtualHostsClient) Create(item *v1.VirtualHost) (*v1.VirtualHost, error) {
@@ -27,7 +28,21 @@ func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, erro
 }
 
 func (c *virtualHostsClient) Delete(name string) error {
-	return c.base.Delete(name)
+	if err := c.base.Delete(name); err != nil {
+		return err
+	}
+	return c.statuses.delete(name)
+}
+
+func (c *virtualHostsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if err := c.statuses.put(name, status); err != nil {
+		return "", err
+	}
+	return out.GetResourceVersion(), nil
 }
 
 func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
@@ -35,25 +50,42 @@ func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
 	if err != nil {
 		return nil, err
 	}
+	status, err := c.statuses.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		out.VirtualHost.Status = status
+	}
 	return out.VirtualHost, nil
 }
 
-func (c *virtualHostsClient) List() ([]*v1.VirtualHost, error) {
+func (c *virtualHostsClient) List(opts *storage.ListOptions) ([]*v1.VirtualHost, error) {
 	list, err := c.base.List()
 	if err != nil {
 		return nil, err
 	}
+	statuses, err := c.statuses.list()
+	if err != nil {
+		return nil, err
+	}
 	var virtualHosts []*v1.VirtualHost
 	for _, obj := range list {
+		if !opts.Matches(obj.VirtualHost.GetMetadata().GetLabels()) {
+			continue
+		}
+		if status, ok := statuses[obj.GetName()]; ok {
+			obj.VirtualHost.Status = status
+		}
 		virtualHosts = append(virtualHosts, obj.VirtualHost)
 	}
 	return virtualHosts, nil
 }
 
-func (c *virtualHostsClient) Watch(handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+func (c *virtualHostsClient) Watch(opts *storage.ListOptions, handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
 	var baseHandlers []base.StorableItemEventHandler
 	for _, h := range handlers {
-		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{VirtualHostEventHandler: h})
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{VirtualHostEventHandler: storage.VirtualHostSelector(opts, h)})
 	}
 	return c.base.Watch(baseHandlers...)
 }
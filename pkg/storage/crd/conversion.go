@@ -4,8 +4,8 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
-	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
 	"github.com/solo-io/gloo/pkg/protoutil"
+	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,13 +20,14 @@ func UpstreamToCrd(namespace string, upstream *v1.Upstream) (*crdv1.Upstream, er
 		}
 	}
 	var resourceVersion string
-	var annotations map[string]string
+	var annotations, labels map[string]string
 	if upstream.Metadata != nil {
 		resourceVersion = upstream.Metadata.ResourceVersion
 		if upstream.Metadata.Namespace != "" {
 			namespace = upstream.Metadata.Namespace
 		}
 		annotations = upstream.Metadata.Annotations
+		labels = upstream.Metadata.Labels
 	}
 
 	// clone and remove fields
@@ -50,6 +51,7 @@ func UpstreamToCrd(namespace string, upstream *v1.Upstream) (*crdv1.Upstream, er
 			Namespace:       namespace,
 			ResourceVersion: resourceVersion,
 			Annotations:     annotations,
+			Labels:          labels,
 		},
 		Status: status,
 		Spec:   &copySpec,
@@ -70,6 +72,7 @@ func UpstreamFromCrd(upstreamCrd *crdv1.Upstream) (*v1.Upstream, error) {
 		ResourceVersion: upstreamCrd.ResourceVersion,
 		Namespace:       upstreamCrd.Namespace,
 		Annotations:     upstreamCrd.Annotations,
+		Labels:          upstreamCrd.Labels,
 	}
 	upstream.Status = upstreamCrd.Status
 	return &upstream, nil
@@ -86,13 +89,14 @@ func VirtualHostToCrd(namespace string, virtualHost *v1.VirtualHost) (*crdv1.Vir
 		}
 	}
 	var resourceVersion string
-	var annotations map[string]string
+	var annotations, labels map[string]string
 	if virtualHost.Metadata != nil {
 		resourceVersion = virtualHost.Metadata.ResourceVersion
 		if virtualHost.Metadata.Namespace != "" {
 			namespace = virtualHost.Metadata.Namespace
 		}
 		annotations = virtualHost.Metadata.Annotations
+		labels = virtualHost.Metadata.Labels
 	}
 
 	// clone and remove fields
@@ -116,6 +120,7 @@ func VirtualHostToCrd(namespace string, virtualHost *v1.VirtualHost) (*crdv1.Vir
 			Namespace:       namespace,
 			ResourceVersion: resourceVersion,
 			Annotations:     annotations,
+			Labels:          labels,
 		},
 		Status: status,
 		Spec:   &copySpec,
@@ -136,6 +141,7 @@ func VirtualHostFromCrd(vHostCrd *crdv1.VirtualHost) (*v1.VirtualHost, error) {
 		ResourceVersion: vHostCrd.ResourceVersion,
 		Namespace:       vHostCrd.Namespace,
 		Annotations:     vHostCrd.Annotations,
+		Labels:          vHostCrd.Labels,
 	}
 	virtualHost.Status = vHostCrd.Status
 	return &virtualHost, nil
@@ -48,6 +48,22 @@ var _ = Describe("Conversion", func() {
 			Expect(spec["functions"]).To(Equal(fnsInSpec))
 		})
 	})
+	Describe("UpstreamFromCrd", func() {
+		It("keeps the labels of the upstream", func() {
+			us := helpers.NewTestUpstream1()
+			labels := map[string]string{"app": "petstore"}
+			us.Metadata = &v1.Metadata{
+				Labels: labels,
+			}
+			upCrd, err := UpstreamToCrd("foo", us)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upCrd.Labels).To(Equal(labels))
+			Expect((*upCrd.Spec)["metadata"]).To(BeNil())
+			out, err := UpstreamFromCrd(upCrd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Metadata.Labels).To(Equal(labels))
+		})
+	})
 	Describe("VirtualhostToCrd", func() {
 		It("Converts a gloo virtualhost to crd", func() {
 			vHost := helpers.NewTestVirtualHost("foo", helpers.NewTestRoute1())
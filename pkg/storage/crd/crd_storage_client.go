@@ -12,20 +12,23 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/gloo/pkg/storage"
 	crdclientset "github.com/solo-io/gloo/pkg/storage/crd/client/clientset/versioned"
 	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
-	"github.com/solo-io/gloo/pkg/log"
 )
 
 type Client struct {
 	v1 *v1client
 }
 
-func NewStorage(cfg *rest.Config, namespace string, syncFrequency time.Duration) (storage.Interface, error) {
+// NewStorage reads and writes config objects in namespace. objects in the watchNamespaces are also listed and watched,
+// and can be read and written by a name qualified with their namespace, as "namespace/name"
+func NewStorage(cfg *rest.Config, namespace string, syncFrequency time.Duration, watchNamespaces ...string) (storage.Interface, error) {
 	if namespace == "" {
 		namespace = GlooDefaultNamespace
 	}
+	namespaces := watchedNamespaces(namespace, watchNamespaces)
 	crdClient, err := crdclientset.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -43,11 +46,13 @@ func NewStorage(cfg *rest.Config, namespace string, syncFrequency time.Duration)
 			upstreams: &upstreamsClient{
 				crds:          crdClient,
 				namespace:     namespace,
+				namespaces:    namespaces,
 				syncFrequency: syncFrequency,
 			},
 			virtualHosts: &virtualHostsClient{
 				crds:          crdClient,
 				namespace:     namespace,
+				namespaces:    namespaces,
 				syncFrequency: syncFrequency,
 			},
 			apiexts:    apiextClient,
@@ -92,10 +97,28 @@ func (c *v1client) Register() error {
 				},
 			},
 		}
+		validation := crdValidation(crd.FullName())
+		toRegister.Spec.Validation = validation
 		log.Debugf("registering crd %v", crd)
-		if _, err := c.apiexts.ApiextensionsV1beta1().CustomResourceDefinitions().Create(toRegister); err != nil && !apierrors.IsAlreadyExists(err) {
+		_, err := c.apiexts.ApiextensionsV1beta1().CustomResourceDefinitions().Create(toRegister)
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsAlreadyExists(err) {
 			return fmt.Errorf("failed to create crd: %v", err)
 		}
+		if validation == nil {
+			continue
+		}
+		// keep the published schemas in sync with the plugins of this version
+		existing, err := c.apiexts.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crd.FullName(), metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get crd %v: %v", crd.FullName(), err)
+		}
+		existing.Spec.Validation = validation
+		if _, err := c.apiexts.ApiextensionsV1beta1().CustomResourceDefinitions().Update(existing); err != nil {
+			return fmt.Errorf("failed to update validation of crd %v: %v", crd.FullName(), err)
+		}
 	}
 	return nil
 }
@@ -127,6 +127,24 @@ var _ = Describe("CrdStorageClient", func() {
 				upstream.Metadata = updated.GetMetadata()
 				Expect(updated).To(Equal(upstream))
 			})
+			It("removes the labels removed from the item", func() {
+				cfg, err := clientcmd.BuildConfigFromFlags(masterUrl, kubeconfigPath)
+				Expect(err).NotTo(HaveOccurred())
+				client, err := NewStorage(cfg, namespace, syncFreq)
+				Expect(err).NotTo(HaveOccurred())
+				err = client.V1().Register()
+				Expect(err).NotTo(HaveOccurred())
+				upstream := NewTestUpstream1()
+				upstream.Metadata.Labels = map[string]string{"team": "a"}
+				created, err := client.V1().Upstreams().Create(upstream)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created.Metadata.Labels).To(Equal(map[string]string{"team": "a"}))
+				upstream.Metadata = created.GetMetadata()
+				upstream.Metadata.Labels = nil
+				updated, err := client.V1().Upstreams().Update(upstream)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Metadata.Labels).To(BeEmpty())
+			})
 		})
 		Describe("Delete", func() {
 			It("deletes a crd from the name", func() {
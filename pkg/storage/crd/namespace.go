@@ -1,3 +1,83 @@
 package crd
 
+import (
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
 const GlooDefaultNamespace = "gloo-system"
+
+// watchedNamespaces returns the default namespace followed by the other namespaces to list and watch, without duplicates
+func watchedNamespaces(namespace string, watchNamespaces []string) []string {
+	namespaces := []string{namespace}
+	seen := map[string]bool{namespace: true}
+	for _, ns := range watchNamespaces {
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// splitName returns the namespace and name of the object named "namespace/name", or "name" in the default namespace
+func splitName(name, defaultNamespace string) (string, string) {
+	namespace, name := v1.ParseRef(name)
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return namespace, name
+}
+
+// informerGroup runs an informer for each namespace. their events are held back until every one of them has synced,
+// so handlers are never called with the objects of some namespaces but not the others
+type informerGroup struct {
+	informers []cache.SharedInformer
+	stores    []cache.Store
+	synced    int32
+}
+
+func (g *informerGroup) add(informer cache.SharedInformer) {
+	g.informers = append(g.informers, informer)
+	g.stores = append(g.stores, informer.GetStore())
+}
+
+// hasSynced returns true once every informer has synced
+func (g *informerGroup) hasSynced() bool {
+	return atomic.LoadInt32(&g.synced) == 1
+}
+
+// run runs the informers until stop is closed. onSynced is called once every informer has synced,
+// with the objects of every namespace
+func (g *informerGroup) run(stop <-chan struct{}, onSynced func()) {
+	var wg sync.WaitGroup
+	var informersSynced []cache.InformerSynced
+	for _, informer := range g.informers {
+		wg.Add(1)
+		go func(informer cache.SharedInformer) {
+			defer wg.Done()
+			informer.Run(stop)
+		}(informer)
+		informersSynced = append(informersSynced, informer.HasSynced)
+	}
+	if cache.WaitForCacheSync(stop, informersSynced...) {
+		// events that arrive from now on are passed on. the objects of the events held back are in the stores
+		atomic.StoreInt32(&g.synced, 1)
+		onSynced()
+	}
+	wg.Wait()
+}
+
+// listStores returns the objects in all the stores
+func listStores(stores []cache.Store) []interface{} {
+	var objects []interface{}
+	for _, store := range stores {
+		objects = append(objects, store.List()...)
+	}
+	return objects
+}
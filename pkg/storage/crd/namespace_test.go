@@ -0,0 +1,68 @@
+package crd
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
+)
+
+// namespaceInformer returns an informer for a namespace with one upstream, whose list blocks until listed is closed
+func namespaceInformer(namespace string, listed <-chan struct{}) cache.SharedInformer {
+	lw := &cache.ListWatch{
+		ListFunc: func(_ metav1.ListOptions) (runtime.Object, error) {
+			<-listed
+			return &crdv1.UpstreamList{Items: []crdv1.Upstream{{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace + "-upstream", Namespace: namespace},
+			}}}, nil
+		},
+		WatchFunc: func(_ metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+	return cache.NewSharedInformer(lw, new(crdv1.Upstream), time.Hour)
+}
+
+var _ = Describe("informerGroup", func() {
+	It("holds back events until the informers of every namespace have synced", func() {
+		fastListed := make(chan struct{})
+		close(fastListed)
+		slowListed := make(chan struct{})
+		group := &informerGroup{}
+		group.add(namespaceInformer("fast", fastListed))
+		group.add(namespaceInformer("slow", slowListed))
+
+		lists := make(chan []*v1.Upstream, 10)
+		eh := &upstreamEventHandler{
+			group: group,
+			handler: &storage.UpstreamEventHandlerFuncs{
+				AddFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+					lists <- updatedList
+				},
+				UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+					lists <- updatedList
+				},
+			},
+		}
+		for _, informer := range group.informers {
+			informer.AddEventHandler(eh)
+		}
+		stop := make(chan struct{})
+		defer close(stop)
+		go group.run(stop, func() {
+			eh.handler.OnUpdate(eh.getUpdatedList(), nil)
+		})
+
+		Consistently(lists, 100*time.Millisecond).ShouldNot(Receive())
+		close(slowListed)
+		Eventually(lists).Should(Receive(HaveLen(2)))
+	})
+})
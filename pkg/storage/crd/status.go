@@ -0,0 +1,17 @@
+package crd
+
+import (
+	"encoding/json"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// statusPatch is a json patch replacing the status of a resource.
+// a patch carries no resource version, so it cannot conflict with changes to the spec
+func statusPatch(status *v1.Status) ([]byte, error) {
+	return json.Marshal([]map[string]interface{}{{
+		"op":    "add",
+		"path":  "/status",
+		"value": status,
+	}})
+}
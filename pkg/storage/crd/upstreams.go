@@ -11,6 +11,7 @@ import (
 	apiexts "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/solo-io/gloo/pkg/storage/crud"
 	kuberrs "k8s.io/apimachinery/pkg/api/errors"
@@ -21,7 +22,9 @@ type upstreamsClient struct {
 	crds    crdclientset.Interface
 	apiexts apiexts.Interface
 	// write and read objects to this namespace if not specified on the GlooObjects
-	namespace     string
+	namespace string
+	// the namespaces to list and watch, starting with the default namespace
+	namespaces    []string
 	syncFrequency time.Duration
 }
 
@@ -34,11 +37,32 @@ func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
 }
 
 func (c *upstreamsClient) Delete(name string) error {
-	return c.crds.GlooV1().Upstreams(c.namespace).Delete(name, nil)
+	namespace, name := splitName(name, c.namespace)
+	return c.crds.GlooV1().Upstreams(namespace).Delete(name, nil)
+}
+
+// UpdateStatus patches the status subresource if it is enabled for the crd.
+// otherwise the status of the resource itself is patched, which still leaves the spec untouched
+func (c *upstreamsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	patch, err := statusPatch(status)
+	if err != nil {
+		return "", errors.Wrap(err, "creating status patch")
+	}
+	namespace, name := splitName(name, c.namespace)
+	upstreams := c.crds.GlooV1().Upstreams(namespace)
+	patched, err := upstreams.Patch(name, types.JSONPatchType, patch, "status")
+	if kuberrs.IsNotFound(err) {
+		patched, err = upstreams.Patch(name, types.JSONPatchType, patch)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "kubernetes patch api request")
+	}
+	return patched.ResourceVersion, nil
 }
 
 func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
-	crdUs, err := c.crds.GlooV1().Upstreams(c.namespace).Get(name, metav1.GetOptions{})
+	namespace, name := splitName(name, c.namespace)
+	crdUs, err := c.crds.GlooV1().Upstreams(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed performing get api request")
 	}
@@ -49,30 +73,50 @@ func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
 	return returnedUpstream, nil
 }
 
-func (c *upstreamsClient) List() ([]*v1.Upstream, error) {
-	crdList, err := c.crds.GlooV1().Upstreams(c.namespace).List(metav1.ListOptions{})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed performing list api request")
-	}
+func (c *upstreamsClient) List(opts *storage.ListOptions) ([]*v1.Upstream, error) {
 	var returnedUpstreams []*v1.Upstream
-	for _, crdUs := range crdList.Items {
-		upstream, err := UpstreamFromCrd(&crdUs)
+	for _, namespace := range c.namespaces {
+		crdList, err := c.crds.GlooV1().Upstreams(namespace).List(metav1.ListOptions{LabelSelector: opts.String()})
 		if err != nil {
-			return nil, errors.Wrap(err, "converting returned crd to upstream")
+			return nil, errors.Wrapf(err, "failed performing list api request in namespace %v", namespace)
+		}
+		for _, crdUs := range crdList.Items {
+			upstream, err := UpstreamFromCrd(&crdUs)
+			if err != nil {
+				return nil, errors.Wrap(err, "converting returned crd to upstream")
+			}
+			returnedUpstreams = append(returnedUpstreams, upstream)
 		}
-		returnedUpstreams = append(returnedUpstreams, upstream)
 	}
 	return returnedUpstreams, nil
 }
 
-func (u *upstreamsClient) Watch(handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
-	lw := cache.NewListWatchFromClient(u.crds.GlooV1().RESTClient(), crdv1.UpstreamCRD.Plural, u.namespace, fields.Everything())
-	sw := cache.NewSharedInformer(lw, new(crdv1.Upstream), u.syncFrequency)
+// Watch runs an informer for each namespace. handlers are called with the upstreams of every namespace,
+// once the informers of all namespaces have synced.
+// the label selector is applied by the kubernetes api
+func (u *upstreamsClient) Watch(opts *storage.ListOptions, handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
+	group := &informerGroup{}
+	for _, namespace := range u.namespaces {
+		lw := cache.NewFilteredListWatchFromClient(u.crds.GlooV1().RESTClient(), crdv1.UpstreamCRD.Plural, namespace, func(options *metav1.ListOptions) {
+			options.LabelSelector = opts.String()
+		})
+		group.add(cache.NewSharedInformer(lw, new(crdv1.Upstream), u.syncFrequency))
+	}
+	var eventHandlers []*upstreamEventHandler
 	for _, h := range handlers {
-		sw.AddEventHandler(&upstreamEventHandler{handler: h, store: sw.GetStore()})
+		eventHandlers = append(eventHandlers, &upstreamEventHandler{handler: h, group: group})
+	}
+	for _, sw := range group.informers {
+		for _, eh := range eventHandlers {
+			sw.AddEventHandler(eh)
+		}
 	}
 	return storage.NewWatcher(func(stop <-chan struct{}, _ chan error) {
-		sw.Run(stop)
+		group.run(stop, func() {
+			for _, eh := range eventHandlers {
+				eh.handler.OnUpdate(eh.getUpdatedList(), nil)
+			}
+		})
 	}), nil
 }
 
@@ -93,15 +137,12 @@ func (c *upstreamsClient) createOrUpdateUpstreamCrd(upstream *v1.Upstream, op cr
 			return nil, errors.Wrap(err, "kubernetes create api request")
 		}
 	case crud.OperationUpdate:
-		// need to make sure we preserve labels
-		currentCrd, err := upstreams.Get(upstreamCrd.Name, metav1.GetOptions{ResourceVersion: upstreamCrd.ResourceVersion})
-		if err != nil {
-			return nil, errors.Wrap(err, "kubernetes get api request")
-		}
-		// copy labels
-		upstreamCrd.Labels = currentCrd.Labels
+		// the labels of the object replace those of the crd, so labels removed from the object are removed from the crd
 		returnedCrd, err = upstreams.Update(upstreamCrd)
 		if err != nil {
+			if kuberrs.IsConflict(err) {
+				return nil, storage.NewConflictErr(err)
+			}
 			return nil, errors.Wrap(err, "kubernetes update api request")
 		}
 	}
@@ -115,13 +156,12 @@ func (c *upstreamsClient) createOrUpdateUpstreamCrd(upstream *v1.Upstream, op cr
 // implements the kubernetes ResourceEventHandler interface
 type upstreamEventHandler struct {
 	handler storage.UpstreamEventHandler
-	store   cache.Store
+	group   *informerGroup
 }
 
 func (eh *upstreamEventHandler) getUpdatedList() []*v1.Upstream {
-	updatedList := eh.store.List()
 	var updatedUpstreamList []*v1.Upstream
-	for _, updated := range updatedList {
+	for _, updated := range listStores(eh.group.stores) {
 		usCrd, ok := updated.(*crdv1.Upstream)
 		if !ok {
 			continue
@@ -148,6 +188,9 @@ func convertUs(obj interface{}) (*v1.Upstream, bool) {
 }
 
 func (eh *upstreamEventHandler) OnAdd(obj interface{}) {
+	if !eh.group.hasSynced() {
+		return
+	}
 	us, ok := convertUs(obj)
 	if !ok {
 		return
@@ -155,6 +198,9 @@ func (eh *upstreamEventHandler) OnAdd(obj interface{}) {
 	eh.handler.OnAdd(eh.getUpdatedList(), us)
 }
 func (eh *upstreamEventHandler) OnUpdate(_, newObj interface{}) {
+	if !eh.group.hasSynced() {
+		return
+	}
 	newUs, ok := convertUs(newObj)
 	if !ok {
 		return
@@ -163,6 +209,9 @@ func (eh *upstreamEventHandler) OnUpdate(_, newObj interface{}) {
 }
 
 func (eh *upstreamEventHandler) OnDelete(obj interface{}) {
+	if !eh.group.hasSynced() {
+		return
+	}
 	us, ok := convertUs(obj)
 	if !ok {
 		return
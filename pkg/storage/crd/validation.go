@@ -0,0 +1,179 @@
+package crd
+
+import (
+	"encoding/json"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	"github.com/solo-io/gloo/pkg/plugins/schemas"
+	crdv1 "github.com/solo-io/gloo/pkg/storage/crd/solo.io/v1"
+)
+
+// crdValidation returns the OpenAPI schema of the crd, built from the schemas registered by plugins.
+// it returns nil if no plugin has registered a schema the crd uses
+func crdValidation(crd string) *v1beta1.CustomResourceValidation {
+	var spec *v1beta1.JSONSchemaProps
+	switch crd {
+	case crdv1.UpstreamCRD.FullName():
+		spec = upstreamSpecProps()
+	case crdv1.VirtualHostCRD.FullName():
+		spec = virtualHostSpecProps()
+	}
+	if spec == nil {
+		return nil
+	}
+	return &v1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &v1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]v1beta1.JSONSchemaProps{
+				"spec": *spec,
+			},
+		},
+	}
+}
+
+// upstreamSpecProps requires the spec, function specs and service properties of an upstream to match the schemas
+// registered for its upstream and service type. upstreams of other types are not constrained
+func upstreamSpecProps() *v1beta1.JSONSchemaProps {
+	upstreamSchemas := schemas.OfKind(schemas.KindUpstreamSpec)
+	serviceSchemas := schemas.OfKind(schemas.KindServiceProperties)
+	if len(upstreamSchemas) == 0 && len(serviceSchemas) == 0 {
+		return nil
+	}
+	props := &v1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]v1beta1.JSONSchemaProps{
+			"type":               {Type: "string"},
+			"connection_timeout": {Type: "string"},
+			"spec":               {Type: "object"},
+			"functions": {
+				Type: "array",
+				Items: &v1beta1.JSONSchemaPropsOrArray{Schema: &v1beta1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]v1beta1.JSONSchemaProps{
+						"name": {Type: "string"},
+						"spec": {Type: "object"},
+					},
+				}},
+			},
+			"service_info": {
+				Type: "object",
+				Properties: map[string]v1beta1.JSONSchemaProps{
+					"type":       {Type: "string"},
+					"properties": {Type: "object"},
+				},
+			},
+		},
+	}
+
+	if len(upstreamSchemas) > 0 {
+		var types []string
+		var byType []v1beta1.JSONSchemaProps
+		for _, s := range upstreamSchemas {
+			types = append(types, s.Type)
+			branch := v1beta1.JSONSchemaProps{
+				Properties: map[string]v1beta1.JSONSchemaProps{
+					"type": {Enum: enum(s.Type)},
+					"spec": jsonSchemaProps(s.Properties()),
+				},
+			}
+			if fn, ok := schemas.FunctionSchema(s.Type, ""); ok {
+				branch.Properties["functions"] = v1beta1.JSONSchemaProps{
+					Items: &v1beta1.JSONSchemaPropsOrArray{Schema: &v1beta1.JSONSchemaProps{
+						Properties: map[string]v1beta1.JSONSchemaProps{
+							"spec": jsonSchemaProps(fn.Properties()),
+						},
+					}},
+				}
+			}
+			byType = append(byType, branch)
+		}
+		byType = append(byType, v1beta1.JSONSchemaProps{
+			Properties: map[string]v1beta1.JSONSchemaProps{
+				"type": {Not: &v1beta1.JSONSchemaProps{Enum: enum(types...)}},
+			},
+		})
+		props.AllOf = append(props.AllOf, v1beta1.JSONSchemaProps{AnyOf: byType})
+	}
+
+	if len(serviceSchemas) > 0 {
+		var types []string
+		var byType []v1beta1.JSONSchemaProps
+		for _, s := range serviceSchemas {
+			types = append(types, s.Type)
+			byType = append(byType, v1beta1.JSONSchemaProps{
+				Required: []string{"service_info"},
+				Properties: map[string]v1beta1.JSONSchemaProps{
+					"service_info": {
+						Properties: map[string]v1beta1.JSONSchemaProps{
+							"type":       {Enum: enum(s.Type)},
+							"properties": jsonSchemaProps(s.Properties()),
+						},
+					},
+				},
+			})
+		}
+		byType = append(byType, v1beta1.JSONSchemaProps{
+			Properties: map[string]v1beta1.JSONSchemaProps{
+				"service_info": {
+					Properties: map[string]v1beta1.JSONSchemaProps{
+						"type": {Not: &v1beta1.JSONSchemaProps{Enum: enum(types...)}},
+					},
+				},
+			},
+		})
+		props.AllOf = append(props.AllOf, v1beta1.JSONSchemaProps{AnyOf: byType})
+	}
+	return props
+}
+
+// virtualHostSpecProps requires route extensions to match the merged schemas of the route plugins
+func virtualHostSpecProps() *v1beta1.JSONSchemaProps {
+	if len(schemas.OfKind(schemas.KindRouteExtensions)) == 0 {
+		return nil
+	}
+	return &v1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]v1beta1.JSONSchemaProps{
+			"routes": {
+				Type: "array",
+				Items: &v1beta1.JSONSchemaPropsOrArray{Schema: &v1beta1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]v1beta1.JSONSchemaProps{
+						"extensions": jsonSchemaProps(schemas.RouteExtensionsProperties()),
+					},
+				}},
+			},
+		},
+	}
+}
+
+// jsonSchemaProps converts the schema of a spec to its kubernetes form.
+// the schemas of map values are dropped, as kubernetes 1.9 does not allow additionalProperties in crd validation
+func jsonSchemaProps(p *schemas.Property) v1beta1.JSONSchemaProps {
+	props := v1beta1.JSONSchemaProps{
+		Type:        p.Type,
+		Format:      p.Format,
+		Description: p.Description,
+	}
+	if len(p.Properties) > 0 {
+		props.Properties = make(map[string]v1beta1.JSONSchemaProps)
+		for name, field := range p.Properties {
+			props.Properties[name] = jsonSchemaProps(field)
+		}
+	}
+	if p.Items != nil {
+		items := jsonSchemaProps(p.Items)
+		props.Items = &v1beta1.JSONSchemaPropsOrArray{Schema: &items}
+	}
+	return props
+}
+
+func enum(values ...string) []v1beta1.JSON {
+	var out []v1beta1.JSON
+	for _, v := range values {
+		raw, _ := json.Marshal(v)
+		out = append(out, v1beta1.JSON{Raw: raw})
+	}
+	return out
+}
@@ -11,6 +11,7 @@ import (
 	apiexts "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/solo-io/gloo/pkg/storage/crud"
 	kuberrs "k8s.io/apimachinery/pkg/api/errors"
@@ -21,7 +22,9 @@ type virtualHostsClient struct {
 	crds    crdclientset.Interface
 	apiexts apiexts.Interface
 	// write and read objects to this namespace if not specified on the GlooObjects
-	namespace     string
+	namespace string
+	// the namespaces to list and watch, starting with the default namespace
+	namespaces    []string
 	syncFrequency time.Duration
 }
 
@@ -34,11 +37,32 @@ func (v *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, erro
 }
 
 func (v *virtualHostsClient) Delete(name string) error {
-	return v.crds.GlooV1().VirtualHosts(v.namespace).Delete(name, nil)
+	namespace, name := splitName(name, v.namespace)
+	return v.crds.GlooV1().VirtualHosts(namespace).Delete(name, nil)
+}
+
+// UpdateStatus patches the status subresource if it is enabled for the crd.
+// otherwise the status of the resource itself is patched, which still leaves the spec untouched
+func (v *virtualHostsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	patch, err := statusPatch(status)
+	if err != nil {
+		return "", errors.Wrap(err, "creating status patch")
+	}
+	namespace, name := splitName(name, v.namespace)
+	virtualHosts := v.crds.GlooV1().VirtualHosts(namespace)
+	patched, err := virtualHosts.Patch(name, types.JSONPatchType, patch, "status")
+	if kuberrs.IsNotFound(err) {
+		patched, err = virtualHosts.Patch(name, types.JSONPatchType, patch)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "kubernetes patch api request")
+	}
+	return patched.ResourceVersion, nil
 }
 
 func (v *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
-	crdVh, err := v.crds.GlooV1().VirtualHosts(v.namespace).Get(name, metav1.GetOptions{})
+	namespace, name := splitName(name, v.namespace)
+	crdVh, err := v.crds.GlooV1().VirtualHosts(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed performing get api request")
 	}
@@ -49,30 +73,50 @@ func (v *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
 	return returnedVirtualHost, nil
 }
 
-func (v *virtualHostsClient) List() ([]*v1.VirtualHost, error) {
-	crdList, err := v.crds.GlooV1().VirtualHosts(v.namespace).List(metav1.ListOptions{})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed performing list api request")
-	}
+func (v *virtualHostsClient) List(opts *storage.ListOptions) ([]*v1.VirtualHost, error) {
 	var returnedVirtualHosts []*v1.VirtualHost
-	for _, crdVh := range crdList.Items {
-		virtualHost, err := VirtualHostFromCrd(&crdVh)
+	for _, namespace := range v.namespaces {
+		crdList, err := v.crds.GlooV1().VirtualHosts(namespace).List(metav1.ListOptions{LabelSelector: opts.String()})
 		if err != nil {
-			return nil, errors.Wrap(err, "converting returned crd to virtualHost")
+			return nil, errors.Wrapf(err, "failed performing list api request in namespace %v", namespace)
+		}
+		for _, crdVh := range crdList.Items {
+			virtualHost, err := VirtualHostFromCrd(&crdVh)
+			if err != nil {
+				return nil, errors.Wrap(err, "converting returned crd to virtualHost")
+			}
+			returnedVirtualHosts = append(returnedVirtualHosts, virtualHost)
 		}
-		returnedVirtualHosts = append(returnedVirtualHosts, virtualHost)
 	}
 	return returnedVirtualHosts, nil
 }
 
-func (v *virtualHostsClient) Watch(handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
-	lw := cache.NewListWatchFromClient(v.crds.GlooV1().RESTClient(), crdv1.VirtualHostCRD.Plural, v.namespace, fields.Everything())
-	sw := cache.NewSharedInformer(lw, new(crdv1.VirtualHost), v.syncFrequency)
+// Watch runs an informer for each namespace. handlers are called with the virtual hosts of every namespace,
+// once the informers of all namespaces have synced.
+// the label selector is applied by the kubernetes api
+func (v *virtualHostsClient) Watch(opts *storage.ListOptions, handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+	group := &informerGroup{}
+	for _, namespace := range v.namespaces {
+		lw := cache.NewFilteredListWatchFromClient(v.crds.GlooV1().RESTClient(), crdv1.VirtualHostCRD.Plural, namespace, func(options *metav1.ListOptions) {
+			options.LabelSelector = opts.String()
+		})
+		group.add(cache.NewSharedInformer(lw, new(crdv1.VirtualHost), v.syncFrequency))
+	}
+	var eventHandlers []*virtualHostEventHandler
 	for _, h := range handlers {
-		sw.AddEventHandler(&virtualHostEventHandler{handler: h, store: sw.GetStore()})
+		eventHandlers = append(eventHandlers, &virtualHostEventHandler{handler: h, group: group})
+	}
+	for _, sw := range group.informers {
+		for _, eh := range eventHandlers {
+			sw.AddEventHandler(eh)
+		}
 	}
 	return storage.NewWatcher(func(stop <-chan struct{}, _ chan error) {
-		sw.Run(stop)
+		group.run(stop, func() {
+			for _, eh := range eventHandlers {
+				eh.handler.OnUpdate(eh.getUpdatedList(), nil)
+			}
+		})
 	}), nil
 }
 
@@ -94,15 +138,12 @@ func (v *virtualHostsClient) createOrUpdateVirtualHostCrd(virtualHost *v1.Virtua
 			return nil, err
 		}
 	case crud.OperationUpdate:
-		// need to make sure we preserve labels and annotations
-		currentCrd, err := vhosts.Get(vhostCrd.Name, metav1.GetOptions{ResourceVersion: vhostCrd.ResourceVersion})
-		if err != nil {
-			return nil, errors.Wrap(err, "kubernetes get api request")
-		}
-		// copy labels
-		vhostCrd.Labels = currentCrd.Labels
+		// the labels of the object replace those of the crd, so labels removed from the object are removed from the crd
 		returnedCrd, err = vhosts.Update(vhostCrd)
 		if err != nil {
+			if kuberrs.IsConflict(err) {
+				return nil, storage.NewConflictErr(err)
+			}
 			return nil, errors.Wrap(err, "kubernetes update api request")
 		}
 	}
@@ -116,13 +157,12 @@ func (v *virtualHostsClient) createOrUpdateVirtualHostCrd(virtualHost *v1.Virtua
 // implements the kubernetes ResourceEventHandler interface
 type virtualHostEventHandler struct {
 	handler storage.VirtualHostEventHandler
-	store   cache.Store
+	group   *informerGroup
 }
 
 func (eh *virtualHostEventHandler) getUpdatedList() []*v1.VirtualHost {
-	updatedList := eh.store.List()
 	var updatedVirtualHostList []*v1.VirtualHost
-	for _, updated := range updatedList {
+	for _, updated := range listStores(eh.group.stores) {
 		vhCrd, ok := updated.(*crdv1.VirtualHost)
 		if !ok {
 			continue
@@ -149,6 +189,9 @@ func convertVh(obj interface{}) (*v1.VirtualHost, bool) {
 }
 
 func (eh *virtualHostEventHandler) OnAdd(obj interface{}) {
+	if !eh.group.hasSynced() {
+		return
+	}
 	vh, ok := convertVh(obj)
 	if !ok {
 		return
@@ -156,6 +199,9 @@ func (eh *virtualHostEventHandler) OnAdd(obj interface{}) {
 	eh.handler.OnAdd(eh.getUpdatedList(), vh)
 }
 func (eh *virtualHostEventHandler) OnUpdate(_, newObj interface{}) {
+	if !eh.group.hasSynced() {
+		return
+	}
 	newVh, ok := convertVh(newObj)
 	if !ok {
 		return
@@ -164,6 +210,9 @@ func (eh *virtualHostEventHandler) OnUpdate(_, newObj interface{}) {
 }
 
 func (eh *virtualHostEventHandler) OnDelete(obj interface{}) {
+	if !eh.group.hasSynced() {
+		return
+	}
 	vh, ok := convertVh(obj)
 	if !ok {
 		return
@@ -0,0 +1,33 @@
+package etcd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/test/helpers"
+	"github.com/solo-io/gloo/test/helpers/local"
+)
+
+func TestEtcd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	log.DefaultOut = GinkgoWriter
+	RunSpecs(t, "Etcd Suite")
+}
+
+var (
+	etcdInstance *localhelpers.EtcdInstance
+	err          error
+)
+
+var _ = BeforeSuite(func() {
+	etcdInstance, err = localhelpers.NewEtcdInstance()
+	helpers.Must(err)
+	err = etcdInstance.Run()
+	helpers.Must(err)
+})
+
+var _ = AfterSuite(func() {
+	etcdInstance.Clean()
+})
@@ -0,0 +1,73 @@
+package etcd
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/storage/base"
+
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+type fileStorage struct {
+	base *base.EtcdStorageClient
+}
+
+func NewFileStorage(cfg clientv3.Config, rootPath string) (dependencies.FileStorage, error) {
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating etcd client")
+	}
+
+	return &fileStorage{
+		base: base.NewEtcdStorageClient(rootPath+"/files", base.StorableItemTypeFile, client),
+	}, nil
+}
+
+func (c *fileStorage) Create(item *dependencies.File) (*dependencies.File, error) {
+	out, err := c.base.Create(&base.StorableItem{File: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.File, nil
+}
+
+func (c *fileStorage) Update(item *dependencies.File) (*dependencies.File, error) {
+	out, err := c.base.Update(&base.StorableItem{File: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.File, nil
+}
+
+func (c *fileStorage) Delete(name string) error {
+	return c.base.Delete(name)
+}
+
+func (c *fileStorage) Get(name string) (*dependencies.File, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return out.File, nil
+}
+
+func (c *fileStorage) List() ([]*dependencies.File, error) {
+	list, err := c.base.List()
+	if err != nil {
+		return nil, err
+	}
+	var files []*dependencies.File
+	for _, obj := range list {
+		files = append(files, obj.File)
+	}
+	return files, nil
+}
+
+func (c *fileStorage) Watch(handlers ...dependencies.FileEventHandler) (*storage.Watcher, error) {
+	var baseHandlers []base.StorableItemEventHandler
+	for _, h := range handlers {
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{FileEventHandler: h})
+	}
+	return c.base.Watch(baseHandlers...)
+}
@@ -0,0 +1,240 @@
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/coreos/etcd/clientv3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	. "github.com/solo-io/gloo/pkg/storage/dependencies/etcd"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("Client", func() {
+	var rootPath string
+	var etcd *clientv3.Client
+	BeforeEach(func() {
+		rootPath = RandString(4)
+		c, err := clientv3.New(etcdInstance.Config())
+		Expect(err).NotTo(HaveOccurred())
+		etcd = c
+	})
+	AfterEach(func() {
+		etcd.Delete(context.Background(), rootPath, clientv3.WithPrefix())
+		etcd.Close()
+	})
+	Describe("files", func() {
+		Describe("create", func() {
+			It("creates the file as an etcd key", func() {
+				client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &dependencies.File{
+					Ref:      "myfile",
+					Contents: []byte("foo"),
+				}
+				fi, err := client.Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fi).NotTo(Equal(input))
+				resp, err := etcd.Get(context.Background(), rootPath+"/files/"+input.Ref)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Kvs).To(HaveLen(1))
+				fileFromEtcd := &dependencies.File{
+					Ref:             strings.TrimPrefix(string(resp.Kvs[0].Key), rootPath+"/files/"),
+					Contents:        resp.Kvs[0].Value,
+					ResourceVersion: fmt.Sprintf("%v", resp.Kvs[0].ModRevision),
+				}
+				Expect(fi).To(Equal(fileFromEtcd))
+			})
+			It("creates binary files without any problem as an etcd key", func() {
+				contents := []byte{1, 2, 3, unicode.MaxASCII + 1}
+				client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &dependencies.File{
+					Ref:      "myfile",
+					Contents: contents,
+				}
+				fi, err := client.Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fi).NotTo(Equal(input))
+				resp, err := etcd.Get(context.Background(), rootPath+"/files/"+input.Ref)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Kvs).To(HaveLen(1))
+				fileFromEtcd := &dependencies.File{
+					Ref:             strings.TrimPrefix(string(resp.Kvs[0].Key), rootPath+"/files/"),
+					Contents:        resp.Kvs[0].Value,
+					ResourceVersion: fmt.Sprintf("%v", resp.Kvs[0].ModRevision),
+				}
+				Expect(fi).To(Equal(fileFromEtcd))
+				get, err := client.Get(input.Ref)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(input.Contents).To(Equal(get.Contents))
+			})
+			It("errors when creating the same file twice", func() {
+				client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &dependencies.File{
+					Ref:      "myfile",
+					Contents: []byte("foo"),
+				}
+				_, err = client.Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.Create(input)
+				Expect(err).To(HaveOccurred())
+			})
+			Describe("update", func() {
+				It("fails if the file doesn't exist", func() {
+					client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &dependencies.File{
+						Ref:      "myfile",
+						Contents: []byte("foo"),
+					}
+					fi, err := client.Update(input)
+					Expect(err).To(HaveOccurred())
+					Expect(fi).To(BeNil())
+				})
+				It("fails if the resourceversion is not up to date", func() {
+					client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &dependencies.File{
+						Ref:      "myfile",
+						Contents: []byte("foo"),
+					}
+					_, err = client.Create(input)
+					Expect(err).NotTo(HaveOccurred())
+					v, err := client.Update(input)
+					Expect(v).To(BeNil())
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("resource version"))
+				})
+				It("updates the file", func() {
+					client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &dependencies.File{
+						Ref:      "myfile",
+						Contents: []byte("foo"),
+					}
+					fi, err := client.Create(input)
+					Expect(err).NotTo(HaveOccurred())
+					changed := &dependencies.File{
+						Ref:             input.Ref,
+						Contents:        []byte("bar"),
+						ResourceVersion: fi.ResourceVersion,
+					}
+					out, err := client.Update(changed)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.Contents).To(Equal(changed.Contents))
+				})
+				Describe("get", func() {
+					It("fails if the file doesn't exist", func() {
+						client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						fi, err := client.Get("foo")
+						Expect(err).To(HaveOccurred())
+						Expect(fi).To(BeNil())
+					})
+					It("returns the file", func() {
+						client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						input := &dependencies.File{
+							Ref:      "myfile",
+							Contents: []byte("foo"),
+						}
+						fi, err := client.Create(input)
+						Expect(err).NotTo(HaveOccurred())
+						out, err := client.Get(input.Ref)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(Equal(fi))
+						input.ResourceVersion = out.ResourceVersion
+						Expect(out).To(Equal(input))
+					})
+				})
+				Describe("list", func() {
+					It("returns all existing files", func() {
+						client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						input1 := &dependencies.File{
+							Ref:      "myfile1",
+							Contents: []byte("foo"),
+						}
+						input2 := &dependencies.File{
+							Ref:      "myfile2",
+							Contents: []byte("foo"),
+						}
+						input3 := &dependencies.File{
+							Ref:      "myfile3",
+							Contents: []byte("foo"),
+						}
+						fi1, err := client.Create(input1)
+						Expect(err).NotTo(HaveOccurred())
+						fi2, err := client.Create(input2)
+						Expect(err).NotTo(HaveOccurred())
+						fi3, err := client.Create(input3)
+						Expect(err).NotTo(HaveOccurred())
+						out, err := client.List()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(ContainElement(fi1))
+						Expect(out).To(ContainElement(fi2))
+						Expect(out).To(ContainElement(fi3))
+					})
+				})
+				Describe("watch", func() {
+					It("watches", func() {
+						client, err := NewFileStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						lists := make(chan []*dependencies.File, 3)
+						stop := make(chan struct{})
+						defer close(stop)
+						errs := make(chan error)
+						w, err := client.Watch(&dependencies.FileEventHandlerFuncs{
+							UpdateFunc: func(updatedList []*dependencies.File, _ *dependencies.File) {
+								lists <- updatedList
+							},
+						})
+						Expect(err).NotTo(HaveOccurred())
+						go func() {
+							w.Run(stop, errs)
+						}()
+						input1 := &dependencies.File{
+							Ref:      "myfile1",
+							Contents: []byte("foo"),
+						}
+						input2 := &dependencies.File{
+							Ref:      "myfile2",
+							Contents: []byte("foo"),
+						}
+						input3 := &dependencies.File{
+							Ref:      "myfile3",
+							Contents: []byte("foo"),
+						}
+						fi1, err := client.Create(input1)
+						Expect(err).NotTo(HaveOccurred())
+						fi2, err := client.Create(input2)
+						Expect(err).NotTo(HaveOccurred())
+						fi3, err := client.Create(input3)
+						Expect(err).NotTo(HaveOccurred())
+
+						var list []*dependencies.File
+						Eventually(func() []*dependencies.File {
+							select {
+							default:
+								return nil
+							case l := <-lists:
+								list = l
+								return l
+							}
+						}).Should(HaveLen(3))
+						Expect(list).To(HaveLen(3))
+						Expect(list).To(ContainElement(fi1))
+						Expect(list).To(ContainElement(fi2))
+						Expect(list).To(ContainElement(fi3))
+					})
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package etcd
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/storage/base"
+
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+type secretStorage struct {
+	base *base.EtcdStorageClient
+}
+
+// NewSecretStorage stores the data of each secret as a JSON object. it is not encrypted by gloo;
+// access to the keys under rootPath should be restricted with etcd's authentication
+func NewSecretStorage(cfg clientv3.Config, rootPath string) (dependencies.SecretStorage, error) {
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating etcd client")
+	}
+
+	return &secretStorage{
+		base: base.NewEtcdStorageClient(rootPath+"/secrets", base.StorableItemTypeSecret, client),
+	}, nil
+}
+
+func (c *secretStorage) Create(item *dependencies.Secret) (*dependencies.Secret, error) {
+	out, err := c.base.Create(&base.StorableItem{Secret: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+func (c *secretStorage) Update(item *dependencies.Secret) (*dependencies.Secret, error) {
+	out, err := c.base.Update(&base.StorableItem{Secret: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+func (c *secretStorage) Delete(name string) error {
+	return c.base.Delete(name)
+}
+
+func (c *secretStorage) Get(name string) (*dependencies.Secret, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+func (c *secretStorage) List() ([]*dependencies.Secret, error) {
+	list, err := c.base.List()
+	if err != nil {
+		return nil, err
+	}
+	var secrets []*dependencies.Secret
+	for _, obj := range list {
+		secrets = append(secrets, obj.Secret)
+	}
+	return secrets, nil
+}
+
+func (c *secretStorage) Watch(handlers ...dependencies.SecretEventHandler) (*storage.Watcher, error) {
+	var baseHandlers []base.StorableItemEventHandler
+	for _, h := range handlers {
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{SecretEventHandler: h})
+	}
+	return c.base.Watch(baseHandlers...)
+}
@@ -0,0 +1,115 @@
+package etcd_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/coreos/etcd/clientv3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	. "github.com/solo-io/gloo/pkg/storage/dependencies/etcd"
+	. "github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("Secret Storage Client", func() {
+	var rootPath string
+	var etcd *clientv3.Client
+	var client dependencies.SecretStorage
+	BeforeEach(func() {
+		rootPath = RandString(4)
+		c, err := clientv3.New(etcdInstance.Config())
+		Expect(err).NotTo(HaveOccurred())
+		etcd = c
+		client, err = NewSecretStorage(etcdInstance.Config(), rootPath)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		etcd.Delete(context.Background(), rootPath, clientv3.WithPrefix())
+		etcd.Close()
+	})
+	Describe("create", func() {
+		It("creates the secret as an etcd key", func() {
+			secret := &dependencies.Secret{
+				Ref:  "good.secretname",
+				Data: map[string]string{"hello": "goodbye"},
+			}
+			s, err := client.Create(secret)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := etcd.Get(context.Background(), rootPath+"/secrets/"+secret.Ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Kvs).To(HaveLen(1))
+			var data map[string]string
+			err = json.Unmarshal(resp.Kvs[0].Value, &data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(secret.Data))
+			Expect(s.Ref).To(Equal(secret.Ref))
+			Expect(s.Data).To(Equal(secret.Data))
+		})
+		It("errors if the secret exists", func() {
+			secret := &dependencies.Secret{
+				Ref:  "good",
+				Data: map[string]string{"hello": "goodbye"},
+			}
+			_, err := client.Create(secret)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.Create(secret)
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsAlreadyExists(err)).To(BeTrue())
+		})
+	})
+	Describe("update", func() {
+		It("updates the secret at the current resource version", func() {
+			s, err := client.Create(&dependencies.Secret{
+				Ref:  "good",
+				Data: map[string]string{"hello": "goodbye"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			s.Data = map[string]string{"hello": "again"}
+			updated, err := client.Update(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Data).To(Equal(s.Data))
+			Expect(updated.ResourceVersion).NotTo(Equal(s.ResourceVersion))
+			_, err = client.Update(s)
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsConflict(err)).To(BeTrue())
+		})
+	})
+	Describe("list and delete", func() {
+		It("lists the secrets that were not deleted", func() {
+			s1, err := client.Create(&dependencies.Secret{Ref: "one", Data: map[string]string{"a": "b"}})
+			Expect(err).NotTo(HaveOccurred())
+			s2, err := client.Create(&dependencies.Secret{Ref: "two", Data: map[string]string{"c": "d"}})
+			Expect(err).NotTo(HaveOccurred())
+			err = client.Delete(s1.Ref)
+			Expect(err).NotTo(HaveOccurred())
+			list, err := client.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(Equal([]*dependencies.Secret{s2}))
+		})
+	})
+	Describe("watch", func() {
+		It("delivers the full list after every change", func() {
+			lists := make(chan []*dependencies.Secret, 10)
+			stop := make(chan struct{})
+			defer close(stop)
+			w, err := client.Watch(&dependencies.SecretEventHandlerFuncs{
+				UpdateFunc: func(updatedList []*dependencies.Secret, _ *dependencies.Secret) {
+					lists <- updatedList
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go w.Run(stop, make(chan error))
+			Eventually(lists).Should(Receive(BeEmpty()))
+
+			s, err := client.Create(&dependencies.Secret{Ref: "one", Data: map[string]string{"a": "b"}})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*dependencies.Secret{s})))
+
+			err = client.Delete(s.Ref)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(BeEmpty()))
+		})
+	})
+})
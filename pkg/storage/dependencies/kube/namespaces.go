@@ -0,0 +1,50 @@
+package kube
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// watchedNamespaces returns the default namespace followed by the other namespaces to list and watch, without duplicates
+func watchedNamespaces(namespace string, watchNamespaces []string) []string {
+	namespaces := []string{namespace}
+	seen := map[string]bool{namespace: true}
+	for _, ns := range watchNamespaces {
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// splitRef returns the namespace and name of the secret with the ref "namespace/name", or "name" in the default namespace
+func splitRef(ref, defaultNamespace string) (string, string) {
+	namespace, name := v1.ParseRef(ref)
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return namespace, name
+}
+
+// secretRef returns the ref of the secret: its name in the default namespace, "namespace/name" in any other
+func secretRef(name, namespace, defaultNamespace string) string {
+	return v1.ShortenRef(v1.QualifyRef(name, namespace), defaultNamespace)
+}
+
+// runInformers runs the informers until stop is closed
+func runInformers(informers []cache.SharedInformer, stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	for _, informer := range informers {
+		wg.Add(1)
+		go func(informer cache.SharedInformer) {
+			defer wg.Done()
+			informer.Run(stop)
+		}(informer)
+	}
+	wg.Wait()
+}
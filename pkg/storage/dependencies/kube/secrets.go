@@ -18,11 +18,15 @@ import (
 type secretStorage struct {
 	kube kubernetes.Interface
 	// write and read objects to this namespace if not specified on the GlooObjects
-	namespace     string
+	namespace string
+	// the namespaces to list and watch, starting with the default namespace
+	namespaces    []string
 	syncFrequency time.Duration
 }
 
-func NewSecretStorage(cfg *rest.Config, namespace string, syncFrequency time.Duration) (dependencies.SecretStorage, error) {
+// NewSecretStorage reads and writes secrets in namespace. secrets in the watchNamespaces are also listed and watched;
+// their refs are qualified with their namespace, as "namespace/name"
+func NewSecretStorage(cfg *rest.Config, namespace string, syncFrequency time.Duration, watchNamespaces ...string) (dependencies.SecretStorage, error) {
 	kube, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating kube clientset")
@@ -30,105 +34,140 @@ func NewSecretStorage(cfg *rest.Config, namespace string, syncFrequency time.Dur
 	return &secretStorage{
 		kube:          kube,
 		namespace:     namespace,
+		namespaces:    watchedNamespaces(namespace, watchNamespaces),
 		syncFrequency: syncFrequency,
 	}, nil
 }
 
 func (s *secretStorage) Create(secret *dependencies.Secret) (*dependencies.Secret, error) {
-	kubeSecret, err := s.kube.CoreV1().Secrets(s.namespace).Create(secretToKubeSecret(secret))
+	namespace, toCreate := s.toKubeSecret(secret)
+	kubeSecret, err := s.kube.CoreV1().Secrets(namespace).Create(toCreate)
 	if err != nil {
 		if kubeerrs.IsAlreadyExists(err) {
 			return nil, storage.NewAlreadyExistsErr(errors.Errorf("secret %v", secret.Ref))
 		}
 		return nil, errors.Wrap(err, "kube api call")
 	}
-	return kubeSecretToSecret(kubeSecret), nil
+	return s.fromKubeSecret(kubeSecret), nil
 }
 
 func (s *secretStorage) Update(secret *dependencies.Secret) (*dependencies.Secret, error) {
-	kubeSecret, err := s.kube.CoreV1().Secrets(s.namespace).Update(secretToKubeSecret(secret))
+	namespace, toUpdate := s.toKubeSecret(secret)
+	kubeSecret, err := s.kube.CoreV1().Secrets(namespace).Update(toUpdate)
 	if err != nil {
 		return nil, errors.Wrap(err, "kube api call")
 	}
-	return kubeSecretToSecret(kubeSecret), nil
+	return s.fromKubeSecret(kubeSecret), nil
 }
 
 func (s *secretStorage) Delete(name string) error {
-	if err := s.kube.CoreV1().Secrets(s.namespace).Delete(name, nil); err != nil {
+	namespace, name := splitRef(name, s.namespace)
+	if err := s.kube.CoreV1().Secrets(namespace).Delete(name, nil); err != nil {
 		return errors.Wrap(err, "kube api call")
 	}
 	return nil
 }
 
 func (s *secretStorage) Get(name string) (*dependencies.Secret, error) {
-	secret, err := s.kube.CoreV1().Secrets(s.namespace).Get(name, metav1.GetOptions{})
+	namespace, name := splitRef(name, s.namespace)
+	secret, err := s.kube.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
 		return nil, errors.Wrap(err, "kube api call")
 	}
-	return kubeSecretToSecret(secret), nil
+	return s.fromKubeSecret(secret), nil
 
 }
 
 func (s *secretStorage) List() ([]*dependencies.Secret, error) {
 	var secrets []*dependencies.Secret
-	kubeSecretList, err := s.kube.CoreV1().Secrets(s.namespace).List(metav1.ListOptions{})
-	if err != nil {
-		return nil, errors.Wrap(err, "kube api call")
-	}
-	for _, kubeSecret := range kubeSecretList.Items {
-		secrets = append(secrets, kubeSecretToSecret(&kubeSecret))
+	for _, namespace := range s.namespaces {
+		kubeSecretList, err := s.kube.CoreV1().Secrets(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "kube api call in namespace %v", namespace)
+		}
+		for _, kubeSecret := range kubeSecretList.Items {
+			secrets = append(secrets, s.fromKubeSecret(&kubeSecret))
+		}
 	}
 	return secrets, nil
 }
 
+// Watch runs an informer for each namespace. handlers are called with the secrets of every namespace
 func (s *secretStorage) Watch(handlers ...dependencies.SecretEventHandler) (*storage.Watcher, error) {
-	lw := cache.NewListWatchFromClient(s.kube.CoreV1().RESTClient(),
-		"secrets", s.namespace, fields.Everything())
-	sw := cache.NewSharedInformer(lw, new(v1.Secret), s.syncFrequency)
-	for _, h := range handlers {
-		sw.AddEventHandler(&secretEventHandler{handler: h, store: sw.GetStore()})
+	var (
+		informers []cache.SharedInformer
+		stores    []cache.Store
+	)
+	for _, namespace := range s.namespaces {
+		lw := cache.NewListWatchFromClient(s.kube.CoreV1().RESTClient(),
+			"secrets", namespace, fields.Everything())
+		sw := cache.NewSharedInformer(lw, new(v1.Secret), s.syncFrequency)
+		informers = append(informers, sw)
+		stores = append(stores, sw.GetStore())
+	}
+	for _, sw := range informers {
+		for _, h := range handlers {
+			sw.AddEventHandler(&secretEventHandler{handler: h, stores: stores, storage: s})
+		}
 	}
 	return storage.NewWatcher(func(stop <-chan struct{}, _ chan error) {
-		sw.Run(stop)
+		runInformers(informers, stop)
 	}), nil
 }
 
+// toKubeSecret returns the namespace of the secret and the secret as it is stored in that namespace
+func (s *secretStorage) toKubeSecret(secret *dependencies.Secret) (string, *v1.Secret) {
+	namespace, name := splitRef(secret.Ref, s.namespace)
+	kubeSecret := secretToKubeSecret(secret)
+	kubeSecret.Name = name
+	kubeSecret.Namespace = namespace
+	return namespace, kubeSecret
+}
+
+func (s *secretStorage) fromKubeSecret(kubeSecret *v1.Secret) *dependencies.Secret {
+	secret := kubeSecretToSecret(kubeSecret)
+	secret.Ref = secretRef(kubeSecret.Name, kubeSecret.Namespace, s.namespace)
+	return secret
+}
+
 // implements the kubernetes ResourceEventHandler interface
 type secretEventHandler struct {
 	handler dependencies.SecretEventHandler
-	store   cache.Store
+	stores  []cache.Store
+	storage *secretStorage
 }
 
-func secretSecret(obj interface{}) (*dependencies.Secret, bool) {
+func (eh *secretEventHandler) secretSecret(obj interface{}) (*dependencies.Secret, bool) {
 	kubeSecret, ok := obj.(*v1.Secret)
 	if !ok {
 		return nil, ok
 	}
-	return kubeSecretToSecret(kubeSecret), ok
+	return eh.storage.fromKubeSecret(kubeSecret), ok
 }
 
 func (eh *secretEventHandler) getUpdatedList() []*dependencies.Secret {
-	updatedList := eh.store.List()
 	var updatedSecretList []*dependencies.Secret
-	for _, updated := range updatedList {
-		kubeSecret, ok := updated.(*v1.Secret)
-		if !ok {
-			continue
+	for _, store := range eh.stores {
+		for _, updated := range store.List() {
+			kubeSecret, ok := updated.(*v1.Secret)
+			if !ok {
+				continue
+			}
+			updatedSecretList = append(updatedSecretList, eh.storage.fromKubeSecret(kubeSecret))
 		}
-		updatedSecretList = append(updatedSecretList, kubeSecretToSecret(kubeSecret))
 	}
 	return updatedSecretList
 }
 
 func (eh *secretEventHandler) OnAdd(obj interface{}) {
-	secret, ok := secretSecret(obj)
+	secret, ok := eh.secretSecret(obj)
 	if !ok {
 		return
 	}
 	eh.handler.OnAdd(eh.getUpdatedList(), secret)
 }
 func (eh *secretEventHandler) OnUpdate(_, newObj interface{}) {
-	secret, ok := secretSecret(newObj)
+	secret, ok := eh.secretSecret(newObj)
 	if !ok {
 		return
 	}
@@ -136,7 +175,7 @@ func (eh *secretEventHandler) OnUpdate(_, newObj interface{}) {
 }
 
 func (eh *secretEventHandler) OnDelete(obj interface{}) {
-	secret, ok := secretSecret(obj)
+	secret, ok := eh.secretSecret(obj)
 	if !ok {
 		return
 	}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/solo-io/gloo/pkg/storage/base"
+
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+type fileStorage struct {
+	base *base.MemoryStorageClient
+}
+
+var (
+	defaultFileStorage     dependencies.FileStorage
+	defaultFileStorageOnce sync.Once
+)
+
+// NewFileStorage returns an empty store that is independent of every other
+func NewFileStorage() dependencies.FileStorage {
+	return &fileStorage{
+		base: base.NewMemoryStorageClient(base.StorableItemTypeFile),
+	}
+}
+
+// DefaultFileStorage returns the same store every time it is called in a process,
+// so components running in one process can share it
+func DefaultFileStorage() dependencies.FileStorage {
+	defaultFileStorageOnce.Do(func() {
+		defaultFileStorage = NewFileStorage()
+	})
+	return defaultFileStorage
+}
+
+func (c *fileStorage) Create(item *dependencies.File) (*dependencies.File, error) {
+	out, err := c.base.Create(&base.StorableItem{File: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.File, nil
+}
+
+func (c *fileStorage) Update(item *dependencies.File) (*dependencies.File, error) {
+	out, err := c.base.Update(&base.StorableItem{File: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.File, nil
+}
+
+func (c *fileStorage) Delete(name string) error {
+	return c.base.Delete(name)
+}
+
+func (c *fileStorage) Get(name string) (*dependencies.File, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return out.File, nil
+}
+
+func (c *fileStorage) List() ([]*dependencies.File, error) {
+	list, err := c.base.List()
+	if err != nil {
+		return nil, err
+	}
+	var files []*dependencies.File
+	for _, obj := range list {
+		files = append(files, obj.File)
+	}
+	return files, nil
+}
+
+func (c *fileStorage) Watch(handlers ...dependencies.FileEventHandler) (*storage.Watcher, error) {
+	var baseHandlers []base.StorableItemEventHandler
+	for _, h := range handlers {
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{FileEventHandler: h})
+	}
+	return c.base.Watch(baseHandlers...)
+}
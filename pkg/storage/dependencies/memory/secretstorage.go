@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/solo-io/gloo/pkg/storage/base"
+
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+type secretStorage struct {
+	base *base.MemoryStorageClient
+}
+
+var (
+	defaultSecretStorage     dependencies.SecretStorage
+	defaultSecretStorageOnce sync.Once
+)
+
+// NewSecretStorage returns an empty store that is independent of every other
+func NewSecretStorage() dependencies.SecretStorage {
+	return &secretStorage{
+		base: base.NewMemoryStorageClient(base.StorableItemTypeSecret),
+	}
+}
+
+// DefaultSecretStorage returns the same store every time it is called in a process,
+// so components running in one process can share it
+func DefaultSecretStorage() dependencies.SecretStorage {
+	defaultSecretStorageOnce.Do(func() {
+		defaultSecretStorage = NewSecretStorage()
+	})
+	return defaultSecretStorage
+}
+
+func (c *secretStorage) Create(item *dependencies.Secret) (*dependencies.Secret, error) {
+	out, err := c.base.Create(&base.StorableItem{Secret: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+func (c *secretStorage) Update(item *dependencies.Secret) (*dependencies.Secret, error) {
+	out, err := c.base.Update(&base.StorableItem{Secret: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+func (c *secretStorage) Delete(name string) error {
+	return c.base.Delete(name)
+}
+
+func (c *secretStorage) Get(name string) (*dependencies.Secret, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+func (c *secretStorage) List() ([]*dependencies.Secret, error) {
+	list, err := c.base.List()
+	if err != nil {
+		return nil, err
+	}
+	var secrets []*dependencies.Secret
+	for _, obj := range list {
+		secrets = append(secrets, obj.Secret)
+	}
+	return secrets, nil
+}
+
+func (c *secretStorage) Watch(handlers ...dependencies.SecretEventHandler) (*storage.Watcher, error) {
+	var baseHandlers []base.StorableItemEventHandler
+	for _, h := range handlers {
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{SecretEventHandler: h})
+	}
+	return c.base.Watch(baseHandlers...)
+}
@@ -0,0 +1,52 @@
+package memory_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	. "github.com/solo-io/gloo/pkg/storage/dependencies/memory"
+)
+
+var _ = Describe("Memory dependency storage", func() {
+	Describe("files", func() {
+		It("stores binary files and rejects stale updates", func() {
+			client := NewFileStorage()
+			fi, err := client.Create(&dependencies.File{Ref: "myfile", Contents: []byte{1, 2, 3, 255}})
+			Expect(err).NotTo(HaveOccurred())
+			out, err := client.Get("myfile")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(fi))
+
+			_, err = client.Create(fi)
+			Expect(storage.IsAlreadyExists(err)).To(BeTrue())
+			_, err = client.Update(&dependencies.File{Ref: "myfile", Contents: []byte("new")})
+			Expect(storage.IsConflict(err)).To(BeTrue())
+		})
+	})
+	Describe("secrets", func() {
+		It("delivers the full list after every change", func() {
+			client := NewSecretStorage()
+			lists := make(chan []*dependencies.Secret, 10)
+			stop := make(chan struct{})
+			defer close(stop)
+			w, err := client.Watch(&dependencies.SecretEventHandlerFuncs{
+				UpdateFunc: func(updatedList []*dependencies.Secret, _ *dependencies.Secret) {
+					lists <- updatedList
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go w.Run(stop, make(chan error))
+			Eventually(lists).Should(Receive(BeEmpty()))
+
+			s, err := client.Create(&dependencies.Secret{Ref: "one", Data: map[string]string{"a": "b"}})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*dependencies.Secret{s})))
+
+			s.Data = map[string]string{"a": "c"}
+			updated, err := client.Update(s)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*dependencies.Secret{updated})))
+		})
+	})
+})
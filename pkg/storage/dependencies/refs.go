@@ -0,0 +1,25 @@
+package dependencies
+
+import "strings"
+
+// secret refs may be prefixed with the scheme of the secret storage backend the secret is read from,
+// e.g. "vault://path" or "kube://namespace/name". refs without a scheme are read from the default backend
+const schemeSeparator = "://"
+
+// ParseSecretRef splits the ref into its scheme and the ref of the secret in that backend.
+// the scheme is empty if the ref has none
+func ParseSecretRef(ref string) (scheme, name string) {
+	parts := strings.SplitN(ref, schemeSeparator, 2)
+	if len(parts) != 2 {
+		return "", ref
+	}
+	return parts[0], parts[1]
+}
+
+// SchemeRef prefixes the ref with the scheme, unless the scheme is empty
+func SchemeRef(scheme, name string) string {
+	if scheme == "" {
+		return name
+	}
+	return scheme + schemeSeparator + name
+}
@@ -0,0 +1,270 @@
+package schemes
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+)
+
+// secretStorage routes each secret ref to the backend for its scheme, e.g. "vault://path" to the vault backend.
+// refs without a scheme are routed to the default backend, as if it were the only one.
+// secrets read from the other backends have their refs prefixed with the backend's scheme
+type secretStorage struct {
+	defaultStorage dependencies.SecretStorage
+	backends       map[string]dependencies.SecretStorage
+
+	// the secrets each backend listed last, by scheme
+	listedLock sync.Mutex
+	listed     map[string][]*dependencies.Secret
+}
+
+// how long List waits for a backend
+var listTimeout = 10 * time.Second
+
+// NewSecretStorage returns secret storage that reads refs without a scheme from defaultStorage,
+// and refs with a scheme from the backend given for it
+func NewSecretStorage(defaultStorage dependencies.SecretStorage, backends map[string]dependencies.SecretStorage) (dependencies.SecretStorage, error) {
+	if defaultStorage == nil {
+		return nil, errors.Errorf("default secret storage must be provided")
+	}
+	for scheme, backend := range backends {
+		if scheme == "" || backend == nil {
+			return nil, errors.Errorf("invalid secret storage backend for scheme %q", scheme)
+		}
+	}
+	return &secretStorage{
+		defaultStorage: defaultStorage,
+		backends:       backends,
+		listed:         make(map[string][]*dependencies.Secret),
+	}, nil
+}
+
+// backend returns the backend the ref is routed to, and the ref of the secret in that backend
+func (s *secretStorage) backend(ref string) (dependencies.SecretStorage, string, string, error) {
+	scheme, name := dependencies.ParseSecretRef(ref)
+	if scheme == "" {
+		return s.defaultStorage, scheme, name, nil
+	}
+	backend, ok := s.backends[scheme]
+	if !ok {
+		return nil, "", "", errors.Errorf("no secret storage configured for scheme %v of secret ref %v", scheme, ref)
+	}
+	return backend, scheme, name, nil
+}
+
+// schemes returns the schemes of the backends, the default backend's empty scheme first
+func (s *secretStorage) schemes() []string {
+	schemes := []string{""}
+	for scheme := range s.backends {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes[1:])
+	return schemes
+}
+
+func (s *secretStorage) storageFor(scheme string) dependencies.SecretStorage {
+	if scheme == "" {
+		return s.defaultStorage
+	}
+	return s.backends[scheme]
+}
+
+// withScheme returns a copy of the secret with its ref prefixed with the scheme
+func withScheme(scheme string, secret *dependencies.Secret) *dependencies.Secret {
+	if secret == nil || scheme == "" {
+		return secret
+	}
+	prefixed := *secret
+	prefixed.Ref = dependencies.SchemeRef(scheme, secret.Ref)
+	return &prefixed
+}
+
+func withSchemes(scheme string, secrets []*dependencies.Secret) []*dependencies.Secret {
+	if scheme == "" {
+		return secrets
+	}
+	var prefixed []*dependencies.Secret
+	for _, secret := range secrets {
+		prefixed = append(prefixed, withScheme(scheme, secret))
+	}
+	return prefixed
+}
+
+// inBackend returns a copy of the secret with the ref it has in its backend
+func inBackend(name string, secret *dependencies.Secret) *dependencies.Secret {
+	routed := *secret
+	routed.Ref = name
+	return &routed
+}
+
+func (s *secretStorage) Create(item *dependencies.Secret) (*dependencies.Secret, error) {
+	backend, scheme, name, err := s.backend(item.Ref)
+	if err != nil {
+		return nil, err
+	}
+	created, err := backend.Create(inBackend(name, item))
+	if err != nil {
+		return nil, err
+	}
+	return withScheme(scheme, created), nil
+}
+
+func (s *secretStorage) Update(item *dependencies.Secret) (*dependencies.Secret, error) {
+	backend, scheme, name, err := s.backend(item.Ref)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := backend.Update(inBackend(name, item))
+	if err != nil {
+		return nil, err
+	}
+	return withScheme(scheme, updated), nil
+}
+
+func (s *secretStorage) Delete(ref string) error {
+	backend, _, name, err := s.backend(ref)
+	if err != nil {
+		return err
+	}
+	return backend.Delete(name)
+}
+
+func (s *secretStorage) Get(ref string) (*dependencies.Secret, error) {
+	backend, scheme, name, err := s.backend(ref)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := backend.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return withScheme(scheme, secret), nil
+}
+
+// List lists every backend at once, so a slow backend never delays the secrets of the others.
+// a backend that fails or does not answer within listTimeout is replaced by the secrets it listed last,
+// and List only fails if every backend does
+func (s *secretStorage) List() ([]*dependencies.Secret, error) {
+	type listed struct {
+		secrets []*dependencies.Secret
+		err     error
+	}
+	schemes := s.schemes()
+	results := make([]chan listed, len(schemes))
+	for i, scheme := range schemes {
+		results[i] = make(chan listed, 1)
+		go func(scheme string, result chan<- listed) {
+			list, err := s.storageFor(scheme).List()
+			result <- listed{secrets: withSchemes(scheme, list), err: err}
+		}(scheme, results[i])
+	}
+	timedOut := make(chan struct{})
+	timer := time.AfterFunc(listTimeout, func() { close(timedOut) })
+	defer timer.Stop()
+
+	var (
+		secrets []*dependencies.Secret
+		errs    error
+		failed  int
+	)
+	for i, scheme := range schemes {
+		var result listed
+		select {
+		case result = <-results[i]:
+		case <-timedOut:
+			result.err = errors.Errorf("timed out after %v", listTimeout)
+		}
+		if result.err != nil {
+			result.err = errors.Wrapf(result.err, "failed to list secrets for scheme %q", scheme)
+			log.Warnf("%v. using the secrets it listed last", result.err)
+			errs = multierror.Append(errs, result.err)
+			failed++
+			secrets = append(secrets, s.lastListed(scheme)...)
+			continue
+		}
+		s.setLastListed(scheme, result.secrets)
+		secrets = append(secrets, result.secrets...)
+	}
+	if failed == len(schemes) {
+		return nil, errs
+	}
+	return secrets, nil
+}
+
+func (s *secretStorage) lastListed(scheme string) []*dependencies.Secret {
+	s.listedLock.Lock()
+	defer s.listedLock.Unlock()
+	return s.listed[scheme]
+}
+
+func (s *secretStorage) setLastListed(scheme string, secrets []*dependencies.Secret) {
+	s.listedLock.Lock()
+	defer s.listedLock.Unlock()
+	s.listed[scheme] = secrets
+}
+
+// Watch watches every backend on its own, so a slow backend never delays the changes of the others.
+// the handlers are given the latest secrets of every backend that has been read so far
+func (s *secretStorage) Watch(handlers ...dependencies.SecretEventHandler) (*storage.Watcher, error) {
+	merger := &secretsMerger{
+		schemes:  s.schemes(),
+		handlers: handlers,
+		lists:    make(map[string][]*dependencies.Secret),
+	}
+	var watchers []*storage.Watcher
+	for _, scheme := range merger.schemes {
+		backendScheme := scheme
+		onChange := func(updatedList []*dependencies.Secret, _ *dependencies.Secret) {
+			merger.update(backendScheme, withSchemes(backendScheme, updatedList))
+		}
+		watcher, err := s.storageFor(scheme).Watch(&dependencies.SecretEventHandlerFuncs{
+			AddFunc:    onChange,
+			UpdateFunc: onChange,
+			DeleteFunc: onChange,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to watch secrets for scheme %q", scheme)
+		}
+		watchers = append(watchers, watcher)
+	}
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		var wg sync.WaitGroup
+		for _, watcher := range watchers {
+			wg.Add(1)
+			go func(watcher *storage.Watcher) {
+				defer wg.Done()
+				watcher.Run(stop, errs)
+			}(watcher)
+		}
+		wg.Wait()
+	}), nil
+}
+
+type secretsMerger struct {
+	schemes  []string
+	handlers []dependencies.SecretEventHandler
+
+	lock sync.Mutex
+	// the latest list delivered by the backend for each scheme
+	lists map[string][]*dependencies.Secret
+}
+
+func (m *secretsMerger) update(scheme string, updatedList []*dependencies.Secret) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.lists[scheme] = updatedList
+	var merged []*dependencies.Secret
+	for _, s := range m.schemes {
+		merged = append(merged, m.lists[s]...)
+	}
+	for _, h := range m.handlers {
+		h.OnUpdate(merged, nil)
+	}
+}
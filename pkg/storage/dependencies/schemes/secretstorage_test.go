@@ -0,0 +1,133 @@
+package schemes_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	"github.com/solo-io/gloo/pkg/storage/dependencies/memory"
+	. "github.com/solo-io/gloo/pkg/storage/dependencies/schemes"
+)
+
+// failingStorage fails to list its secrets while failing is set
+type failingStorage struct {
+	dependencies.SecretStorage
+	failing bool
+}
+
+func (s *failingStorage) List() ([]*dependencies.Secret, error) {
+	if s.failing {
+		return nil, errors.New("backend is down")
+	}
+	return s.SecretStorage.List()
+}
+
+var _ = Describe("SecretStorage", func() {
+	var (
+		defaultStorage, vault dependencies.SecretStorage
+		client                dependencies.SecretStorage
+	)
+	BeforeEach(func() {
+		defaultStorage = memory.NewSecretStorage()
+		vault = memory.NewSecretStorage()
+		var err error
+		client, err = NewSecretStorage(defaultStorage, map[string]dependencies.SecretStorage{"vault": vault})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("routes refs to the backend for their scheme", func() {
+		created, err := client.Create(&dependencies.Secret{Ref: "vault://aws/creds", Data: map[string]string{"key": "a"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Ref).To(Equal("vault://aws/creds"))
+		inVault, err := vault.Get("aws/creds")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inVault.Data).To(Equal(map[string]string{"key": "a"}))
+
+		_, err = client.Create(&dependencies.Secret{Ref: "tls", Data: map[string]string{"cert": "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = defaultStorage.Get("tls")
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := client.Get("vault://aws/creds")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal(created))
+	})
+	It("errors for a scheme without a backend", func() {
+		_, err := client.Get("kube://tls")
+		Expect(err).To(HaveOccurred())
+	})
+	It("lists the secrets of every backend", func() {
+		_, err := vault.Create(&dependencies.Secret{Ref: "aws/creds"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = defaultStorage.Create(&dependencies.Secret{Ref: "tls"})
+		Expect(err).NotTo(HaveOccurred())
+		list, err := client.List()
+		Expect(err).NotTo(HaveOccurred())
+		var refs []string
+		for _, secret := range list {
+			refs = append(refs, secret.Ref)
+		}
+		Expect(refs).To(Equal([]string{"tls", "vault://aws/creds"}))
+	})
+	It("lists the other backends when one of them fails", func() {
+		failing := &failingStorage{SecretStorage: vault}
+		client, err := NewSecretStorage(defaultStorage, map[string]dependencies.SecretStorage{"vault": failing})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = vault.Create(&dependencies.Secret{Ref: "aws/creds"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = defaultStorage.Create(&dependencies.Secret{Ref: "tls"})
+		Expect(err).NotTo(HaveOccurred())
+		refs := func(list []*dependencies.Secret) []string {
+			var refs []string
+			for _, secret := range list {
+				refs = append(refs, secret.Ref)
+			}
+			return refs
+		}
+		list, err := client.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs(list)).To(Equal([]string{"tls", "vault://aws/creds"}))
+
+		// the failing backend is replaced by what it listed last
+		failing.failing = true
+		_, err = defaultStorage.Create(&dependencies.Secret{Ref: "tls-2"})
+		Expect(err).NotTo(HaveOccurred())
+		list, err = client.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs(list)).To(ConsistOf("tls", "tls-2", "vault://aws/creds"))
+	})
+	It("fails to list when every backend fails", func() {
+		client, err := NewSecretStorage(&failingStorage{SecretStorage: defaultStorage, failing: true},
+			map[string]dependencies.SecretStorage{"vault": &failingStorage{SecretStorage: vault, failing: true}})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.List()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("backend is down"))
+	})
+	It("watches every backend", func() {
+		lists := make(chan []*dependencies.Secret, 10)
+		stop := make(chan struct{})
+		defer close(stop)
+		w, err := client.Watch(&dependencies.SecretEventHandlerFuncs{
+			UpdateFunc: func(updatedList []*dependencies.Secret, _ *dependencies.Secret) {
+				lists <- updatedList
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		go w.Run(stop, make(chan error))
+
+		_, err = vault.Create(&dependencies.Secret{Ref: "aws/creds"})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() []string {
+			var refs []string
+			select {
+			case list := <-lists:
+				for _, secret := range list {
+					refs = append(refs, secret.Ref)
+				}
+			default:
+			}
+			return refs
+		}).Should(Equal([]string{"vault://aws/creds"}))
+	})
+})
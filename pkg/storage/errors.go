@@ -1,6 +1,10 @@
 package storage
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
 
 // a special kind of error returned by "create" funcs
 // can be used by callers to tell if they can ignore create errors
@@ -23,3 +27,89 @@ func IsAlreadyExists(err error) bool {
 	}
 	return false
 }
+
+// returned by "update" funcs when the resource version of the item is outdated.
+// callers can get the latest version of the item and retry
+type conflictErr struct {
+	err error
+}
+
+func (err *conflictErr) Error() string {
+	return fmt.Sprintf("conflict: %v", err.err.Error())
+}
+
+func NewConflictErr(err error) *conflictErr {
+	return &conflictErr{err: err}
+}
+
+func IsConflict(err error) bool {
+	switch errors.Cause(err).(type) {
+	case *conflictErr:
+		return true
+	}
+	return false
+}
+
+// returned by write funcs of storage clients that only write while this replica is the elected leader
+type notLeaderErr struct {
+	err error
+}
+
+func (err *notLeaderErr) Error() string {
+	return fmt.Sprintf("not leader: %v", err.err.Error())
+}
+
+func NewNotLeaderErr(err error) *notLeaderErr {
+	return &notLeaderErr{err: err}
+}
+
+func IsNotLeader(err error) bool {
+	switch errors.Cause(err).(type) {
+	case *notLeaderErr:
+		return true
+	}
+	return false
+}
+
+// returned by write funcs of storage backends that gloo cannot write to, e.g. because
+// the config is managed in a system of record outside of gloo
+type readOnlyErr struct {
+	err error
+}
+
+func (err *readOnlyErr) Error() string {
+	return fmt.Sprintf("read only: %v", err.err.Error())
+}
+
+func NewReadOnlyErr(err error) *readOnlyErr {
+	return &readOnlyErr{err: err}
+}
+
+func IsReadOnly(err error) bool {
+	switch errors.Cause(err).(type) {
+	case *readOnlyErr:
+		return true
+	}
+	return false
+}
+
+// returned by write funcs when the item is not valid, e.g. because its spec does not match the schema of its type
+type invalidErr struct {
+	err error
+}
+
+func (err *invalidErr) Error() string {
+	return fmt.Sprintf("invalid: %v", err.err.Error())
+}
+
+func NewInvalidErr(err error) *invalidErr {
+	return &invalidErr{err: err}
+}
+
+func IsInvalid(err error) bool {
+	switch errors.Cause(err).(type) {
+	case *invalidErr:
+		return true
+	}
+	return false
+}
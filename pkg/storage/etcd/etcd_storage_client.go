@@ -0,0 +1,55 @@
+package etcd
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/base"
+)
+
+type Client struct {
+	v1 *v1client
+}
+
+// NewStorage stores config objects under rootPath. etcd pushes changes to watches,
+// so unlike the other backends there is no sync frequency
+func NewStorage(cfg clientv3.Config, rootPath string) (storage.Interface, error) {
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating etcd client")
+	}
+
+	return &Client{
+		v1: &v1client{
+			upstreams: &upstreamsClient{
+				base:     base.NewEtcdStorageClient(rootPath+"/upstreams", base.StorableItemTypeUpstream, client),
+				statuses: &statusClient{rootPath: rootPath + "/status/upstreams", etcd: client},
+			},
+			virtualHosts: &virtualHostsClient{
+				base:     base.NewEtcdStorageClient(rootPath+"/virtualhosts", base.StorableItemTypeVirtualHost, client),
+				statuses: &statusClient{rootPath: rootPath + "/status/virtualhosts", etcd: client},
+			},
+		},
+	}, nil
+}
+
+func (c *Client) V1() storage.V1 {
+	return c.v1
+}
+
+type v1client struct {
+	upstreams    *upstreamsClient
+	virtualHosts *virtualHostsClient
+}
+
+func (c *v1client) Register() error {
+	return nil
+}
+
+func (c *v1client) Upstreams() storage.Upstreams {
+	return c.upstreams
+}
+
+func (c *v1client) VirtualHosts() storage.VirtualHosts {
+	return c.virtualHosts
+}
@@ -0,0 +1,440 @@
+package etcd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"time"
+
+	"fmt"
+
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/proto"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	. "github.com/solo-io/gloo/pkg/storage/etcd"
+	"github.com/solo-io/gloo/test/helpers"
+)
+
+var _ = Describe("EtcdStorageClient", func() {
+	var rootPath string
+	var etcd *clientv3.Client
+	BeforeEach(func() {
+		rootPath = helpers.RandString(4)
+		c, err := clientv3.New(etcdInstance.Config())
+		Expect(err).NotTo(HaveOccurred())
+		etcd = c
+	})
+	AfterEach(func() {
+		etcd.Delete(context.Background(), rootPath, clientv3.WithPrefix())
+		etcd.Close()
+	})
+	Describe("Upstreams", func() {
+		Describe("create", func() {
+			It("creates the upstream as an etcd key", func() {
+				client, err := NewStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &v1.Upstream{
+					Name:              "myupstream",
+					Type:              "foo",
+					ConnectionTimeout: time.Second,
+				}
+				us, err := client.V1().Upstreams().Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(us).NotTo(Equal(input))
+				resp, err := etcd.Get(context.Background(), rootPath+"/upstreams/"+input.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Kvs).To(HaveLen(1))
+				var unmarshalledUpstream v1.Upstream
+				err = proto.Unmarshal(resp.Kvs[0].Value, &unmarshalledUpstream)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(&unmarshalledUpstream).To(Equal(input))
+				resourceVersion := fmt.Sprintf("%v", resp.Kvs[0].ModRevision)
+				Expect(us.Metadata.ResourceVersion).To(Equal(resourceVersion))
+				input.Metadata = us.Metadata
+				Expect(us).To(Equal(input))
+			})
+			It("errors when creating the same upstream twice", func() {
+				client, err := NewStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &v1.Upstream{
+					Name:              "myupstream",
+					Type:              "foo",
+					ConnectionTimeout: time.Second,
+				}
+				_, err = client.V1().Upstreams().Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.V1().Upstreams().Create(input)
+				Expect(err).To(HaveOccurred())
+			})
+			Describe("update", func() {
+				It("fails if the upstream doesn't exist", func() {
+					client, err := NewStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &v1.Upstream{
+						Name:              "myupstream",
+						Type:              "foo",
+						ConnectionTimeout: time.Second,
+					}
+					us, err := client.V1().Upstreams().Update(input)
+					Expect(err).To(HaveOccurred())
+					Expect(us).To(BeNil())
+				})
+				It("fails if the resourceversion is not up to date", func() {
+					client, err := NewStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &v1.Upstream{
+						Name:              "myupstream",
+						Type:              "foo",
+						ConnectionTimeout: time.Second,
+					}
+					_, err = client.V1().Upstreams().Create(input)
+					Expect(err).NotTo(HaveOccurred())
+					v, err := client.V1().Upstreams().Update(input)
+					Expect(v).To(BeNil())
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("resource version"))
+					Expect(storage.IsConflict(err)).To(BeTrue())
+				})
+				It("updates the upstream", func() {
+					client, err := NewStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &v1.Upstream{
+						Name:              "myupstream",
+						Type:              "foo",
+						ConnectionTimeout: time.Second,
+					}
+					us, err := client.V1().Upstreams().Create(input)
+					Expect(err).NotTo(HaveOccurred())
+					changed := proto.Clone(input).(*v1.Upstream)
+					changed.Type = "bar"
+					// match resource version
+					changed.Metadata = us.Metadata
+					out, err := client.V1().Upstreams().Update(changed)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.Type).To(Equal(changed.Type))
+				})
+				Describe("get", func() {
+					It("fails if the upstream doesn't exist", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						us, err := client.V1().Upstreams().Get("foo")
+						Expect(err).To(HaveOccurred())
+						Expect(us).To(BeNil())
+					})
+					It("returns the upstream", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						input := &v1.Upstream{
+							Name:              "myupstream",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						us, err := client.V1().Upstreams().Create(input)
+						Expect(err).NotTo(HaveOccurred())
+						out, err := client.V1().Upstreams().Get(input.Name)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(Equal(us))
+						input.Metadata = out.Metadata
+						Expect(out).To(Equal(input))
+					})
+				})
+				Describe("list", func() {
+					It("returns all existing upstreams", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						input1 := &v1.Upstream{
+							Name:              "myupstream1",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						input2 := &v1.Upstream{
+							Name:              "myupstream2",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						input3 := &v1.Upstream{
+							Name:              "myupstream3",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						us1, err := client.V1().Upstreams().Create(input1)
+						Expect(err).NotTo(HaveOccurred())
+						us2, err := client.V1().Upstreams().Create(input2)
+						Expect(err).NotTo(HaveOccurred())
+						us3, err := client.V1().Upstreams().Create(input3)
+						Expect(err).NotTo(HaveOccurred())
+						out, err := client.V1().Upstreams().List(nil)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(ContainElement(us1))
+						Expect(out).To(ContainElement(us2))
+						Expect(out).To(ContainElement(us3))
+					})
+				})
+				Describe("watch", func() {
+					It("watches", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						lists := make(chan []*v1.Upstream, 3)
+						stop := make(chan struct{})
+						defer close(stop)
+						errs := make(chan error)
+						w, err := client.V1().Upstreams().Watch(nil, &storage.UpstreamEventHandlerFuncs{
+							UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+								lists <- updatedList
+							},
+						})
+						Expect(err).NotTo(HaveOccurred())
+						go func() {
+							w.Run(stop, errs)
+						}()
+						input1 := &v1.Upstream{
+							Name:              "myupstream1",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						input2 := &v1.Upstream{
+							Name:              "myupstream2",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						input3 := &v1.Upstream{
+							Name:              "myupstream3",
+							Type:              "foo",
+							ConnectionTimeout: time.Second,
+						}
+						us1, err := client.V1().Upstreams().Create(input1)
+						Expect(err).NotTo(HaveOccurred())
+						us2, err := client.V1().Upstreams().Create(input2)
+						Expect(err).NotTo(HaveOccurred())
+						us3, err := client.V1().Upstreams().Create(input3)
+						Expect(err).NotTo(HaveOccurred())
+
+						var list []*v1.Upstream
+						Eventually(func() []*v1.Upstream {
+							select {
+							default:
+								return nil
+							case l := <-lists:
+								list = l
+								return l
+							}
+						}).Should(HaveLen(3))
+						Expect(list).To(HaveLen(3))
+						Expect(list).To(ContainElement(us1))
+						Expect(list).To(ContainElement(us2))
+						Expect(list).To(ContainElement(us3))
+					})
+				})
+			})
+		})
+	})
+	Describe("VirtualHosts", func() {
+		Describe("create", func() {
+			It("creates the virtualhost as an etcd key", func() {
+				client, err := NewStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &v1.VirtualHost{
+					Name:    "myvirtualhost",
+					Domains: []string{"foo"},
+				}
+				vh, err := client.V1().VirtualHosts().Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vh).NotTo(Equal(input))
+				resp, err := etcd.Get(context.Background(), rootPath+"/virtualhosts/"+input.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Kvs).To(HaveLen(1))
+				var unmarshalledVirtualHost v1.VirtualHost
+				err = proto.Unmarshal(resp.Kvs[0].Value, &unmarshalledVirtualHost)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(&unmarshalledVirtualHost).To(Equal(input))
+				resourceVersion := fmt.Sprintf("%v", resp.Kvs[0].ModRevision)
+				Expect(vh.Metadata.ResourceVersion).To(Equal(resourceVersion))
+				input.Metadata = vh.Metadata
+				Expect(vh).To(Equal(input))
+			})
+			It("errors when creating the same virtualhost twice", func() {
+				client, err := NewStorage(etcdInstance.Config(), rootPath)
+				Expect(err).NotTo(HaveOccurred())
+				input := &v1.VirtualHost{
+					Name:    "myvirtualhost",
+					Domains: []string{"foo"},
+				}
+				_, err = client.V1().VirtualHosts().Create(input)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.V1().VirtualHosts().Create(input)
+				Expect(err).To(HaveOccurred())
+			})
+			Describe("update", func() {
+				It("fails if the virtualhost doesn't exist", func() {
+					client, err := NewStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &v1.VirtualHost{
+						Name:    "myvirtualhost",
+						Domains: []string{"foo"},
+					}
+					vh, err := client.V1().VirtualHosts().Update(input)
+					Expect(err).To(HaveOccurred())
+					Expect(vh).To(BeNil())
+				})
+				It("fails if the resourceversion is not up to date", func() {
+					client, err := NewStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &v1.VirtualHost{
+						Name:    "myvirtualhost",
+						Domains: []string{"foo"},
+					}
+					_, err = client.V1().VirtualHosts().Create(input)
+					Expect(err).NotTo(HaveOccurred())
+					v, err := client.V1().VirtualHosts().Update(input)
+					Expect(v).To(BeNil())
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("resource version"))
+				})
+				It("updates the virtualhost", func() {
+					client, err := NewStorage(etcdInstance.Config(), rootPath)
+					Expect(err).NotTo(HaveOccurred())
+					input := &v1.VirtualHost{
+						Name:    "myvirtualhost",
+						Domains: []string{"foo"},
+					}
+					vh, err := client.V1().VirtualHosts().Create(input)
+					Expect(err).NotTo(HaveOccurred())
+					changed := proto.Clone(input).(*v1.VirtualHost)
+					changed.Domains = []string{"bar"}
+					// match resource version
+					changed.Metadata = vh.Metadata
+					out, err := client.V1().VirtualHosts().Update(changed)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.Domains).To(Equal(changed.Domains))
+				})
+				Describe("get", func() {
+					It("fails if the virtualhost doesn't exist", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						vh, err := client.V1().VirtualHosts().Get("foo")
+						Expect(err).To(HaveOccurred())
+						Expect(vh).To(BeNil())
+					})
+					It("returns the virtualhost", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						input := &v1.VirtualHost{
+							Name:    "myvirtualhost",
+							Domains: []string{"foo"},
+						}
+						vh, err := client.V1().VirtualHosts().Create(input)
+						Expect(err).NotTo(HaveOccurred())
+						out, err := client.V1().VirtualHosts().Get(input.Name)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(Equal(vh))
+						input.Metadata = out.Metadata
+						Expect(out).To(Equal(input))
+					})
+				})
+				Describe("list", func() {
+					It("returns all existing virtualhosts", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						input1 := &v1.VirtualHost{
+							Name:    "myvirtualhost1",
+							Domains: []string{"foo"},
+						}
+						input2 := &v1.VirtualHost{
+							Name:    "myvirtualhost2",
+							Domains: []string{"foo"},
+						}
+						input3 := &v1.VirtualHost{
+							Name:    "myvirtualhost3",
+							Domains: []string{"foo"},
+						}
+						vh1, err := client.V1().VirtualHosts().Create(input1)
+						Expect(err).NotTo(HaveOccurred())
+						vh2, err := client.V1().VirtualHosts().Create(input2)
+						Expect(err).NotTo(HaveOccurred())
+						vh3, err := client.V1().VirtualHosts().Create(input3)
+						Expect(err).NotTo(HaveOccurred())
+						out, err := client.V1().VirtualHosts().List(nil)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(ContainElement(vh1))
+						Expect(out).To(ContainElement(vh2))
+						Expect(out).To(ContainElement(vh3))
+					})
+				})
+				Describe("watch", func() {
+					It("watches", func() {
+						client, err := NewStorage(etcdInstance.Config(), rootPath)
+						Expect(err).NotTo(HaveOccurred())
+						lists := make(chan []*v1.VirtualHost, 3)
+						stop := make(chan struct{})
+						defer close(stop)
+						errs := make(chan error)
+						w, err := client.V1().VirtualHosts().Watch(nil, &storage.VirtualHostEventHandlerFuncs{
+							UpdateFunc: func(updatedList []*v1.VirtualHost, _ *v1.VirtualHost) {
+								lists <- updatedList
+							},
+						})
+						Expect(err).NotTo(HaveOccurred())
+						go func() {
+							w.Run(stop, errs)
+						}()
+						input1 := &v1.VirtualHost{
+							Name:    "myvirtualhost1",
+							Domains: []string{"foo"},
+						}
+						input2 := &v1.VirtualHost{
+							Name:    "myvirtualhost2",
+							Domains: []string{"foo"},
+						}
+						input3 := &v1.VirtualHost{
+							Name:    "myvirtualhost3",
+							Domains: []string{"foo"},
+						}
+						vh1, err := client.V1().VirtualHosts().Create(input1)
+						Expect(err).NotTo(HaveOccurred())
+						vh2, err := client.V1().VirtualHosts().Create(input2)
+						Expect(err).NotTo(HaveOccurred())
+						vh3, err := client.V1().VirtualHosts().Create(input3)
+						Expect(err).NotTo(HaveOccurred())
+
+						var list []*v1.VirtualHost
+						Eventually(func() []*v1.VirtualHost {
+							select {
+							default:
+								return nil
+							case l := <-lists:
+								list = l
+								return l
+							}
+						}).Should(HaveLen(3))
+						Expect(list).To(HaveLen(3))
+						Expect(list).To(ContainElement(vh1))
+						Expect(list).To(ContainElement(vh2))
+						Expect(list).To(ContainElement(vh3))
+					})
+				})
+			})
+		})
+	})
+	Describe("status", func() {
+		It("writes the status without changing the resource version of the upstream", func() {
+			client, err := NewStorage(etcdInstance.Config(), rootPath)
+			Expect(err).NotTo(HaveOccurred())
+			us, err := client.V1().Upstreams().Create(&v1.Upstream{
+				Name: "myupstream",
+				Type: "foo",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			status := &v1.Status{State: v1.Status_Rejected, Reason: "bad"}
+			resourceVersion, err := client.V1().Upstreams().(storage.StatusWriter).UpdateStatus(us.Name, status)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceVersion).To(Equal(us.Metadata.ResourceVersion))
+			out, err := client.V1().Upstreams().Get(us.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Status).To(Equal(status))
+			Expect(out.Metadata.ResourceVersion).To(Equal(us.Metadata.ResourceVersion))
+		})
+	})
+})
@@ -0,0 +1,73 @@
+package etcd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/protoutil"
+)
+
+// statusClient keeps the status of each item under a root of its own,
+// so writing a status neither changes the mod revision of the item nor triggers watches on the items
+type statusClient struct {
+	rootPath string
+	etcd     *clientv3.Client
+}
+
+func (c *statusClient) key(name string) string {
+	return c.rootPath + "/" + name
+}
+
+// returns nil if no status was written for the item
+func (c *statusClient) get(name string) (*v1.Status, error) {
+	resp, err := c.etcd.Get(context.Background(), c.key(name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting status for %v", name)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var status v1.Status
+	if err := protoutil.Unmarshal(resp.Kvs[0].Value, &status); err != nil {
+		return nil, errors.Wrapf(err, "unmarshalling status for %v", name)
+	}
+	return &status, nil
+}
+
+func (c *statusClient) list() (map[string]*v1.Status, error) {
+	resp, err := c.etcd.Get(context.Background(), c.rootPath+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Wrap(err, "listing statuses")
+	}
+	statuses := make(map[string]*v1.Status)
+	for _, kv := range resp.Kvs {
+		var status v1.Status
+		if err := protoutil.Unmarshal(kv.Value, &status); err != nil {
+			return nil, errors.Wrapf(err, "unmarshalling status %s", kv.Key)
+		}
+		statuses[strings.TrimPrefix(string(kv.Key), c.rootPath+"/")] = &status
+	}
+	return statuses, nil
+}
+
+func (c *statusClient) put(name string, status *v1.Status) error {
+	data, err := protoutil.Marshal(status)
+	if err != nil {
+		return errors.Wrapf(err, "marshalling status for %v", name)
+	}
+	if _, err := c.etcd.Put(context.Background(), c.key(name), string(data)); err != nil {
+		return errors.Wrapf(err, "writing status for %v", name)
+	}
+	return nil
+}
+
+func (c *statusClient) delete(name string) error {
+	if _, err := c.etcd.Delete(context.Background(), c.key(name)); err != nil {
+		return errors.Wrapf(err, "deleting status for %v", name)
+	}
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage"
 	. "github.com/solo-io/gloo/pkg/storage/file"
 	. "github.com/solo-io/gloo/test/helpers"
 )
@@ -99,6 +101,27 @@ var _ = Describe("CrdStorageClient", func() {
 			Expect(created2).To(Equal(vhost2))
 		})
 	})
+	Describe("List", func() {
+		It("lists only the items selected by the label selector", func() {
+			client, err := NewStorage(dir, resync)
+			Expect(err).NotTo(HaveOccurred())
+			err = client.V1().Register()
+			Expect(err).NotTo(HaveOccurred())
+			upstream := NewTestUpstream1()
+			upstream.Metadata = &v1.Metadata{Labels: map[string]string{"app": "petstore"}}
+			selected, err := client.V1().Upstreams().Create(upstream)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Upstreams().Create(NewTestUpstream2())
+			Expect(err).NotTo(HaveOccurred())
+
+			list, err := client.V1().Upstreams().List(&storage.ListOptions{Selector: map[string]string{"app": "petstore"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(Equal([]*v1.Upstream{selected}))
+			list, err = client.V1().Upstreams().List(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(HaveLen(2))
+		})
+	})
 	Describe("Get", func() {
 		It("gets a file from the name", func() {
 			client, err := NewStorage(dir, resync)
@@ -149,4 +172,31 @@ var _ = Describe("CrdStorageClient", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	Describe("UpdateStatus", func() {
+		It("writes the status without changing the item", func() {
+			client, err := NewStorage(dir, resync)
+			Expect(err).NotTo(HaveOccurred())
+			err = client.V1().Register()
+			Expect(err).NotTo(HaveOccurred())
+			upstream := NewTestUpstream1()
+			created, err := client.V1().Upstreams().Create(upstream)
+			Expect(err).NotTo(HaveOccurred())
+			statusWriter, ok := client.V1().Upstreams().(storage.StatusWriter)
+			Expect(ok).To(BeTrue())
+			status := &v1.Status{State: v1.Status_Rejected, Reason: "bad"}
+			version, err := statusWriter.UpdateStatus(upstream.Name, status)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(created.Metadata.ResourceVersion))
+			got, err := client.V1().Upstreams().Get(upstream.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Status).To(Equal(status))
+			got.Status = nil
+			Expect(got).To(Equal(created))
+
+			// an update with an outdated resource version is a conflict
+			upstream.Metadata = &v1.Metadata{ResourceVersion: "0"}
+			_, err = client.V1().Upstreams().Update(upstream)
+			Expect(storage.IsConflict(err)).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,43 @@
+package file
+
+import (
+	"os"
+	"strings"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// the status of an item is kept in a sidecar file next to the item's file,
+// so writing a status never rewrites the item or causes a watch event
+const statusFileSuffix = ".status"
+
+func statusFile(path string) string {
+	return path + statusFileSuffix
+}
+
+func isConfigFile(path string) bool {
+	return strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")
+}
+
+// returns nil if the item at path has no status file
+func readStatus(path string) (*v1.Status, error) {
+	if _, err := os.Stat(statusFile(path)); os.IsNotExist(err) {
+		return nil, nil
+	}
+	var status v1.Status
+	if err := ReadFileInto(statusFile(path), &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func writeStatus(path string, status *v1.Status) error {
+	return WriteToFile(statusFile(path), status)
+}
+
+func removeStatus(path string) error {
+	if err := os.Remove(statusFile(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
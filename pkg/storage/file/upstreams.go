@@ -4,7 +4,6 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
@@ -34,7 +33,7 @@ func (c *upstreamsClient) Create(item *v1.Upstream) (*v1.Upstream, error) {
 		upstreamClone.Metadata = &v1.Metadata{}
 	}
 	upstreamClone.Metadata.ResourceVersion = newOrIncrementResourceVer(upstreamClone.Metadata.ResourceVersion)
-	upstreamFiles, err := c.pathsToUpstreams()
+	upstreamFiles, err := c.pathsToUpstreams(nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read upstream dir")
 	}
@@ -56,7 +55,7 @@ func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
 	if item.Metadata == nil || item.Metadata.ResourceVersion == "" {
 		return nil, errors.New("resource version must be set for update operations")
 	}
-	upstreamFiles, err := c.pathsToUpstreams()
+	upstreamFiles, err := c.pathsToUpstreams(nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read upstream dir")
 	}
@@ -66,7 +65,7 @@ func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
 			continue
 		}
 		if existingUps.Metadata != nil && lessThan(item.Metadata.ResourceVersion, existingUps.Metadata.ResourceVersion) {
-			return nil, errors.Errorf("resource version outdated for %v", item.Name)
+			return nil, storage.NewConflictErr(errors.Errorf("resource version outdated for %v", item.Name))
 		}
 		upstreamClone, ok := proto.Clone(item).(*v1.Upstream)
 		if !ok {
@@ -85,21 +84,42 @@ func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
 }
 
 func (c *upstreamsClient) Delete(name string) error {
-	upstreamFiles, err := c.pathsToUpstreams()
+	upstreamFiles, err := c.pathsToUpstreams(nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to read upstream dir")
 	}
 	// error if exists already
 	for file, existingUps := range upstreamFiles {
 		if existingUps.Name == name {
-			return os.Remove(file)
+			if err := os.Remove(file); err != nil {
+				return err
+			}
+			return removeStatus(file)
 		}
 	}
 	return errors.Errorf("file not found for upstream %v", name)
 }
 
+// UpdateStatus writes the status to a sidecar file, leaving the upstream's file untouched
+func (c *upstreamsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	upstreamFiles, err := c.pathsToUpstreams(nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read upstream dir")
+	}
+	for file, existing := range upstreamFiles {
+		if existing.Name != name {
+			continue
+		}
+		if err := writeStatus(file, status); err != nil {
+			return "", errors.Wrap(err, "failed writing status file")
+		}
+		return existing.GetMetadata().GetResourceVersion(), nil
+	}
+	return "", errors.Errorf("file not found for upstream %v", name)
+}
+
 func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
-	upstreamFiles, err := c.pathsToUpstreams()
+	upstreamFiles, err := c.pathsToUpstreams(nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read upstream dir")
 	}
@@ -112,8 +132,8 @@ func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
 	return nil, errors.Errorf("file not found for upstream %v", name)
 }
 
-func (c *upstreamsClient) List() ([]*v1.Upstream, error) {
-	upstreamPaths, err := c.pathsToUpstreams()
+func (c *upstreamsClient) List(opts *storage.ListOptions) ([]*v1.Upstream, error) {
+	upstreamPaths, err := c.pathsToUpstreams(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +144,8 @@ func (c *upstreamsClient) List() ([]*v1.Upstream, error) {
 	return upstreams, nil
 }
 
-func (c *upstreamsClient) pathsToUpstreams() (map[string]*v1.Upstream, error) {
+// pathsToUpstreams returns the selected upstreams by path. the status files of the others are not read
+func (c *upstreamsClient) pathsToUpstreams(opts *storage.ListOptions) (map[string]*v1.Upstream, error) {
 	files, err := ioutil.ReadDir(c.dir)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read dir")
@@ -132,7 +153,7 @@ func (c *upstreamsClient) pathsToUpstreams() (map[string]*v1.Upstream, error) {
 	upstreams := make(map[string]*v1.Upstream)
 	for _, f := range files {
 		path := filepath.Join(c.dir, f.Name())
-		if !strings.HasSuffix(path, ".yml") && !strings.HasSuffix(path, ".yaml") {
+		if !isConfigFile(path) {
 			continue
 		}
 		var upstream v1.Upstream
@@ -140,12 +161,27 @@ func (c *upstreamsClient) pathsToUpstreams() (map[string]*v1.Upstream, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "unable to parse .yml file as upstream")
 		}
+		if !opts.Matches(upstream.GetMetadata().GetLabels()) {
+			continue
+		}
+		status, err := readStatus(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse status file for %v", path)
+		}
+		if status != nil {
+			upstream.Status = status
+		}
 		upstreams[path] = &upstream
 	}
 	return upstreams, nil
 }
 
-func (u *upstreamsClient) Watch(handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
+func (u *upstreamsClient) Watch(opts *storage.ListOptions, handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
+	var selectors []storage.UpstreamEventHandler
+	for _, h := range handlers {
+		selectors = append(selectors, storage.UpstreamSelector(opts, h))
+	}
+	handlers = selectors
 	w := watcher.New()
 	w.SetMaxEvents(0)
 	w.FilterOps(watcher.Create, watcher.Write, watcher.Remove)
@@ -160,7 +196,7 @@ func (u *upstreamsClient) Watch(handlers ...storage.UpstreamEventHandler) (*stor
 			}
 		}()
 		// start the watch with an "initial read" event
-		current, err := u.List()
+		current, err := u.List(nil)
 		if err != nil {
 			errs <- err
 			return
@@ -190,7 +226,11 @@ func (u *upstreamsClient) Watch(handlers ...storage.UpstreamEventHandler) (*stor
 
 func (u *upstreamsClient) onEvent(event watcher.Event, handlers ...storage.UpstreamEventHandler) error {
 	log.Debugf("file event: %v [%v]", event.Path, event.Op)
-	current, err := u.List()
+	// status files are written by the reporter and are not items themselves
+	if !event.IsDir() && !isConfigFile(event.Path) {
+		return nil
+	}
+	current, err := u.List(nil)
 	if err != nil {
 		return err
 	}
@@ -4,7 +4,6 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -33,7 +32,7 @@ func (c *virtualHostsClient) Create(item *v1.VirtualHost) (*v1.VirtualHost, erro
 		virtualHostClone.Metadata = &v1.Metadata{}
 	}
 	virtualHostClone.Metadata.ResourceVersion = newOrIncrementResourceVer(virtualHostClone.Metadata.ResourceVersion)
-	virtualHostFiles, err := c.pathsToVirtualHosts()
+	virtualHostFiles, err := c.pathsToVirtualHosts(nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read virtualHost dir")
 	}
@@ -55,7 +54,7 @@ func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, erro
 	if item.Metadata == nil || item.Metadata.ResourceVersion == "" {
 		return nil, errors.New("resource version must be set for update operations")
 	}
-	virtualHostFiles, err := c.pathsToVirtualHosts()
+	virtualHostFiles, err := c.pathsToVirtualHosts(nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read virtualHost dir")
 	}
@@ -65,7 +64,7 @@ func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, erro
 			continue
 		}
 		if existingUps.Metadata != nil && lessThan(item.Metadata.ResourceVersion, existingUps.Metadata.ResourceVersion) {
-			return nil, errors.Errorf("resource version outdated for %v", item.Name)
+			return nil, storage.NewConflictErr(errors.Errorf("resource version outdated for %v", item.Name))
 		}
 		virtualHostClone, ok := proto.Clone(item).(*v1.VirtualHost)
 		if !ok {
@@ -84,21 +83,42 @@ func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, erro
 }
 
 func (c *virtualHostsClient) Delete(name string) error {
-	virtualHostFiles, err := c.pathsToVirtualHosts()
+	virtualHostFiles, err := c.pathsToVirtualHosts(nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to read virtualHost dir")
 	}
 	// error if exists already
 	for file, existingUps := range virtualHostFiles {
 		if existingUps.Name == name {
-			return os.Remove(file)
+			if err := os.Remove(file); err != nil {
+				return err
+			}
+			return removeStatus(file)
 		}
 	}
 	return errors.Errorf("file not found for virtualHost %v", name)
 }
 
+// UpdateStatus writes the status to a sidecar file, leaving the virtual host's file untouched
+func (c *virtualHostsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	virtualHostFiles, err := c.pathsToVirtualHosts(nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read virtualHost dir")
+	}
+	for file, existing := range virtualHostFiles {
+		if existing.Name != name {
+			continue
+		}
+		if err := writeStatus(file, status); err != nil {
+			return "", errors.Wrap(err, "failed writing status file")
+		}
+		return existing.GetMetadata().GetResourceVersion(), nil
+	}
+	return "", errors.Errorf("file not found for virtualHost %v", name)
+}
+
 func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
-	virtualHostFiles, err := c.pathsToVirtualHosts()
+	virtualHostFiles, err := c.pathsToVirtualHosts(nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read virtualHost dir")
 	}
@@ -111,8 +131,8 @@ func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
 	return nil, errors.Errorf("file not found for virtualHost %v", name)
 }
 
-func (c *virtualHostsClient) List() ([]*v1.VirtualHost, error) {
-	virtualHostPaths, err := c.pathsToVirtualHosts()
+func (c *virtualHostsClient) List(opts *storage.ListOptions) ([]*v1.VirtualHost, error) {
+	virtualHostPaths, err := c.pathsToVirtualHosts(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +143,8 @@ func (c *virtualHostsClient) List() ([]*v1.VirtualHost, error) {
 	return virtualHosts, nil
 }
 
-func (c *virtualHostsClient) pathsToVirtualHosts() (map[string]*v1.VirtualHost, error) {
+// pathsToVirtualHosts returns the selected virtual hosts by path. the status files of the others are not read
+func (c *virtualHostsClient) pathsToVirtualHosts(opts *storage.ListOptions) (map[string]*v1.VirtualHost, error) {
 	files, err := ioutil.ReadDir(c.dir)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read dir")
@@ -131,7 +152,7 @@ func (c *virtualHostsClient) pathsToVirtualHosts() (map[string]*v1.VirtualHost,
 	virtualHosts := make(map[string]*v1.VirtualHost)
 	for _, f := range files {
 		path := filepath.Join(c.dir, f.Name())
-		if !strings.HasSuffix(path, ".yml") && !strings.HasSuffix(path, ".yaml") {
+		if !isConfigFile(path) {
 			continue
 		}
 		var virtualHost v1.VirtualHost
@@ -139,12 +160,27 @@ func (c *virtualHostsClient) pathsToVirtualHosts() (map[string]*v1.VirtualHost,
 		if err != nil {
 			return nil, errors.Wrap(err, "unable to parse .yml file as virtualHost")
 		}
+		if !opts.Matches(virtualHost.GetMetadata().GetLabels()) {
+			continue
+		}
+		status, err := readStatus(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse status file for %v", path)
+		}
+		if status != nil {
+			virtualHost.Status = status
+		}
 		virtualHosts[path] = &virtualHost
 	}
 	return virtualHosts, nil
 }
 
-func (u *virtualHostsClient) Watch(handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+func (u *virtualHostsClient) Watch(opts *storage.ListOptions, handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+	var selectors []storage.VirtualHostEventHandler
+	for _, h := range handlers {
+		selectors = append(selectors, storage.VirtualHostSelector(opts, h))
+	}
+	handlers = selectors
 	w := watcher.New()
 	w.SetMaxEvents(0)
 	w.FilterOps(watcher.Create, watcher.Write, watcher.Remove)
@@ -159,7 +195,7 @@ func (u *virtualHostsClient) Watch(handlers ...storage.VirtualHostEventHandler)
 			}
 		}()
 		// start the watch with an "initial read" event
-		current, err := u.List()
+		current, err := u.List(nil)
 		if err != nil {
 			errs <- err
 			return
@@ -186,7 +222,11 @@ func (u *virtualHostsClient) Watch(handlers ...storage.VirtualHostEventHandler)
 
 func (u *virtualHostsClient) onEvent(event watcher.Event, handlers ...storage.VirtualHostEventHandler) error {
 	log.Debugf("file event: %v [%v]", event.Path, event.Op)
-	current, err := u.List()
+	// status files are written by the reporter and are not items themselves
+	if !event.IsDir() && !isConfigFile(event.Path) {
+		return nil
+	}
+	current, err := u.List(nil)
 	if err != nil {
 		return err
 	}
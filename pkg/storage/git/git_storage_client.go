@@ -0,0 +1,321 @@
+package git
+
+import (
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+const (
+	upstreamsDir    = "upstreams"
+	virtualHostsDir = "virtualhosts"
+
+	// statuses are kept in the status branch at the path of the item's file, with this suffix
+	statusFileSuffix = ".status"
+)
+
+// Client reads config objects from a git ref, in the same directory layout as the file storage client.
+// the resource version of a config object is the sha of the last commit that changed its file.
+// gloo never writes to the ref: creating, updating and deleting config objects fails with a read only error.
+// statuses are committed to a separate branch of the same repository, if one is given
+type Client struct {
+	repo *repo
+	ref  string
+	v1   *v1client
+}
+
+// NewStorage reads config objects from ref (a branch, tag or commit) of the local repository at repoDir.
+// dir is the directory of the repository that contains the upstreams and virtualhosts directories.
+// if statusBranch is empty, statuses cannot be written
+func NewStorage(repoDir, ref, dir, statusBranch string, syncFrequency time.Duration) (*Client, error) {
+	r, err := newRepo(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	if statusBranch != "" && (statusBranch == ref || "refs/heads/"+statusBranch == ref) {
+		return nil, errors.Errorf("the status branch must not be the branch config is read from")
+	}
+	newDir := func(name string) *configDir {
+		return &configDir{
+			repo:          r,
+			ref:           ref,
+			dir:           path.Join(dir, name),
+			statusBranch:  statusBranch,
+			syncFrequency: syncFrequency,
+		}
+	}
+	return &Client{
+		repo: r,
+		ref:  ref,
+		v1: &v1client{
+			upstreams:    &upstreamsClient{dir: newDir(upstreamsDir)},
+			virtualHosts: &virtualHostsClient{dir: newDir(virtualHostsDir)},
+		},
+	}, nil
+}
+
+func (c *Client) V1() storage.V1 {
+	return c.v1
+}
+
+// Commit returns the sha of the commit config objects are currently read from
+func (c *Client) Commit() (string, error) {
+	return c.repo.resolve(c.ref)
+}
+
+type v1client struct {
+	upstreams    *upstreamsClient
+	virtualHosts *virtualHostsClient
+}
+
+func (c *v1client) Register() error {
+	return nil
+}
+
+func (c *v1client) Upstreams() storage.Upstreams {
+	return c.upstreams
+}
+
+func (c *v1client) VirtualHosts() storage.VirtualHosts {
+	return c.virtualHosts
+}
+
+// configDir reads the files of one directory of the ref
+type configDir struct {
+	repo          *repo
+	ref           string
+	dir           string
+	statusBranch  string
+	syncFrequency time.Duration
+
+	// the directory at the last commit it was read at, so reading it again only runs git if the ref moved
+	cacheLock sync.Mutex
+	cached    *dirSnapshot
+}
+
+// dirSnapshot is the config files of a directory at a commit
+type dirSnapshot struct {
+	commit string
+	// by path
+	blobs       map[string]string
+	contents    map[string][]byte
+	lastChanged map[string]string
+}
+
+type configFile struct {
+	path            string
+	data            []byte
+	resourceVersion string
+	status          *v1.Status
+}
+
+func isConfigFile(path string) bool {
+	return strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")
+}
+
+// read returns the config files in the directory at the commit the ref currently points to
+func (d *configDir) read() ([]configFile, error) {
+	commit, err := d.repo.resolve(d.ref)
+	if err != nil {
+		return nil, err
+	}
+	observeCommit(d.ref, commit)
+	snapshot, err := d.snapshot(commit)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := d.statuses()
+	if err != nil {
+		return nil, err
+	}
+	var configFiles []configFile
+	for path, data := range snapshot.contents {
+		configFiles = append(configFiles, configFile{
+			path:            path,
+			data:            data,
+			resourceVersion: snapshot.lastChanged[path],
+			status:          statuses[path],
+		})
+	}
+	return configFiles, nil
+}
+
+// snapshot returns the config files in the directory at the commit.
+// it builds on the snapshot of the last commit read: files whose blob did not change are not read again,
+// and only the history since that commit is searched for the last commit that changed each file
+func (d *configDir) snapshot(commit string) (*dirSnapshot, error) {
+	d.cacheLock.Lock()
+	defer d.cacheLock.Unlock()
+	previous := d.cached
+	if previous != nil && previous.commit == commit {
+		return previous, nil
+	}
+
+	blobs, err := d.repo.listFiles(commit, d.dir)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &dirSnapshot{
+		commit:   commit,
+		blobs:    make(map[string]string),
+		contents: make(map[string][]byte),
+	}
+	var toRead []string
+	for path, blob := range blobs {
+		if !isConfigFile(path) {
+			continue
+		}
+		snapshot.blobs[path] = blob
+		if previous != nil && previous.blobs[path] == blob {
+			snapshot.contents[path] = previous.contents[path]
+			continue
+		}
+		toRead = append(toRead, blob)
+	}
+	contents, err := d.repo.readBlobs(toRead)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %v at %v", d.dir, commit)
+	}
+	for path, blob := range snapshot.blobs {
+		if data, ok := contents[blob]; ok {
+			snapshot.contents[path] = data
+		}
+	}
+
+	var lastChanged map[string]string
+	if previous != nil {
+		lastChanged, err = d.repo.lastChangedSince(previous.commit, previous.lastChanged, commit, d.dir)
+	} else {
+		lastChanged, err = d.repo.lastChanged(commit, d.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// files that were deleted are not kept around
+	snapshot.lastChanged = make(map[string]string)
+	for path := range snapshot.blobs {
+		snapshot.lastChanged[path] = lastChanged[path]
+	}
+
+	d.cached = snapshot
+	return snapshot, nil
+}
+
+// statuses returns the statuses in the status branch, by the path of the item's file
+func (d *configDir) statuses() (map[string]*v1.Status, error) {
+	statuses := make(map[string]*v1.Status)
+	if d.statusBranch == "" {
+		return statuses, nil
+	}
+	tip, err := d.repo.branchTip(d.statusBranch)
+	if err != nil || tip == "" {
+		return statuses, err
+	}
+	files, err := d.repo.readFiles(tip, d.dir)
+	if err != nil {
+		return nil, err
+	}
+	for path, data := range files {
+		if !strings.HasSuffix(path, statusFileSuffix) {
+			continue
+		}
+		var status v1.Status
+		if err := unmarshalYaml(data, &status); err != nil {
+			return nil, errors.Wrapf(err, "parsing status file %v", path)
+		}
+		statuses[strings.TrimSuffix(path, statusFileSuffix)] = &status
+	}
+	return statuses, nil
+}
+
+func (d *configDir) writeStatus(path string, status *v1.Status) error {
+	if d.statusBranch == "" {
+		return storage.NewReadOnlyErr(errors.Errorf("no status branch was given for the git storage client"))
+	}
+	jsn, err := protoutil.Marshal(status)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.JSONToYAML(jsn)
+	if err != nil {
+		return err
+	}
+	return d.repo.commitFile(d.statusBranch, path+statusFileSuffix, data, "status of "+path)
+}
+
+func (d *configDir) readOnlyErr(kind, name string) error {
+	return storage.NewReadOnlyErr(errors.Errorf("%v %v is read from git ref %v; change it with a commit to the repository", kind, name, d.ref))
+}
+
+// watch calls onChange once when it starts, and again each time the ref points to a new commit.
+// commits to the status branch do not trigger the watch
+func (d *configDir) watch(onChange func() error) *storage.Watcher {
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		var lastCommit string
+		check := func() {
+			commit, err := d.repo.resolve(d.ref)
+			if err != nil {
+				log.Warnf("error syncing with git ref %v: %v", d.ref, err)
+				return
+			}
+			if commit == lastCommit {
+				return
+			}
+			if err := onChange(); err != nil {
+				log.Warnf("error syncing with git ref %v: %v", d.ref, err)
+				return
+			}
+			lastCommit = commit
+		}
+		check()
+		ticker := time.NewTicker(d.syncFrequency)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-stop:
+				return
+			}
+		}
+	})
+}
+
+func unmarshalYaml(data []byte, pb proto.Message) error {
+	jsn, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return err
+	}
+	return protoutil.Unmarshal(jsn, pb)
+}
+
+var (
+	liveCommitsLock sync.Mutex
+	liveCommits     = make(map[string]string)
+)
+
+// observeCommit logs and exports the commit config is read from when the ref moves
+func observeCommit(ref, commit string) {
+	liveCommitsLock.Lock()
+	defer liveCommitsLock.Unlock()
+	previous, seen := liveCommits[ref]
+	if seen && previous == commit {
+		return
+	}
+	if seen {
+		liveCommit.DeleteLabelValues(ref, previous)
+	}
+	liveCommits[ref] = commit
+	liveCommit.WithLabelValues(ref, commit).Set(1)
+	log.Printf("reading config from git ref %v at commit %v", ref, commit)
+}
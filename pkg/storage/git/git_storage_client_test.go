@@ -0,0 +1,194 @@
+package git_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	. "github.com/solo-io/gloo/pkg/storage/git"
+)
+
+var _ = Describe("GitStorageClient", func() {
+	var (
+		tmpdir, bare, work string
+	)
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@localhost",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@localhost")
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return strings.TrimSpace(string(out))
+	}
+	// commits the files to master of the bare repository and returns the sha of the commit
+	push := func(files map[string]string) string {
+		for path, contents := range files {
+			path = filepath.Join(work, path)
+			Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+		}
+		git(work, "add", "-A")
+		git(work, "commit", "-q", "-m", "change")
+		git(work, "push", "-q", "origin", "HEAD:master")
+		return git(work, "rev-parse", "HEAD")
+	}
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "git-storage")
+		Expect(err).NotTo(HaveOccurred())
+		bare = filepath.Join(tmpdir, "config.git")
+		work = filepath.Join(tmpdir, "work")
+		git(tmpdir, "init", "-q", "--bare", bare)
+		git(tmpdir, "init", "-q", work)
+		git(work, "remote", "add", "origin", bare)
+	})
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	It("reads config objects with the last commit that changed them as resource version", func() {
+		first := push(map[string]string{
+			"upstreams/a.yml":          "name: a\ntype: static\n",
+			"virtualhosts/default.yml": "name: default\ndomains: [\"*\"]\n",
+		})
+		second := push(map[string]string{
+			"upstreams/b.yml": "name: b\ntype: static\n",
+		})
+		client, err := NewStorage(bare, "master", "", "gloo-status", time.Second)
+		Expect(err).NotTo(HaveOccurred())
+
+		upstreams, err := client.V1().Upstreams().List(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upstreams).To(HaveLen(2))
+		Expect(upstreams[0].Name).To(Equal("a"))
+		Expect(upstreams[0].Metadata.ResourceVersion).To(Equal(first))
+		Expect(upstreams[1].Name).To(Equal("b"))
+		Expect(upstreams[1].Metadata.ResourceVersion).To(Equal(second))
+
+		vh, err := client.V1().VirtualHosts().Get("default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vh.Domains).To(Equal([]string{"*"}))
+		Expect(vh.Metadata.ResourceVersion).To(Equal(first))
+
+		commit, err := client.Commit()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commit).To(Equal(second))
+	})
+
+	It("keeps resource versions up to date as the ref moves", func() {
+		first := push(map[string]string{
+			"upstreams/a.yml": "name: a\ntype: static\n",
+			"upstreams/b.yml": "name: b\ntype: static\n",
+		})
+		client, err := NewStorage(bare, "master", "", "gloo-status", time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		versions := func() map[string]string {
+			upstreams, err := client.V1().Upstreams().List(nil)
+			Expect(err).NotTo(HaveOccurred())
+			out := make(map[string]string)
+			for _, us := range upstreams {
+				out[us.Name] = us.Metadata.ResourceVersion
+			}
+			return out
+		}
+		Expect(versions()).To(Equal(map[string]string{"a": first, "b": first}))
+
+		git(work, "rm", "-q", "upstreams/b.yml")
+		second := push(map[string]string{
+			"upstreams/a.yml": "name: a\ntype: static\nconnection_timeout: 1s\n",
+			"upstreams/c.yml": "name: c\ntype: static\n",
+		})
+		Expect(versions()).To(Equal(map[string]string{"a": second, "c": second}))
+		us, err := client.V1().Upstreams().Get("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(us.ConnectionTimeout).To(Equal(time.Second))
+
+		// a force push replaces the history the resource versions were computed from
+		git(work, "reset", "-q", "--hard", first)
+		Expect(ioutil.WriteFile(filepath.Join(work, "upstreams/d.yml"), []byte("name: d\ntype: static\n"), 0644)).To(Succeed())
+		git(work, "add", "-A")
+		git(work, "commit", "-q", "-m", "rewrite")
+		git(work, "push", "-q", "-f", "origin", "HEAD:master")
+		third := git(work, "rev-parse", "HEAD")
+		Expect(versions()).To(Equal(map[string]string{"a": first, "b": first, "d": third}))
+	})
+
+	It("rejects writes to config objects", func() {
+		push(map[string]string{"upstreams/a.yml": "name: a\ntype: static\n"})
+		client, err := NewStorage(bare, "master", "", "gloo-status", time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		us, err := client.V1().Upstreams().Get("a")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.V1().Upstreams().Create(&v1.Upstream{Name: "new", Type: "static"})
+		Expect(storage.IsReadOnly(err)).To(BeTrue())
+		_, err = client.V1().Upstreams().Update(us)
+		Expect(storage.IsReadOnly(err)).To(BeTrue())
+		err = client.V1().Upstreams().Delete(us.Name)
+		Expect(storage.IsReadOnly(err)).To(BeTrue())
+	})
+
+	It("commits statuses to the status branch without changing the config objects", func() {
+		commit := push(map[string]string{"upstreams/a.yml": "name: a\ntype: static\n"})
+		client, err := NewStorage(bare, "master", "", "gloo-status", time.Second)
+		Expect(err).NotTo(HaveOccurred())
+
+		status := &v1.Status{State: v1.Status_Rejected, Reason: "bad"}
+		resourceVersion, err := client.V1().Upstreams().(storage.StatusWriter).UpdateStatus("a", status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceVersion).To(Equal(commit))
+		status.State = v1.Status_Accepted
+		status.Reason = ""
+		_, err = client.V1().Upstreams().(storage.StatusWriter).UpdateStatus("a", status)
+		Expect(err).NotTo(HaveOccurred())
+
+		us, err := client.V1().Upstreams().Get("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(us.Status).To(Equal(status))
+		Expect(us.Metadata.ResourceVersion).To(Equal(commit))
+		Expect(git(bare, "rev-parse", "master")).To(Equal(commit))
+		Expect(git(bare, "rev-list", "--count", "gloo-status")).To(Equal("2"))
+		Expect(git(bare, "show", "gloo-status:upstreams/a.yml.status")).To(ContainSubstring("Accepted"))
+	})
+
+	It("does not write statuses without a status branch", func() {
+		push(map[string]string{"upstreams/a.yml": "name: a\ntype: static\n"})
+		client, err := NewStorage(bare, "master", "", "", time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.V1().Upstreams().(storage.StatusWriter).UpdateStatus("a", &v1.Status{})
+		Expect(storage.IsReadOnly(err)).To(BeTrue())
+	})
+
+	It("watches the ref for new commits", func() {
+		push(map[string]string{"virtualhosts/a.yml": "name: a\n"})
+		client, err := NewStorage(bare, "master", "", "gloo-status", 10*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		lists := make(chan []*v1.VirtualHost, 10)
+		stop := make(chan struct{})
+		defer close(stop)
+		w, err := client.V1().VirtualHosts().Watch(nil, &storage.VirtualHostEventHandlerFuncs{
+			UpdateFunc: func(updatedList []*v1.VirtualHost, _ *v1.VirtualHost) {
+				lists <- updatedList
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		go w.Run(stop, make(chan error))
+		Eventually(lists).Should(Receive(HaveLen(1)))
+
+		// statuses do not trigger the watch
+		_, err = client.V1().VirtualHosts().(storage.StatusWriter).UpdateStatus("a", &v1.Status{State: v1.Status_Accepted})
+		Expect(err).NotTo(HaveOccurred())
+		Consistently(lists, 100*time.Millisecond).ShouldNot(Receive())
+
+		push(map[string]string{"virtualhosts/b.yml": "name: b\n"})
+		Eventually(lists).Should(Receive(HaveLen(2)))
+	})
+})
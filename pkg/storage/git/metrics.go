@@ -0,0 +1,18 @@
+package git
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/solo-io/gloo/pkg/metrics"
+)
+
+var liveCommit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "git_storage",
+	Name:      "live_commit",
+	Help:      "set to 1 for the commit of each git ref that config objects are currently read from",
+}, []string{"ref", "commit"})
+
+func init() {
+	prometheus.MustRegister(liveCommit)
+}
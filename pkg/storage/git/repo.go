@@ -0,0 +1,272 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+// identity of the commits gloo makes to the status branch
+var committerEnv = []string{
+	"GIT_AUTHOR_NAME=gloo",
+	"GIT_AUTHOR_EMAIL=gloo@localhost",
+	"GIT_COMMITTER_NAME=gloo",
+	"GIT_COMMITTER_EMAIL=gloo@localhost",
+}
+
+// repo runs git commands against a local repository, which may be bare.
+// only git objects and refs are used, never the working tree
+type repo struct {
+	dir string
+
+	// serializes commits to the status branch made by this process
+	commitLock sync.Mutex
+}
+
+func newRepo(dir string) (*repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, errors.Wrap(err, "git storage requires the git binary")
+	}
+	r := &repo{dir: dir}
+	if _, err := r.run(nil, nil, "rev-parse", "--git-dir"); err != nil {
+		return nil, errors.Wrapf(err, "%v is not a git repository", dir)
+	}
+	return r, nil
+}
+
+func (r *repo) run(env []string, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.dir, "-c", "core.quotepath=off"}, args...)...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "git %v: %v", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// resolve returns the sha of the commit ref points to
+func (r *repo) resolve(ref string) (string, error) {
+	out, err := r.run(nil, nil, "rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %v", ref)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// branchTip returns the sha of the commit the branch points to, or "" if the branch does not exist
+func (r *repo) branchTip(branch string) (string, error) {
+	ref := "refs/heads/" + branch
+	// the pattern also matches refs below it, e.g. refs/heads/<branch>/other
+	out, err := r.run(nil, nil, "for-each-ref", "--format=%(refname) %(objectname)", ref)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == ref {
+			return fields[1], nil
+		}
+	}
+	return "", nil
+}
+
+// readFiles returns the contents of the files under dir at the commit, by path
+func (r *repo) readFiles(commit, dir string) (map[string][]byte, error) {
+	blobsByPath, err := r.listFiles(commit, dir)
+	if err != nil {
+		return nil, err
+	}
+	var blobs []string
+	for _, blob := range blobsByPath {
+		blobs = append(blobs, blob)
+	}
+	contents, err := r.readBlobs(blobs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %v at %v", dir, commit)
+	}
+	files := make(map[string][]byte)
+	for path, blob := range blobsByPath {
+		files[path] = contents[blob]
+	}
+	return files, nil
+}
+
+// listFiles returns the sha of the blob of each file under dir at the commit, by path
+func (r *repo) listFiles(commit, dir string) (map[string]string, error) {
+	out, err := r.run(nil, nil, "ls-tree", "-r", "-z", commit, "--", dir+"/")
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing %v at %v", dir, commit)
+	}
+	blobs := make(map[string]string)
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// <mode> SP <type> SP <object> TAB <path>
+		tab := strings.Index(entry, "\t")
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(entry[:tab])
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		blobs[entry[tab+1:]] = fields[2]
+	}
+	return blobs, nil
+}
+
+// readBlobs returns the contents of the blobs, by sha. they are all read by a single git process
+func (r *repo) readBlobs(blobs []string) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	if len(blobs) == 0 {
+		return contents, nil
+	}
+	out, err := r.run(nil, strings.NewReader(strings.Join(blobs, "\n")+"\n"), "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(bytes.NewReader(out))
+	for range blobs {
+		// <object> SP <type> SP <size> LF <contents> LF
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, errors.Wrap(err, "reading git cat-file output")
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 || fields[1] != "blob" {
+			return nil, errors.Errorf("unexpected git cat-file output: %q", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected git cat-file output: %q", strings.TrimSpace(header))
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, errors.Wrapf(err, "reading blob %v", fields[0])
+		}
+		if _, err := reader.Discard(1); err != nil {
+			return nil, errors.Wrapf(err, "reading blob %v", fields[0])
+		}
+		contents[fields[0]] = data
+	}
+	return contents, nil
+}
+
+// lastChanged returns the sha of the last commit before or at the given commit that changed each file under dir
+func (r *repo) lastChanged(commit, dir string) (map[string]string, error) {
+	return r.logChanges(commit, dir)
+}
+
+// lastChangedSince returns what lastChanged returns for the commit, given what it returned for an earlier commit.
+// only the commits in between are read, unless the earlier commit is not an ancestor of the commit,
+// e.g. because the ref was force pushed
+func (r *repo) lastChangedSince(since string, changedBefore map[string]string, commit, dir string) (map[string]string, error) {
+	if since == commit {
+		return changedBefore, nil
+	}
+	if _, err := r.run(nil, nil, "merge-base", "--is-ancestor", since, commit); err != nil {
+		return r.lastChanged(commit, dir)
+	}
+	changed, err := r.logChanges(since+".."+commit, dir)
+	if err != nil {
+		return nil, err
+	}
+	for path, sha := range changedBefore {
+		if _, ok := changed[path]; !ok {
+			changed[path] = sha
+		}
+	}
+	return changed, nil
+}
+
+// logChanges returns the sha of the newest commit in the revision range that changed each file under dir
+func (r *repo) logChanges(revisions, dir string) (map[string]string, error) {
+	out, err := r.run(nil, nil, "log", "--format=format:commit %H", "--name-only", revisions, "--", dir+"/")
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading history of %v at %v", dir, revisions)
+	}
+	changed := make(map[string]string)
+	var current string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "commit "):
+			current = strings.TrimPrefix(line, "commit ")
+		case line == "":
+		default:
+			// the log is newest first
+			if _, ok := changed[line]; !ok {
+				changed[line] = current
+			}
+		}
+	}
+	return changed, scanner.Err()
+}
+
+// commitFile commits the file to the branch, creating the branch if it does not exist.
+// returns a conflict error if the branch was moved by another writer during the commit
+func (r *repo) commitFile(branch, path string, data []byte, message string) error {
+	r.commitLock.Lock()
+	defer r.commitLock.Unlock()
+
+	parent, err := r.branchTip(branch)
+	if err != nil {
+		return err
+	}
+	out, err := r.run(nil, bytes.NewReader(data), "hash-object", "-w", "--stdin")
+	if err != nil {
+		return errors.Wrap(err, "writing blob")
+	}
+	blob := strings.TrimSpace(string(out))
+
+	// build the tree in a temporary index, so the repository's own index is never touched
+	tmpdir, err := ioutil.TempDir("", "gloo-git-index")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+	indexEnv := []string{"GIT_INDEX_FILE=" + filepath.Join(tmpdir, "index")}
+	readTree := []string{"read-tree", "--empty"}
+	if parent != "" {
+		readTree = []string{"read-tree", parent}
+	}
+	if _, err := r.run(indexEnv, nil, readTree...); err != nil {
+		return err
+	}
+	if _, err := r.run(indexEnv, nil, "update-index", "--add", "--cacheinfo", "100644,"+blob+","+path); err != nil {
+		return err
+	}
+	out, err = r.run(indexEnv, nil, "write-tree")
+	if err != nil {
+		return err
+	}
+	tree := strings.TrimSpace(string(out))
+
+	commitTree := []string{"commit-tree", tree, "-m", message}
+	if parent != "" {
+		commitTree = append(commitTree, "-p", parent)
+	}
+	out, err = r.run(committerEnv, nil, commitTree...)
+	if err != nil {
+		return err
+	}
+	commit := strings.TrimSpace(string(out))
+
+	// only move the branch if it still points to the parent. an empty old value requires that the branch does not exist
+	if _, err := r.run(nil, nil, "update-ref", "refs/heads/"+branch, commit, parent); err != nil {
+		return storage.NewConflictErr(errors.Wrapf(err, "branch %v was changed by another writer", branch))
+	}
+	return nil
+}
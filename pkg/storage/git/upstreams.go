@@ -0,0 +1,111 @@
+package git
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+type upstreamsClient struct {
+	dir *configDir
+}
+
+func (c *upstreamsClient) Create(item *v1.Upstream) (*v1.Upstream, error) {
+	return nil, c.dir.readOnlyErr("upstream", item.Name)
+}
+
+func (c *upstreamsClient) Update(item *v1.Upstream) (*v1.Upstream, error) {
+	return nil, c.dir.readOnlyErr("upstream", item.Name)
+}
+
+func (c *upstreamsClient) Delete(name string) error {
+	return c.dir.readOnlyErr("upstream", name)
+}
+
+// UpdateStatus commits the status to the status branch. the resource version of the upstream does not change
+func (c *upstreamsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	paths, err := c.pathsToUpstreams()
+	if err != nil {
+		return "", err
+	}
+	for path, existing := range paths {
+		if existing.Name != name {
+			continue
+		}
+		if err := c.dir.writeStatus(path, status); err != nil {
+			return "", errors.Wrapf(err, "failed writing status of upstream %v", name)
+		}
+		return existing.GetMetadata().GetResourceVersion(), nil
+	}
+	return "", errors.Errorf("file not found for upstream %v", name)
+}
+
+func (c *upstreamsClient) Get(name string) (*v1.Upstream, error) {
+	paths, err := c.pathsToUpstreams()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range paths {
+		if existing.Name == name {
+			return existing, nil
+		}
+	}
+	return nil, errors.Errorf("file not found for upstream %v", name)
+}
+
+func (c *upstreamsClient) List(opts *storage.ListOptions) ([]*v1.Upstream, error) {
+	paths, err := c.pathsToUpstreams()
+	if err != nil {
+		return nil, err
+	}
+	var upstreams []*v1.Upstream
+	for _, us := range paths {
+		if !opts.Matches(us.GetMetadata().GetLabels()) {
+			continue
+		}
+		upstreams = append(upstreams, us)
+	}
+	sort.SliceStable(upstreams, func(i, j int) bool {
+		return upstreams[i].Name < upstreams[j].Name
+	})
+	return upstreams, nil
+}
+
+func (c *upstreamsClient) pathsToUpstreams() (map[string]*v1.Upstream, error) {
+	files, err := c.dir.read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read upstreams from git")
+	}
+	upstreams := make(map[string]*v1.Upstream)
+	for _, f := range files {
+		var upstream v1.Upstream
+		if err := unmarshalYaml(f.data, &upstream); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %v as upstream", f.path)
+		}
+		if upstream.Metadata == nil {
+			upstream.Metadata = &v1.Metadata{}
+		}
+		upstream.Metadata.ResourceVersion = f.resourceVersion
+		if f.status != nil {
+			upstream.Status = f.status
+		}
+		upstreams[f.path] = &upstream
+	}
+	return upstreams, nil
+}
+
+func (c *upstreamsClient) Watch(opts *storage.ListOptions, handlers ...storage.UpstreamEventHandler) (*storage.Watcher, error) {
+	return c.dir.watch(func() error {
+		current, err := c.List(opts)
+		if err != nil {
+			return err
+		}
+		for _, h := range handlers {
+			h.OnUpdate(current, nil)
+		}
+		return nil
+	}), nil
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+)
+
+type virtualHostsClient struct {
+	dir *configDir
+}
+
+func (c *virtualHostsClient) Create(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	return nil, c.dir.readOnlyErr("virtual host", item.Name)
+}
+
+func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	return nil, c.dir.readOnlyErr("virtual host", item.Name)
+}
+
+func (c *virtualHostsClient) Delete(name string) error {
+	return c.dir.readOnlyErr("virtual host", name)
+}
+
+// UpdateStatus commits the status to the status branch. the resource version of the virtual host does not change
+func (c *virtualHostsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	paths, err := c.pathsToVirtualHosts()
+	if err != nil {
+		return "", err
+	}
+	for path, existing := range paths {
+		if existing.Name != name {
+			continue
+		}
+		if err := c.dir.writeStatus(path, status); err != nil {
+			return "", errors.Wrapf(err, "failed writing status of virtual host %v", name)
+		}
+		return existing.GetMetadata().GetResourceVersion(), nil
+	}
+	return "", errors.Errorf("file not found for virtual host %v", name)
+}
+
+func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
+	paths, err := c.pathsToVirtualHosts()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range paths {
+		if existing.Name == name {
+			return existing, nil
+		}
+	}
+	return nil, errors.Errorf("file not found for virtual host %v", name)
+}
+
+func (c *virtualHostsClient) List(opts *storage.ListOptions) ([]*v1.VirtualHost, error) {
+	paths, err := c.pathsToVirtualHosts()
+	if err != nil {
+		return nil, err
+	}
+	var virtualHosts []*v1.VirtualHost
+	for _, vh := range paths {
+		if !opts.Matches(vh.GetMetadata().GetLabels()) {
+			continue
+		}
+		virtualHosts = append(virtualHosts, vh)
+	}
+	sort.SliceStable(virtualHosts, func(i, j int) bool {
+		return virtualHosts[i].Name < virtualHosts[j].Name
+	})
+	return virtualHosts, nil
+}
+
+func (c *virtualHostsClient) pathsToVirtualHosts() (map[string]*v1.VirtualHost, error) {
+	files, err := c.dir.read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read virtual hosts from git")
+	}
+	virtualHosts := make(map[string]*v1.VirtualHost)
+	for _, f := range files {
+		var virtualHost v1.VirtualHost
+		if err := unmarshalYaml(f.data, &virtualHost); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %v as virtual host", f.path)
+		}
+		if virtualHost.Metadata == nil {
+			virtualHost.Metadata = &v1.Metadata{}
+		}
+		virtualHost.Metadata.ResourceVersion = f.resourceVersion
+		if f.status != nil {
+			virtualHost.Status = f.status
+		}
+		virtualHosts[f.path] = &virtualHost
+	}
+	return virtualHosts, nil
+}
+
+func (c *virtualHostsClient) Watch(opts *storage.ListOptions, handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+	return c.dir.watch(func() error {
+		current, err := c.List(opts)
+		if err != nil {
+			return err
+		}
+		for _, h := range handlers {
+			h.OnUpdate(current, nil)
+		}
+		return nil
+	}), nil
+}
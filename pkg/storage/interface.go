@@ -18,8 +18,10 @@ type Upstreams interface {
 	Update(*v1.Upstream) (*v1.Upstream, error)
 	Delete(name string) error
 	Get(name string) (*v1.Upstream, error)
-	List() ([]*v1.Upstream, error)
-	Watch(handlers ...UpstreamEventHandler) (*Watcher, error)
+	// List returns the upstreams selected by the options
+	List(opts *ListOptions) ([]*v1.Upstream, error)
+	// Watch gives the handlers the upstreams selected by the options
+	Watch(opts *ListOptions, handlers ...UpstreamEventHandler) (*Watcher, error)
 }
 
 type VirtualHosts interface {
@@ -27,6 +29,17 @@ type VirtualHosts interface {
 	Update(*v1.VirtualHost) (*v1.VirtualHost, error)
 	Delete(name string) error
 	Get(name string) (*v1.VirtualHost, error)
-	List() ([]*v1.VirtualHost, error)
-	Watch(...VirtualHostEventHandler) (*Watcher, error)
+	// List returns the virtual hosts selected by the options
+	List(opts *ListOptions) ([]*v1.VirtualHost, error)
+	// Watch gives the handlers the virtual hosts selected by the options
+	Watch(opts *ListOptions, handlers ...VirtualHostEventHandler) (*Watcher, error)
+}
+
+// StatusWriter is implemented by the Upstreams and VirtualHosts clients of storage backends
+// that can write the status of an item without writing the rest of it,
+// so that status updates never conflict with changes to the spec
+type StatusWriter interface {
+	// UpdateStatus replaces the status of the named item.
+	// it returns the resource version of the item after the update
+	UpdateStatus(name string, status *v1.Status) (string, error)
 }
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/base"
+)
+
+type Client struct {
+	v1 *v1client
+}
+
+var (
+	defaultStorage     storage.Interface
+	defaultStorageOnce sync.Once
+)
+
+// NewStorage returns an empty store that is independent of every other
+func NewStorage() storage.Interface {
+	return &Client{
+		v1: &v1client{
+			upstreams: &upstreamsClient{
+				base:     base.NewMemoryStorageClient(base.StorableItemTypeUpstream),
+				statuses: newStatusClient(),
+			},
+			virtualHosts: &virtualHostsClient{
+				base:     base.NewMemoryStorageClient(base.StorableItemTypeVirtualHost),
+				statuses: newStatusClient(),
+			},
+		},
+	}
+}
+
+// DefaultStorage returns the same store every time it is called in a process,
+// so components running in one process can share it
+func DefaultStorage() storage.Interface {
+	defaultStorageOnce.Do(func() {
+		defaultStorage = NewStorage()
+	})
+	return defaultStorage
+}
+
+func (c *Client) V1() storage.V1 {
+	return c.v1
+}
+
+type v1client struct {
+	upstreams    *upstreamsClient
+	virtualHosts *virtualHostsClient
+}
+
+func (c *v1client) Register() error {
+	return nil
+}
+
+func (c *v1client) Upstreams() storage.Upstreams {
+	return c.upstreams
+}
+
+func (c *v1client) VirtualHosts() storage.VirtualHosts {
+	return c.virtualHosts
+}
@@ -0,0 +1,183 @@
+package memory_test
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	. "github.com/solo-io/gloo/pkg/storage/memory"
+)
+
+var _ = Describe("MemoryStorageClient", func() {
+	var client storage.Interface
+	BeforeEach(func() {
+		client = NewStorage()
+	})
+	Describe("Upstreams", func() {
+		It("creates, updates, gets, lists and deletes upstreams", func() {
+			input := &v1.Upstream{
+				Name:              "myupstream",
+				Type:              "foo",
+				ConnectionTimeout: time.Second,
+			}
+			us, err := client.V1().Upstreams().Create(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(us.Metadata.ResourceVersion).NotTo(BeEmpty())
+			input.Metadata = us.Metadata
+			Expect(us).To(Equal(input))
+
+			changed := proto.Clone(us).(*v1.Upstream)
+			changed.Type = "bar"
+			updated, err := client.V1().Upstreams().Update(changed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Type).To(Equal("bar"))
+			Expect(updated.Metadata.ResourceVersion).NotTo(Equal(us.Metadata.ResourceVersion))
+
+			out, err := client.V1().Upstreams().Get(us.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(updated))
+			list, err := client.V1().Upstreams().List(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(Equal([]*v1.Upstream{updated}))
+
+			err = client.V1().Upstreams().Delete(us.Name)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Upstreams().Get(us.Name)
+			Expect(err).To(HaveOccurred())
+		})
+		It("errors when creating the same upstream twice", func() {
+			input := &v1.Upstream{Name: "myupstream", Type: "foo"}
+			_, err := client.V1().Upstreams().Create(input)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Upstreams().Create(input)
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsAlreadyExists(err)).To(BeTrue())
+		})
+		It("fails to update if the resource version is not up to date", func() {
+			us, err := client.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Upstreams().Update(us)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Upstreams().Update(us)
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsConflict(err)).To(BeTrue())
+		})
+		It("does not share memory with the caller", func() {
+			input := &v1.Upstream{Name: "myupstream", Type: "foo"}
+			us, err := client.V1().Upstreams().Create(input)
+			Expect(err).NotTo(HaveOccurred())
+			input.Type = "changed"
+			us.Type = "changed"
+			out, err := client.V1().Upstreams().Get("myupstream")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Type).To(Equal("foo"))
+		})
+		It("writes the status without changing the resource version", func() {
+			us, err := client.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			status := &v1.Status{State: v1.Status_Accepted}
+			resourceVersion, err := client.V1().Upstreams().(storage.StatusWriter).UpdateStatus(us.Name, status)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceVersion).To(Equal(us.Metadata.ResourceVersion))
+			out, err := client.V1().Upstreams().Get(us.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Status).To(Equal(status))
+		})
+		It("watches", func() {
+			lists := make(chan []*v1.Upstream, 10)
+			stop := make(chan struct{})
+			defer close(stop)
+			w, err := client.V1().Upstreams().Watch(nil, &storage.UpstreamEventHandlerFuncs{
+				UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+					lists <- updatedList
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go w.Run(stop, make(chan error))
+			Eventually(lists).Should(Receive(BeEmpty()))
+
+			us, err := client.V1().Upstreams().Create(&v1.Upstream{Name: "myupstream", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*v1.Upstream{us})))
+
+			err = client.V1().Upstreams().Delete(us.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(BeEmpty()))
+		})
+		Context("with a label selector", func() {
+			var web, db *v1.Upstream
+			opts := &storage.ListOptions{Selector: map[string]string{"tier": "web"}}
+			BeforeEach(func() {
+				var err error
+				web, err = client.V1().Upstreams().Create(&v1.Upstream{Name: "web", Type: "foo",
+					Metadata: &v1.Metadata{Labels: map[string]string{"tier": "web", "app": "petstore"}}})
+				Expect(err).NotTo(HaveOccurred())
+				db, err = client.V1().Upstreams().Create(&v1.Upstream{Name: "db", Type: "foo",
+					Metadata: &v1.Metadata{Labels: map[string]string{"tier": "db", "app": "petstore"}}})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("lists only the selected upstreams", func() {
+				list, err := client.V1().Upstreams().List(opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(list).To(Equal([]*v1.Upstream{web}))
+				list, err = client.V1().Upstreams().List(&storage.ListOptions{Selector: map[string]string{"app": "petstore"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(list).To(Equal([]*v1.Upstream{db, web}))
+			})
+			It("watches only the selected upstreams", func() {
+				lists := make(chan []*v1.Upstream, 10)
+				stop := make(chan struct{})
+				defer close(stop)
+				w, err := client.V1().Upstreams().Watch(opts, &storage.UpstreamEventHandlerFuncs{
+					UpdateFunc: func(updatedList []*v1.Upstream, _ *v1.Upstream) {
+						lists <- updatedList
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				go w.Run(stop, make(chan error))
+				Eventually(lists).Should(Receive(Equal([]*v1.Upstream{web})))
+
+				// the upstream is no longer selected once its labels stop matching
+				changed := proto.Clone(web).(*v1.Upstream)
+				changed.Metadata.Labels = map[string]string{"tier": "cache"}
+				_, err = client.V1().Upstreams().Update(changed)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(lists).Should(Receive(BeEmpty()))
+			})
+		})
+	})
+	Describe("VirtualHosts", func() {
+		It("watches", func() {
+			lists := make(chan []*v1.VirtualHost, 10)
+			stop := make(chan struct{})
+			defer close(stop)
+			w, err := client.V1().VirtualHosts().Watch(nil, &storage.VirtualHostEventHandlerFuncs{
+				UpdateFunc: func(updatedList []*v1.VirtualHost, _ *v1.VirtualHost) {
+					lists <- updatedList
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go w.Run(stop, make(chan error))
+			Eventually(lists).Should(Receive(BeEmpty()))
+
+			vh, err := client.V1().VirtualHosts().Create(&v1.VirtualHost{Name: "myvirtualhost", Domains: []string{"foo"}})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lists).Should(Receive(Equal([]*v1.VirtualHost{vh})))
+		})
+	})
+	Describe("DefaultStorage", func() {
+		It("is shared within the process", func() {
+			us, err := DefaultStorage().V1().Upstreams().Create(&v1.Upstream{Name: "shared", Type: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			defer DefaultStorage().V1().Upstreams().Delete(us.Name)
+			out, err := DefaultStorage().V1().Upstreams().Get("shared")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(us))
+			_, err = NewStorage().V1().Upstreams().Get("shared")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
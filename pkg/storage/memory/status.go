@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// statusClient keeps the status of each item apart from the item,
+// so writing a status neither changes the resource version of the item nor triggers watches on the items
+type statusClient struct {
+	lock     sync.RWMutex
+	statuses map[string]*v1.Status
+}
+
+func newStatusClient() *statusClient {
+	return &statusClient{statuses: make(map[string]*v1.Status)}
+}
+
+// returns nil if no status was written for the item
+func (c *statusClient) get(name string) (*v1.Status, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	status, ok := c.statuses[name]
+	if !ok {
+		return nil, nil
+	}
+	return proto.Clone(status).(*v1.Status), nil
+}
+
+func (c *statusClient) list() (map[string]*v1.Status, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	statuses := make(map[string]*v1.Status)
+	for name, status := range c.statuses {
+		statuses[name] = proto.Clone(status).(*v1.Status)
+	}
+	return statuses, nil
+}
+
+func (c *statusClient) put(name string, status *v1.Status) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.statuses[name] = proto.Clone(status).(*v1.Status)
+	return nil
+}
+
+func (c *statusClient) delete(name string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.statuses, name)
+	return nil
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/base"
+)
+
+type virtualHostsClient struct {
+	base     *base.MemoryStorageClient
+	statuses *statusClient
+}
+
+func (c *virtualHostsClient) Create(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	out, err := c.base.Create(&base.StorableItem{VirtualHost: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.VirtualHost, nil
+}
+
+func (c *virtualHostsClient) Update(item *v1.VirtualHost) (*v1.VirtualHost, error) {
+	out, err := c.base.Update(&base.StorableItem{VirtualHost: item})
+	if err != nil {
+		return nil, err
+	}
+	return out.VirtualHost, nil
+}
+
+func (c *virtualHostsClient) Delete(name string) error {
+	if err := c.base.Delete(name); err != nil {
+		return err
+	}
+	return c.statuses.delete(name)
+}
+
+func (c *virtualHostsClient) UpdateStatus(name string, status *v1.Status) (string, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if err := c.statuses.put(name, status); err != nil {
+		return "", err
+	}
+	return out.GetResourceVersion(), nil
+}
+
+func (c *virtualHostsClient) Get(name string) (*v1.VirtualHost, error) {
+	out, err := c.base.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	status, err := c.statuses.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		out.VirtualHost.Status = status
+	}
+	return out.VirtualHost, nil
+}
+
+func (c *virtualHostsClient) List(opts *storage.ListOptions) ([]*v1.VirtualHost, error) {
+	list, err := c.base.List()
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := c.statuses.list()
+	if err != nil {
+		return nil, err
+	}
+	var virtualHosts []*v1.VirtualHost
+	for _, obj := range list {
+		if !opts.Matches(obj.VirtualHost.GetMetadata().GetLabels()) {
+			continue
+		}
+		if status, ok := statuses[obj.GetName()]; ok {
+			obj.VirtualHost.Status = status
+		}
+		virtualHosts = append(virtualHosts, obj.VirtualHost)
+	}
+	return virtualHosts, nil
+}
+
+func (c *virtualHostsClient) Watch(opts *storage.ListOptions, handlers ...storage.VirtualHostEventHandler) (*storage.Watcher, error) {
+	var baseHandlers []base.StorableItemEventHandler
+	for _, h := range handlers {
+		baseHandlers = append(baseHandlers, base.StorableItemEventHandler{VirtualHostEventHandler: storage.VirtualHostSelector(opts, h)})
+	}
+	return c.base.Watch(baseHandlers...)
+}
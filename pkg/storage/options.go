@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/solo-io/gloo/pkg/api/types/v1"
+)
+
+// ListOptions select the items returned by List and Watch. nil options select every item
+type ListOptions struct {
+	// only items that have all of these labels, with the same values, are selected.
+	// an empty selector selects every item
+	Selector map[string]string
+}
+
+// Matches returns true if an item with the labels is selected
+func (o *ListOptions) Matches(labels map[string]string) bool {
+	if o == nil {
+		return true
+	}
+	for k, v := range o.Selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the format of kubernetes label selectors, e.g. "app=petstore,tier=web"
+func (o *ListOptions) String() string {
+	if o == nil {
+		return ""
+	}
+	var requirements []string
+	for k, v := range o.Selector {
+		requirements = append(requirements, k+"="+v)
+	}
+	sort.Strings(requirements)
+	return strings.Join(requirements, ",")
+}
+
+// SelectUpstreams returns the selected upstreams
+func SelectUpstreams(opts *ListOptions, upstreams []*v1.Upstream) []*v1.Upstream {
+	if opts == nil || len(opts.Selector) == 0 {
+		return upstreams
+	}
+	var selected []*v1.Upstream
+	for _, us := range upstreams {
+		if opts.Matches(us.GetMetadata().GetLabels()) {
+			selected = append(selected, us)
+		}
+	}
+	return selected
+}
+
+// SelectVirtualHosts returns the selected virtual hosts
+func SelectVirtualHosts(opts *ListOptions, virtualHosts []*v1.VirtualHost) []*v1.VirtualHost {
+	if opts == nil || len(opts.Selector) == 0 {
+		return virtualHosts
+	}
+	var selected []*v1.VirtualHost
+	for _, vh := range virtualHosts {
+		if opts.Matches(vh.GetMetadata().GetLabels()) {
+			selected = append(selected, vh)
+		}
+	}
+	return selected
+}
+
+// UpstreamSelector wraps the handler so it is only given the selected upstreams.
+// a change to an upstream that is not selected, e.g. because its labels no longer match,
+// is passed on as an update without an object, so the handler still receives the new list
+func UpstreamSelector(opts *ListOptions, handler UpstreamEventHandler) UpstreamEventHandler {
+	if opts == nil || len(opts.Selector) == 0 {
+		return handler
+	}
+	return &upstreamSelector{opts: opts, handler: handler}
+}
+
+type upstreamSelector struct {
+	opts    *ListOptions
+	handler UpstreamEventHandler
+}
+
+// events without an object are always passed on
+func (s *upstreamSelector) selected(obj *v1.Upstream) bool {
+	return obj == nil || s.opts.Matches(obj.GetMetadata().GetLabels())
+}
+
+func (s *upstreamSelector) OnAdd(updatedList []*v1.Upstream, obj *v1.Upstream) {
+	if !s.selected(obj) {
+		s.handler.OnUpdate(SelectUpstreams(s.opts, updatedList), nil)
+		return
+	}
+	s.handler.OnAdd(SelectUpstreams(s.opts, updatedList), obj)
+}
+
+func (s *upstreamSelector) OnUpdate(updatedList []*v1.Upstream, newObj *v1.Upstream) {
+	if !s.selected(newObj) {
+		newObj = nil
+	}
+	s.handler.OnUpdate(SelectUpstreams(s.opts, updatedList), newObj)
+}
+
+func (s *upstreamSelector) OnDelete(updatedList []*v1.Upstream, obj *v1.Upstream) {
+	if !s.selected(obj) {
+		s.handler.OnUpdate(SelectUpstreams(s.opts, updatedList), nil)
+		return
+	}
+	s.handler.OnDelete(SelectUpstreams(s.opts, updatedList), obj)
+}
+
+// VirtualHostSelector wraps the handler so it is only given the selected virtual hosts.
+// a change to a virtual host that is not selected, e.g. because its labels no longer match,
+// is passed on as an update without an object, so the handler still receives the new list
+func VirtualHostSelector(opts *ListOptions, handler VirtualHostEventHandler) VirtualHostEventHandler {
+	if opts == nil || len(opts.Selector) == 0 {
+		return handler
+	}
+	return &virtualHostSelector{opts: opts, handler: handler}
+}
+
+type virtualHostSelector struct {
+	opts    *ListOptions
+	handler VirtualHostEventHandler
+}
+
+// events without an object are always passed on
+func (s *virtualHostSelector) selected(obj *v1.VirtualHost) bool {
+	return obj == nil || s.opts.Matches(obj.GetMetadata().GetLabels())
+}
+
+func (s *virtualHostSelector) OnAdd(updatedList []*v1.VirtualHost, obj *v1.VirtualHost) {
+	if !s.selected(obj) {
+		s.handler.OnUpdate(SelectVirtualHosts(s.opts, updatedList), nil)
+		return
+	}
+	s.handler.OnAdd(SelectVirtualHosts(s.opts, updatedList), obj)
+}
+
+func (s *virtualHostSelector) OnUpdate(updatedList []*v1.VirtualHost, newObj *v1.VirtualHost) {
+	if !s.selected(newObj) {
+		newObj = nil
+	}
+	s.handler.OnUpdate(SelectVirtualHosts(s.opts, updatedList), newObj)
+}
+
+func (s *virtualHostSelector) OnDelete(updatedList []*v1.VirtualHost, obj *v1.VirtualHost) {
+	if !s.selected(obj) {
+		s.handler.OnUpdate(SelectVirtualHosts(s.opts, updatedList), nil)
+		return
+	}
+	s.handler.OnDelete(SelectVirtualHosts(s.opts, updatedList), obj)
+}
@@ -0,0 +1,82 @@
+package localhelpers
+
+import (
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/embed"
+	"github.com/pkg/errors"
+)
+
+// EtcdInstance is a single member etcd cluster embedded in the test process
+type EtcdInstance struct {
+	tmpdir string
+	cfg    *embed.Config
+	etcd   *embed.Etcd
+}
+
+func NewEtcdInstance() (*EtcdInstance, error) {
+	tmpdir, err := ioutil.TempDir(os.Getenv("HELPER_TMP"), "etcd")
+	if err != nil {
+		return nil, err
+	}
+	clientURL, err := freeLocalURL()
+	if err != nil {
+		return nil, err
+	}
+	peerURL, err := freeLocalURL()
+	if err != nil {
+		return nil, err
+	}
+	cfg := embed.NewConfig()
+	cfg.Dir = tmpdir
+	cfg.LCUrls, cfg.ACUrls = []url.URL{*clientURL}, []url.URL{*clientURL}
+	cfg.LPUrls, cfg.APUrls = []url.URL{*peerURL}, []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+	return &EtcdInstance{tmpdir: tmpdir, cfg: cfg}, nil
+}
+
+func (i *EtcdInstance) Run() error {
+	e, err := embed.StartEtcd(i.cfg)
+	if err != nil {
+		return err
+	}
+	i.etcd = e
+	select {
+	case <-e.Server.ReadyNotify():
+		return nil
+	case err := <-e.Err():
+		return err
+	case <-time.After(time.Minute):
+		e.Close()
+		return errors.New("timed out waiting for etcd to start")
+	}
+}
+
+// Config returns a client config for the instance
+func (i *EtcdInstance) Config() clientv3.Config {
+	return clientv3.Config{
+		Endpoints:   []string{i.cfg.ACUrls[0].String()},
+		DialTimeout: 5 * time.Second,
+	}
+}
+
+func (i *EtcdInstance) Clean() error {
+	if i.etcd != nil {
+		i.etcd.Close()
+	}
+	return os.RemoveAll(i.tmpdir)
+}
+
+func freeLocalURL() (*url.URL, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer lis.Close()
+	return url.Parse("http://" + lis.Addr().String())
+}
@@ -25,7 +25,7 @@ var _ = Describe("Fission Function Discovery Service Detection", func() {
 
 	It("should detect the upstream service info", func() {
 		Eventually(func() (*v1.ServiceInfo, error) {
-			list, err := gloo.V1().Upstreams().List()
+			list, err := gloo.V1().Upstreams().List(nil)
 			if err != nil {
 				return nil, err
 			}
@@ -35,7 +35,7 @@ var _ = Describe("Function Discovery Service Detection", func() {
 		Context("discovery for "+test.description+" upstreams", func() {
 			It("should detect the upstream service info", func() {
 				Eventually(func() (*v1.ServiceInfo, error) {
-					list, err := gloo.V1().Upstreams().List()
+					list, err := gloo.V1().Upstreams().List(nil)
 					if err != nil {
 						return nil, err
 					}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	envoyutil "github.com/envoyproxy/go-control-plane/pkg/util"
+	"github.com/k0kubun/pp"
+
+	"github.com/solo-io/gloo/test/utilities/xdsclient"
+)
+
+func main() {
+	client, err := xdsclient.NewClient("localhost:8081")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	_, clusters, err := client.Clusters(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, cluster := range clusters {
+		pp.Printf("%v\n", cluster)
+	}
+
+	_, listeners, err := client.Listeners(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var routeNames []string
+	for _, l := range listeners {
+		pp.Printf("%v\n", l)
+		for _, fc := range l.FilterChains {
+			for _, filter := range fc.Filters {
+				if filter.Name == "envoy.http_connection_manager" {
+					var hcm envoyhttp.HttpConnectionManager
+					if err := envoyutil.StructToMessage(filter.Config, &hcm); err == nil {
+						routeNames = append(routeNames, hcm.RouteSpecifier.(*envoyhttp.HttpConnectionManager_Rds).Rds.RouteConfigName)
+					}
+				}
+			}
+		}
+	}
+
+	_, routes, err := client.Routes(ctx, routeNames)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, route := range routes {
+		pp.Printf("%v\n", route)
+	}
+
+	_, clas, err := client.Endpoints(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, cla := range clas {
+		pp.Printf("%v\n", cla)
+	}
+}
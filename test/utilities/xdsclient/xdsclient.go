@@ -0,0 +1,109 @@
+package xdsclient
+
+import (
+	"context"
+
+	"github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoycore "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// Client fetches resources from an xDS server the same way Envoy would
+type Client struct {
+	conn *grpc.ClientConn
+	node *envoycore.Node
+}
+
+func NewClient(addr string) (*Client, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, errors.Wrapf(err, "dialing xds server at %v", addr)
+	}
+	return &Client{
+		conn: conn,
+		node: &envoycore.Node{
+			Id:      "oneid",
+			Cluster: "ingress",
+		},
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) request(resourceNames ...string) *v2.DiscoveryRequest {
+	return &v2.DiscoveryRequest{
+		Node:          c.node,
+		ResourceNames: resourceNames,
+	}
+}
+
+// Clusters returns the version and contents of the clusters currently served
+func (c *Client) Clusters(ctx context.Context) (string, []v2.Cluster, error) {
+	resp, err := v2.NewClusterDiscoveryServiceClient(c.conn).FetchClusters(ctx, c.request())
+	if err != nil {
+		return "", nil, errors.Wrap(err, "fetching clusters")
+	}
+	var clusters []v2.Cluster
+	for _, anyCluster := range resp.Resources {
+		var cluster v2.Cluster
+		if err := cluster.Unmarshal(anyCluster.Value); err != nil {
+			return "", nil, errors.Wrap(err, "unmarshalling cluster")
+		}
+		clusters = append(clusters, cluster)
+	}
+	return resp.VersionInfo, clusters, nil
+}
+
+// Endpoints returns the version and contents of the cluster load assignments currently served
+func (c *Client) Endpoints(ctx context.Context) (string, []v2.ClusterLoadAssignment, error) {
+	resp, err := v2.NewEndpointDiscoveryServiceClient(c.conn).FetchEndpoints(ctx, c.request())
+	if err != nil {
+		return "", nil, errors.Wrap(err, "fetching endpoints")
+	}
+	var clas []v2.ClusterLoadAssignment
+	for _, anyCla := range resp.Resources {
+		var cla v2.ClusterLoadAssignment
+		if err := cla.Unmarshal(anyCla.Value); err != nil {
+			return "", nil, errors.Wrap(err, "unmarshalling cluster load assignment")
+		}
+		clas = append(clas, cla)
+	}
+	return resp.VersionInfo, clas, nil
+}
+
+// Listeners returns the version and contents of the listeners currently served
+func (c *Client) Listeners(ctx context.Context) (string, []v2.Listener, error) {
+	resp, err := v2.NewListenerDiscoveryServiceClient(c.conn).FetchListeners(ctx, c.request())
+	if err != nil {
+		return "", nil, errors.Wrap(err, "fetching listeners")
+	}
+	var listeners []v2.Listener
+	for _, anyListener := range resp.Resources {
+		var listener v2.Listener
+		if err := listener.Unmarshal(anyListener.Value); err != nil {
+			return "", nil, errors.Wrap(err, "unmarshalling listener")
+		}
+		listeners = append(listeners, listener)
+	}
+	return resp.VersionInfo, listeners, nil
+}
+
+// Routes returns the version and contents of the named route configurations currently served
+func (c *Client) Routes(ctx context.Context, routeNames []string) (string, []v2.RouteConfiguration, error) {
+	resp, err := v2.NewRouteDiscoveryServiceClient(c.conn).FetchRoutes(ctx, c.request(routeNames...))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "fetching routes")
+	}
+	var routes []v2.RouteConfiguration
+	for _, anyRoute := range resp.Resources {
+		var route v2.RouteConfiguration
+		if err := route.Unmarshal(anyRoute.Value); err != nil {
+			return "", nil, errors.Wrap(err, "unmarshalling route configuration")
+		}
+		routes = append(routes, route)
+	}
+	return resp.VersionInfo, routes, nil
+}